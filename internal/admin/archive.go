@@ -0,0 +1,298 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/backup"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ArchiveTimeout is the maximum duration for moving rows to or from archive tables.
+const ArchiveTimeout = 5 * time.Minute
+
+// ArchiveSpec names a table that can be archived by period, and the date
+// column that places each row in a period.
+type ArchiveSpec struct {
+	Table  string
+	Column string
+}
+
+// ArchiveTable returns the archive table holding this table's closed periods.
+func (s ArchiveSpec) ArchiveTable() string {
+	return s.Table + "_archive"
+}
+
+func (s ArchiveSpec) column() string {
+	return pgx.Identifier{s.Column}.Sanitize()
+}
+
+// ArchiveSpecs lists the archivable tables. Rows with no date are never archived.
+var ArchiveSpecs = []ArchiveSpec{
+	{Table: "ns_so_detail", Column: "document_date"},
+	{Table: "ns_invoice_detail", Column: "date"},
+	{Table: "sfdc_opp_detail", Column: "close_date"},
+	{Table: "anrok_transactions", Column: "invoice_date"},
+}
+
+// archiveFor returns the archive spec of table, if it is archivable.
+func archiveFor(table string) (ArchiveSpec, bool) {
+	for _, s := range ArchiveSpecs {
+		if s.Table == table {
+			return s, true
+		}
+	}
+	return ArchiveSpec{}, false
+}
+
+// Period is a calendar month.
+type Period struct {
+	Year  int
+	Month time.Month
+}
+
+// ParsePeriod parses "YYYY-MM".
+func ParsePeriod(s string) (Period, error) {
+	t, err := time.Parse("2006-01", strings.TrimSpace(s))
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid period %q: use YYYY-MM", s)
+	}
+	return Period{Year: t.Year(), Month: t.Month()}, nil
+}
+
+func (p Period) String() string {
+	return fmt.Sprintf("%04d-%02d", p.Year, int(p.Month))
+}
+
+// Start returns the first day of the period.
+func (p Period) Start() time.Time {
+	return time.Date(p.Year, p.Month, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// End returns the first day after the period.
+func (p Period) End() time.Time {
+	return p.Start().AddDate(0, 1, 0)
+}
+
+// Archiver moves closed periods between live and archive tables.
+type Archiver struct {
+	Pool *pgxpool.Pool
+}
+
+/* ----------------------------------------
+	Archive
+---------------------------------------- */
+
+// CountArchive returns, per table, the live rows dated in or before through.
+func (a *Archiver) CountArchive(ctx context.Context, through Period) ([]TableCount, error) {
+	return a.count(ctx, func(s ArchiveSpec) (string, string) { return s.Table, s.column() + " < $1" }, through.End())
+}
+
+// Archive moves every row dated in or before through into the archive tables
+// in one transaction, after backing up the tables involved.
+func (a *Archiver) Archive(through Period) tea.Cmd {
+	return a.move("Archived through "+through.String(), "archive through "+through.String(),
+		func(s ArchiveSpec) (string, string, string) { return s.Table, s.ArchiveTable(), s.column() + " < $1" },
+		through.End())
+}
+
+/* ----------------------------------------
+	Restore
+---------------------------------------- */
+
+// CountRestore returns, per table, the archived rows dated in period.
+func (a *Archiver) CountRestore(ctx context.Context, period Period) ([]TableCount, error) {
+	return a.count(ctx, func(s ArchiveSpec) (string, string) {
+		return s.ArchiveTable(), s.column() + " >= $1 AND " + s.column() + " < $2"
+	},
+		period.Start(), period.End())
+}
+
+// RestorePeriod moves one archived period back into the live tables.
+func (a *Archiver) RestorePeriod(period Period) tea.Cmd {
+	return a.move("Restored "+period.String(), "restore archived "+period.String(),
+		func(s ArchiveSpec) (string, string, string) {
+			return s.ArchiveTable(), s.Table, s.column() + " >= $1 AND " + s.column() + " < $2"
+		},
+		period.Start(), period.End())
+}
+
+/* ----------------------------------------
+	Status
+---------------------------------------- */
+
+// Status reports live and archived row counts and the archived date range per table.
+func (a *Archiver) Status() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), StatusTimeout)
+		defer cancel()
+
+		var sb strings.Builder
+		sb.WriteString("Archive Status\n\n")
+		tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "Table\tLive rows\tArchived rows\tArchived dates")
+
+		for _, s := range ArchiveSpecs {
+			var live, archived int64
+			var first, last *time.Time
+			err := a.Pool.QueryRow(ctx, fmt.Sprintf(`
+				SELECT (SELECT count(*) FROM %s), count(*), min(%s), max(%s) FROM %s`,
+				pgx.Identifier{s.Table}.Sanitize(),
+				s.column(), s.column(),
+				pgx.Identifier{s.ArchiveTable()}.Sanitize(),
+			)).Scan(&live, &archived, &first, &last)
+			if err != nil {
+				return handler.ErrMsg{Err: fmt.Errorf("reading archive status for %s: %w", s.Table, err)}
+			}
+
+			dates := "-"
+			if first != nil && last != nil {
+				dates = first.Format("2006-01-02") + " to " + last.Format("2006-01-02")
+			}
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%s\n", s.Table, live, archived, dates)
+		}
+		tw.Flush()
+
+		return handler.WdMsg(strings.TrimRight(sb.String(), "\n"))
+	}
+}
+
+/* ----------------------------------------
+	Helpers
+---------------------------------------- */
+
+func (a *Archiver) count(ctx context.Context, where func(ArchiveSpec) (table, cond string), args ...any) ([]TableCount, error) {
+	var counts []TableCount
+	for _, s := range ArchiveSpecs {
+		table, cond := where(s)
+		var n int64
+		if err := a.Pool.QueryRow(ctx, `SELECT count(*) FROM `+pgx.Identifier{table}.Sanitize()+` WHERE `+cond, args...).Scan(&n); err != nil {
+			return nil, fmt.Errorf("counting %s: %w", table, err)
+		}
+		counts = append(counts, TableCount{Table: table, Rows: n})
+	}
+	return counts, nil
+}
+
+// move backs up every live, archive and derived table, then moves matching
+// rows from one side to the other and rebuilds the derived tables in a single
+// transaction. Derived tables are built from live and archived rows alike, so
+// the rebuild only catches up tables that were built from live rows alone.
+func (a *Archiver) move(title, reason string, route func(ArchiveSpec) (from, to, cond string), args ...any) tea.Cmd {
+	return func() tea.Msg {
+		var tables, rebuilt []string
+		var regs []handler.Registration
+		for _, s := range ArchiveSpecs {
+			tables = append(tables, s.Table, s.ArchiveTable())
+		}
+		for _, reg := range handler.Registrations(a.Pool) {
+			if _, ok := archiveFor(reg.Props.Table()); !ok || len(DerivedTables[reg.Props.Table()]) == 0 {
+				continue
+			}
+			regs = append(regs, reg)
+			for _, table := range DerivedTables[reg.Props.Table()] {
+				if !slices.Contains(rebuilt, table) {
+					rebuilt = append(rebuilt, table)
+				}
+			}
+		}
+		tables = append(tables, rebuilt...)
+
+		bctx, bcancel := context.WithTimeout(context.Background(), backup.Timeout)
+		saved, err := backup.Create(bctx, a.Pool, reason, tables)
+		bcancel()
+		if err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("backup failed, nothing was moved: %w", err)}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), ArchiveTimeout)
+		defer cancel()
+
+		tx, err := a.Pool.Begin(ctx)
+		if err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("failed to begin transaction: %w", err)}
+		}
+		defer func() { _ = tx.Rollback(ctx) }()
+
+		var counts []TableCount
+		for _, s := range ArchiveSpecs {
+			from, to, cond := route(s)
+
+			cols, err := liveColumns(ctx, tx, s.Table)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+
+			tag, err := tx.Exec(ctx, fmt.Sprintf(`
+				WITH moved AS (DELETE FROM %s WHERE %s RETURNING %s)
+				INSERT INTO %s (%s) SELECT %s FROM moved`,
+				pgx.Identifier{from}.Sanitize(), cond, cols,
+				pgx.Identifier{to}.Sanitize(), cols, cols), args...)
+			if err != nil {
+				return handler.ErrMsg{Err: fmt.Errorf("moving %s to %s: %w", from, to, err)}
+			}
+			counts = append(counts, TableCount{Table: from, Rows: tag.RowsAffected()})
+		}
+
+		queries := db.New(tx)
+		for _, reg := range regs {
+			if err := reg.Props.Rebuild(ctx, queries); err != nil {
+				return handler.ErrMsg{Err: fmt.Errorf("rebuilding tables derived from %s: %w", reg.Props.Table(), err)}
+			}
+		}
+
+		if err := tx.Commit(ctx); err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("failed to commit transaction: %w", err)}
+		}
+
+		return handler.DoneMsg(title + ".\n\n" + FormatTableCounts(counts, "rows moved") +
+			"\n\nRebuilt " + strings.Join(rebuilt, ", ") + ".\n\nBackup saved to " + saved.Path)
+	}
+}
+
+// liveColumns returns the live table's columns as a sanitized, comma-separated
+// list. Archive tables have the same columns plus archived_at.
+func liveColumns(ctx context.Context, tx pgx.Tx, table string) (string, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT column_name
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return "", fmt.Errorf("reading columns for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var cols []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return "", err
+		}
+		cols = append(cols, pgx.Identifier{name}.Sanitize())
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if len(cols) == 0 {
+		return "", fmt.Errorf("table %s not found", table)
+	}
+	return strings.Join(cols, ", "), nil
+}
+
+// FormatTableCounts renders one line per table with a unit label.
+func FormatTableCounts(counts []TableCount, unit string) string {
+	lines := make([]string, len(counts))
+	for i, c := range counts {
+		lines[i] = fmt.Sprintf("  %-28s %d %s", c.Table, c.Rows, unit)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,100 @@
+package admin
+
+import (
+	"testing"
+	"time"
+)
+
+/* ========================================
+	Period Tests
+======================================== */
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Period
+		wantErr bool
+	}{
+		{"2024-03", Period{2024, time.March}, false},
+		{" 2024-12 ", Period{2024, time.December}, false},
+		{"2024-13", Period{}, true},
+		{"2024/03", Period{}, true},
+		{"March 2024", Period{}, true},
+		{"", Period{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePeriod(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePeriod(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePeriod(%q) = %v, expected %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPeriod_Bounds(t *testing.T) {
+	tests := []struct {
+		period    Period
+		wantStart string
+		wantEnd   string
+	}{
+		{Period{2024, time.March}, "2024-03-01", "2024-04-01"},
+		{Period{2024, time.December}, "2024-12-01", "2025-01-01"},
+		{Period{2024, time.February}, "2024-02-01", "2024-03-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period.String(), func(t *testing.T) {
+			if got := tt.period.Start().Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("Start() = %q, expected %q", got, tt.wantStart)
+			}
+			if got := tt.period.End().Format("2006-01-02"); got != tt.wantEnd {
+				t.Errorf("End() = %q, expected %q", got, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestPeriod_String(t *testing.T) {
+	if got := (Period{2025, time.January}).String(); got != "2025-01" {
+		t.Errorf("String() = %q, expected %q", got, "2025-01")
+	}
+}
+
+/* ========================================
+	ArchiveSpec Tests
+======================================== */
+
+func TestArchiveSpecs(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, spec := range ArchiveSpecs {
+		if seen[spec.Table] {
+			t.Errorf("duplicate spec for %s", spec.Table)
+		}
+		seen[spec.Table] = true
+
+		if got, want := spec.ArchiveTable(), spec.Table+"_archive"; got != want {
+			t.Errorf("ArchiveTable() = %q, expected %q", got, want)
+		}
+		if spec.column() != `"`+spec.Column+`"` {
+			t.Errorf("column() = %s, expected quoted %s", spec.column(), spec.Column)
+		}
+	}
+}
+
+func TestFormatTableCounts(t *testing.T) {
+	got := FormatTableCounts([]TableCount{
+		{Table: "ns_so_detail", Rows: 12},
+		{Table: "anrok_transactions", Rows: 0},
+	}, "rows")
+
+	want := "  ns_so_detail                 12 rows\n" +
+		"  anrok_transactions           0 rows"
+	if got != want {
+		t.Errorf("FormatTableCounts() =\n%s\nexpected\n%s", got, want)
+	}
+}
@@ -0,0 +1,225 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/migrate"
+	"github.com/JonMunkholm/TUI/internal/schema"
+	sqlfiles "github.com/JonMunkholm/TUI/sql"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SchemaCheckTimeout is the maximum duration for a schema consistency check.
+const SchemaCheckTimeout = 15 * time.Second
+
+// ignoredColumns are table columns managed by the database rather than by
+// FieldSpecs, so they are never reported as extra.
+var ignoredColumns = map[string]bool{
+	"id":          true,
+	"source_file": true,
+}
+
+// SchemaIssue describes one disagreement between FieldSpecs, sqlc params and the live database.
+type SchemaIssue struct {
+	Table   string
+	Column  string
+	Problem string
+}
+
+func (i SchemaIssue) String() string {
+	switch {
+	case i.Table == "":
+		return i.Problem
+	case i.Column == "":
+		return fmt.Sprintf("%s: %s", i.Table, i.Problem)
+	default:
+		return fmt.Sprintf("%s.%s: %s", i.Table, i.Column, i.Problem)
+	}
+}
+
+// SchemaChecker compares every registered upload type against the database.
+type SchemaChecker struct {
+	Pool *pgxpool.Pool
+}
+
+// Run checks the schema and reports the result as a message.
+func (c *SchemaChecker) Run() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), SchemaCheckTimeout)
+		defer cancel()
+
+		issues, err := CheckSchema(ctx, c.Pool, handler.Registrations(c.Pool))
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return handler.WdMsg(FormatSchemaIssues(issues))
+	}
+}
+
+// FormatSchemaIssues renders issues as a report for display.
+func FormatSchemaIssues(issues []SchemaIssue) string {
+	if len(issues) == 0 {
+		return "Schema check passed: FieldSpecs, sqlc params and database tables agree."
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Schema check found %d issue(s):\n", len(issues))
+	for _, issue := range issues {
+		sb.WriteString("\n  " + issue.String())
+	}
+	return sb.String()
+}
+
+// CheckSchema compares each registration's FieldSpecs and sqlc insert params
+// to the live table's columns, and reports migrations that were never applied.
+func CheckSchema(ctx context.Context, pool *pgxpool.Pool, regs []handler.Registration) ([]SchemaIssue, error) {
+	issues, err := checkMigrations(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reg := range regs {
+		table := reg.Props.Table()
+
+		cols, err := tableColumns(ctx, pool, table)
+		if err != nil {
+			return nil, fmt.Errorf("reading columns for %s: %w", table, err)
+		}
+
+		issues = append(issues, compareTable(table, reg.Props.Specs(), reg.Props.ParamColumns(), cols)...)
+	}
+
+	return issues, nil
+}
+
+// compareTable reports disagreements for a single table. cols maps column
+// name to information_schema data_type; an empty map means the table is missing.
+func compareTable(table string, specs []schema.FieldSpec, params []string, cols map[string]string) []SchemaIssue {
+	if len(cols) == 0 {
+		return []SchemaIssue{{Table: table, Problem: "table does not exist (migration not applied?)"}}
+	}
+
+	var issues []SchemaIssue
+
+	paramSet := make(map[string]bool, len(params))
+	for _, p := range params {
+		paramSet[p] = true
+	}
+
+	specSet := make(map[string]bool, len(specs))
+	for _, spec := range specs {
+		col := spec.ColumnName()
+		specSet[col] = true
+
+		dataType, ok := cols[col]
+		if !ok {
+			issues = append(issues, SchemaIssue{Table: table, Column: col, Problem: "missing column (FieldSpec " + strconv.Quote(spec.Name) + " has no column)"})
+		} else if want := columnTypes(spec.Type); !want[dataType] {
+			issues = append(issues, SchemaIssue{Table: table, Column: col, Problem: fmt.Sprintf("type mismatch: %s spec, column is %s", fieldTypeName(spec.Type), dataType)})
+		}
+
+		if !paramSet[col] {
+			issues = append(issues, SchemaIssue{Table: table, Column: col, Problem: "FieldSpec has no sqlc insert param"})
+		}
+	}
+
+	for _, p := range params {
+		if !specSet[p] {
+			issues = append(issues, SchemaIssue{Table: table, Column: p, Problem: "sqlc insert param has no FieldSpec"})
+		}
+	}
+
+	var extra []string
+	for col := range cols {
+		if !specSet[col] && !ignoredColumns[col] {
+			extra = append(extra, col)
+		}
+	}
+	sort.Strings(extra)
+	for _, col := range extra {
+		issues = append(issues, SchemaIssue{Table: table, Column: col, Problem: "extra column (no FieldSpec)"})
+	}
+
+	return issues
+}
+
+// columnTypes returns the information_schema data types accepted for a field type.
+func columnTypes(t schema.FieldType) map[string]bool {
+	switch t {
+	case schema.FieldDate:
+		return map[string]bool{"date": true}
+	case schema.FieldNumeric:
+		return map[string]bool{"numeric": true, "integer": true, "bigint": true, "smallint": true, "double precision": true, "real": true}
+	case schema.FieldBool:
+		return map[string]bool{"boolean": true}
+	default:
+		return map[string]bool{"text": true, "character varying": true, "character": true}
+	}
+}
+
+func fieldTypeName(t schema.FieldType) string {
+	switch t {
+	case schema.FieldEnum:
+		return "FieldEnum"
+	case schema.FieldDate:
+		return "FieldDate"
+	case schema.FieldNumeric:
+		return "FieldNumeric"
+	case schema.FieldBool:
+		return "FieldBool"
+	default:
+		return "FieldText"
+	}
+}
+
+// tableColumns returns column name -> data type for a table in the current schema.
+func tableColumns(ctx context.Context, pool *pgxpool.Pool, table string) (map[string]string, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT column_name, data_type
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cols := make(map[string]string)
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return nil, err
+		}
+		cols[name] = dataType
+	}
+	return cols, rows.Err()
+}
+
+/* ----------------------------------------
+	Migrations
+---------------------------------------- */
+
+// checkMigrations reports embedded migrations missing from goose_db_version.
+func checkMigrations(ctx context.Context, pool *pgxpool.Pool) ([]SchemaIssue, error) {
+	runner, err := migrate.New(pool, sqlfiles.Migrations, sqlfiles.MigrationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := runner.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []SchemaIssue
+	for _, m := range pending {
+		issues = append(issues, SchemaIssue{Problem: fmt.Sprintf("migration %s was never applied", m.File)})
+	}
+	return issues, nil
+}
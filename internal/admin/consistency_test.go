@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/schema"
+)
+
+/* ========================================
+	compareTable Tests
+======================================== */
+
+func TestCompareTable_Consistent(t *testing.T) {
+	specs := []schema.FieldSpec{
+		{Name: "Invoice date", Column: "invoice_date", Type: schema.FieldDate},
+		{Name: "amount", Type: schema.FieldNumeric},
+		{Name: "memo", Type: schema.FieldText},
+	}
+	params := []string{"invoice_date", "amount", "memo"}
+	cols := map[string]string{"id": "uuid", "invoice_date": "date", "amount": "numeric", "memo": "text"}
+
+	if issues := compareTable("t", specs, params, cols); len(issues) != 0 {
+		t.Errorf("compareTable() = %v, expected no issues", issues)
+	}
+}
+
+func TestCompareTable_Issues(t *testing.T) {
+	specs := []schema.FieldSpec{
+		{Name: "invoice_date", Type: schema.FieldDate},
+		{Name: "amount", Type: schema.FieldNumeric},
+		{Name: "not_in_table", Type: schema.FieldText},
+	}
+	params := []string{"invoice_date", "not_in_table", "param_only"}
+	cols := map[string]string{"id": "uuid", "invoice_date": "text", "amount": "numeric", "extra_col": "text"}
+
+	issues := compareTable("t", specs, params, cols)
+
+	want := []string{
+		"t.invoice_date: type mismatch: FieldDate spec, column is text",
+		"t.amount: FieldSpec has no sqlc insert param",
+		"t.not_in_table: missing column",
+		"t.param_only: sqlc insert param has no FieldSpec",
+		"t.extra_col: extra column (no FieldSpec)",
+	}
+
+	report := FormatSchemaIssues(issues)
+	for _, w := range want {
+		if !strings.Contains(report, w) {
+			t.Errorf("report missing %q:\n%s", w, report)
+		}
+	}
+}
+
+func TestCompareTable_MissingTable(t *testing.T) {
+	issues := compareTable("t", []schema.FieldSpec{{Name: "a"}}, []string{"a"}, map[string]string{})
+
+	if len(issues) != 1 || !strings.Contains(issues[0].Problem, "does not exist") {
+		t.Errorf("compareTable() = %v, expected single missing-table issue", issues)
+	}
+}
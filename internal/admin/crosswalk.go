@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/crosswalk"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CrosswalkTimeout is the maximum duration for rebuilding or editing the customer crosswalk.
+const CrosswalkTimeout = 60 * time.Second
+
+// Crosswalk rebuilds customer_crosswalk and edits its manual overrides.
+type Crosswalk struct {
+	Pool *pgxpool.Pool
+}
+
+// Rebuild refreshes the imported links from ns_customers.
+func (c *Crosswalk) Rebuild() tea.Cmd {
+	return c.run(func(ctx context.Context, q *db.Queries) (string, error) {
+		if err := crosswalk.Rebuild(ctx, q); err != nil {
+			return "", err
+		}
+		return "Customer crosswalk rebuilt.", nil
+	})
+}
+
+// SetOverride links nsInternalID to sfdcAccountID manually; a blank account
+// ID records that the customer has no Salesforce account.
+func (c *Crosswalk) SetOverride(nsInternalID, sfdcAccountID string) tea.Cmd {
+	return c.run(func(ctx context.Context, q *db.Queries) (string, error) {
+		if err := crosswalk.SetOverride(ctx, q, nsInternalID, sfdcAccountID, "set in TUI"); err != nil {
+			return "", err
+		}
+		if sfdcAccountID == "" {
+			return fmt.Sprintf("NS customer %s is now marked as having no SFDC account.", nsInternalID), nil
+		}
+		return fmt.Sprintf("NS customer %s is now linked to %s.", nsInternalID, crosswalk.AccountID(sfdcAccountID).String), nil
+	})
+}
+
+// ClearOverride removes the manual override for nsInternalID.
+func (c *Crosswalk) ClearOverride(nsInternalID string) tea.Cmd {
+	return c.run(func(ctx context.Context, q *db.Queries) (string, error) {
+		removed, err := crosswalk.ClearOverride(ctx, q, nsInternalID)
+		if err != nil {
+			return "", err
+		}
+		if !removed {
+			return fmt.Sprintf("NS customer %s has no manual override.", nsInternalID), nil
+		}
+		return fmt.Sprintf("Manual override for NS customer %s removed.", nsInternalID), nil
+	})
+}
+
+// run executes fn in a transaction and reports its message.
+func (c *Crosswalk) run(fn func(ctx context.Context, q *db.Queries) (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), CrosswalkTimeout)
+		defer cancel()
+
+		var msg string
+		err := pgx.BeginFunc(ctx, c.Pool, func(tx pgx.Tx) error {
+			var err error
+			msg, err = fn(ctx, db.New(tx))
+			return err
+		})
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return handler.DoneMsg(msg)
+	}
+}
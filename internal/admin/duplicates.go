@@ -0,0 +1,276 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/backup"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/report"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DuplicateTimeout is the maximum duration for scanning or removing duplicates.
+const DuplicateTimeout = 2 * time.Minute
+
+// FullRow is the key that compares every column except id and source_file.
+const FullRow = "*"
+
+// DuplicateKeys are the default key columns per table. Tables not listed
+// are compared by full row.
+var DuplicateKeys = map[string][]string{
+	"anrok_transactions": {"transaction_id"},
+	"ns_customers":       {"internal_id"},
+	"ns_invoice_detail":  {"document_number", "sfdc_opp_line_id", "item", "amount"},
+	"ns_so_detail":       {"so_number", "sfdc_opp_line_id", "item_name", "amount_gross"},
+	"sfdc_customers":     {"account_id_casesafe"},
+	"sfdc_opp_detail":    {"opportunity_product_casesafe_id"},
+	"sfdc_price_book":    {"price_book_name", "product_code"},
+}
+
+// DefaultDuplicateKey returns the default key for table.
+func DefaultDuplicateKey(table string) []string {
+	if key, ok := DuplicateKeys[table]; ok {
+		return key
+	}
+	return []string{FullRow}
+}
+
+// ParseDuplicateKey reads a comma-separated column list. Blank means the
+// table's default key and "*" the full row.
+func ParseDuplicateKey(table, s string) []string {
+	var key []string
+	for _, col := range strings.Split(s, ",") {
+		if col = strings.ToLower(strings.TrimSpace(col)); col != "" {
+			key = append(key, col)
+		}
+	}
+	if len(key) == 0 {
+		return DefaultDuplicateKey(table)
+	}
+	for _, col := range key {
+		if col == FullRow {
+			return []string{FullRow}
+		}
+	}
+	return key
+}
+
+// FormatDuplicateKey renders a key for display.
+func FormatDuplicateKey(key []string) string {
+	if len(key) == 1 && key[0] == FullRow {
+		return "full row"
+	}
+	return strings.Join(key, ", ")
+}
+
+// keyColumns returns the SQL column list rows are grouped by. Rows match
+// when every key column is equal, with NULLs matching each other but not
+// empty strings.
+func keyColumns(key []string) string {
+	if len(key) == 1 && key[0] == FullRow {
+		return `(to_jsonb(t) - 'id' - 'source_file')`
+	}
+	cols := make([]string, len(key))
+	for i, col := range key {
+		cols[i] = "t." + pgx.Identifier{col}.Sanitize()
+	}
+	return strings.Join(cols, ", ")
+}
+
+// keyLabel returns the SQL expression displaying a row's key. It is only
+// shown, never grouped by, so values containing the separator are harmless.
+func keyLabel(key []string) string {
+	if len(key) == 1 && key[0] == FullRow {
+		return `md5((to_jsonb(t) - 'id' - 'source_file')::text)`
+	}
+	parts := make([]string, len(key))
+	for i, col := range key {
+		parts[i] = fmt.Sprintf(`coalesce(t.%s::text, '<null>')`, pgx.Identifier{col}.Sanitize())
+	}
+	return "concat_ws(' | ', " + strings.Join(parts, ", ") + ")"
+}
+
+// DuplicateGroup is a set of rows sharing a key.
+type DuplicateGroup struct {
+	Key   string // the key values, or the row hash for full-row keys
+	Rows  int64
+	Files []string // source files of the rows; "(unknown)" for rows imported before source files were recorded
+}
+
+// Extra is the number of rows that would be deleted, keeping one.
+func (g DuplicateGroup) Extra() int64 { return g.Rows - 1 }
+
+// Duplicates finds and removes duplicate rows in the upload tables.
+type Duplicates struct {
+	Pool *pgxpool.Pool
+}
+
+// noSourceFile stands in for source_file on tables that predate it.
+const noSourceFile = "NULL::text"
+
+// checkKey verifies that every key column exists in table and returns the
+// expression for a row's source file: NULL on schemas from before migration
+// 016, which have no source_file column.
+func checkKey(ctx context.Context, q pgx.Tx, table string, key []string) (string, error) {
+	cols, err := liveColumns(ctx, q, table)
+	if err != nil {
+		return "", err
+	}
+	have := make(map[string]bool)
+	for _, c := range strings.Split(cols, ", ") {
+		have[c] = true
+	}
+	if !(len(key) == 1 && key[0] == FullRow) {
+		for _, col := range key {
+			if !have[pgx.Identifier{col}.Sanitize()] {
+				return "", fmt.Errorf("%s has no column %q", table, col)
+			}
+		}
+	}
+	if !have[`"source_file"`] {
+		return noSourceFile, nil
+	}
+	return "t.source_file", nil
+}
+
+// derivedTablesExist reports whether every table derived from table exists.
+// They are created by migrations 013-015.
+func derivedTablesExist(ctx context.Context, q pgx.Tx, table string) (bool, error) {
+	for _, derived := range DerivedTables[table] {
+		var exists bool
+		if err := q.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, derived).Scan(&exists); err != nil {
+			return false, fmt.Errorf("checking for %s: %w", derived, err)
+		}
+		if !exists {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Scan returns the duplicate groups in table, largest first.
+func (d *Duplicates) Scan(ctx context.Context, table string, key []string) ([]DuplicateGroup, error) {
+	tx, err := d.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	sourceFile, err := checkKey(ctx, tx, table, key)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, fmt.Sprintf(`
+		SELECT min(%s) AS k, count(*), array_agg(DISTINCT coalesce(%s, '(unknown)'))
+		FROM %s t
+		GROUP BY %s
+		HAVING count(*) > 1
+		ORDER BY count(*) DESC, k`,
+		keyLabel(key), sourceFile, pgx.Identifier{table}.Sanitize(), keyColumns(key)))
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var groups []DuplicateGroup
+	for rows.Next() {
+		var g DuplicateGroup
+		if err := rows.Scan(&g.Key, &g.Rows, &g.Files); err != nil {
+			return nil, err
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// Remove backs up the table, then keeps one row per duplicate group (the
+// first by source file, then id) and deletes the rest in one transaction.
+// The table's derived tables, such as the revenue schedule, are rebuilt
+// afterwards to match what is left, when the schema has them. On schemas
+// without source_file, rows are kept by id alone.
+func (d *Duplicates) Remove(reg handler.Registration, key []string) tea.Cmd {
+	return func() tea.Msg {
+		table := reg.Props.Table()
+
+		bctx, bcancel := context.WithTimeout(context.Background(), backup.Timeout)
+		saved, err := backup.Create(bctx, d.Pool, "dedupe "+table, []string{table})
+		bcancel()
+		if err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("backup failed, nothing was deleted: %w", err)}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), DuplicateTimeout)
+		defer cancel()
+
+		var deleted int64
+		err = pgx.BeginFunc(ctx, d.Pool, func(tx pgx.Tx) error {
+			sourceFile, err := checkKey(ctx, tx, table, key)
+			if err != nil {
+				return err
+			}
+
+			tag, err := tx.Exec(ctx, fmt.Sprintf(`
+				DELETE FROM %[1]s
+				WHERE id IN (
+					SELECT id FROM (
+						SELECT t.id, row_number() OVER (PARTITION BY %[2]s ORDER BY %[3]s NULLS LAST, t.id) AS n
+						FROM %[1]s t
+					) ranked
+					WHERE n > 1
+				)`, pgx.Identifier{table}.Sanitize(), keyColumns(key), sourceFile))
+			if err != nil {
+				return fmt.Errorf("deleting duplicates from %s: %w", table, err)
+			}
+			deleted = tag.RowsAffected()
+
+			// Before migration 016 the handler queries, which read
+			// source_file, cannot run, so derived tables wait for the next import.
+			if deleted == 0 || sourceFile == noSourceFile {
+				return nil
+			}
+			if rebuild, err := derivedTablesExist(ctx, tx, table); err != nil || !rebuild {
+				return err
+			}
+			return reg.Props.Rebuild(ctx, db.New(tx))
+		})
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+
+		return handler.DoneMsg(fmt.Sprintf("Deleted %d duplicate rows from %s (key: %s).\n\nBackup saved to %s",
+			deleted, table, FormatDuplicateKey(key), saved.Path))
+	}
+}
+
+// DuplicateReport lists the duplicate groups of a table.
+func DuplicateReport(table string, key []string, groups []DuplicateGroup) report.Report {
+	rows := make([]report.Row, len(groups))
+	var extra int64
+	for i, g := range groups {
+		extra += g.Extra()
+		rows[i] = report.Row{
+			Cells:   []string{g.Key, fmt.Sprint(g.Rows), fmt.Sprint(len(g.Files)), strings.Join(g.Files, "; ")},
+			Flagged: len(g.Files) == 1,
+		}
+	}
+
+	return report.Report{
+		Title: "Duplicates in " + table,
+		Columns: []report.Column{
+			{Title: "Key (" + FormatDuplicateKey(key) + ")", Width: 50},
+			{Title: "Rows", Width: 6},
+			{Title: "Files", Width: 6},
+			{Title: "Source Files", Width: 80},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d duplicate groups, %d extra rows. Flagged groups repeat within a single file; the rest span overlapping imports.",
+			len(groups), extra),
+	}
+}
@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/handler"
+)
+
+/* ========================================
+	Duplicate Key Tests
+======================================== */
+
+func TestParseDuplicateKey(t *testing.T) {
+	tests := []struct {
+		table    string
+		input    string
+		expected string
+	}{
+		{"sfdc_opp_detail", "", "opportunity_product_casesafe_id"},
+		{"ns_invoice_detail", "  ", "document_number, sfdc_opp_line_id, item, amount"},
+		{"ns_invoice_detail", "Document_Number, amount,", "document_number, amount"},
+		{"ns_invoice_detail", "*", "full row"},
+		{"ns_invoice_detail", "amount, *", "full row"},
+		{"unknown_table", "", "full row"},
+	}
+
+	for _, tt := range tests {
+		got := FormatDuplicateKey(ParseDuplicateKey(tt.table, tt.input))
+		if got != tt.expected {
+			t.Errorf("ParseDuplicateKey(%q, %q) = %q, expected %q", tt.table, tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestDuplicateKeys_DefaultsAreRegisteredTables(t *testing.T) {
+	tables := make(map[string]bool)
+	for _, reg := range handler.Registrations(nil) {
+		tables[reg.Props.Table()] = true
+	}
+	for table := range DuplicateKeys {
+		if !tables[table] {
+			t.Errorf("DuplicateKeys has %q, which is not a registered upload table", table)
+		}
+	}
+}
+
+func TestKeyColumns(t *testing.T) {
+	if got := keyColumns([]string{FullRow}); got != `(to_jsonb(t) - 'id' - 'source_file')` {
+		t.Errorf("keyColumns(full row) = %q, expected the row without id and source_file", got)
+	}
+
+	got := keyColumns([]string{"document_number", `bad"col`})
+	expected := `t."document_number", t."bad""col"`
+	if got != expected {
+		t.Errorf("keyColumns() = %q, expected %q", got, expected)
+	}
+}
+
+func TestKeyLabel(t *testing.T) {
+	if got := keyLabel([]string{FullRow}); !strings.Contains(got, `- 'id' - 'source_file'`) {
+		t.Errorf("keyLabel(full row) = %q, expected id and source_file excluded", got)
+	}
+
+	got := keyLabel([]string{"document_number", `bad"col`})
+	expected := `concat_ws(' | ', coalesce(t."document_number"::text, '<null>'), coalesce(t."bad""col"::text, '<null>'))`
+	if got != expected {
+		t.Errorf("keyLabel() = %q, expected %q", got, expected)
+	}
+}
+
+/* ========================================
+	DuplicateReport Tests
+======================================== */
+
+func TestDuplicateReport(t *testing.T) {
+	groups := []DuplicateGroup{
+		{Key: "INV-1 | 00k1", Rows: 3, Files: []string{"NS/InvoiceDetail/jan.csv", "NS/InvoiceDetail/feb.csv"}},
+		{Key: "INV-2 | 00k2", Rows: 2, Files: []string{"NS/InvoiceDetail/jan.csv"}},
+	}
+
+	r := DuplicateReport("ns_invoice_detail", []string{"document_number", "sfdc_opp_line_id"}, groups)
+
+	if r.Columns[0].Title != "Key (document_number, sfdc_opp_line_id)" {
+		t.Errorf("key column = %q", r.Columns[0].Title)
+	}
+	if r.Rows[0].Flagged || !r.Rows[1].Flagged {
+		t.Error("only groups repeated within a single file should be flagged")
+	}
+	if !strings.HasPrefix(r.Summary, "2 duplicate groups, 3 extra rows") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
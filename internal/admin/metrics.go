@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/metrics"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// MetricsTimeout is the maximum duration for recomputing metrics_monthly.
+const MetricsTimeout = 60 * time.Second
+
+// Metrics recomputes metrics_monthly outside of an import.
+type Metrics struct {
+	Pool *pgxpool.Pool
+}
+
+// Recompute rebuilds every month from sfdc_opp_detail in one transaction.
+func (m *Metrics) Recompute() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), MetricsTimeout)
+		defer cancel()
+
+		var res metrics.Result
+		err := pgx.BeginFunc(ctx, m.Pool, func(tx pgx.Tx) error {
+			var err error
+			res, err = metrics.Rebuild(ctx, db.New(tx))
+			return err
+		})
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return handler.DoneMsg(fmt.Sprintf("Metrics recomputed: %d months from %d opportunity lines, %d skipped without service dates, %d without an account name.",
+			res.Months, res.Contracts, res.Skipped, res.Unassigned))
+	}
+}
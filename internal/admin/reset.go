@@ -3,11 +3,17 @@ package admin
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/JonMunkholm/TUI/internal/backup"
 	db "github.com/JonMunkholm/TUI/internal/database"
 	"github.com/JonMunkholm/TUI/internal/handler"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // ResetTimeout is the maximum duration for database reset operations.
@@ -15,40 +21,323 @@ const ResetTimeout = 30 * time.Second
 
 // ResetDbs handles database reset operations.
 type ResetDbs struct {
-	DB *db.Queries
+	Pool *pgxpool.Pool
 }
 
-type dbResetFn func(ctx context.Context) error
-
-// ResetAll truncates all data tables and clears the CSV upload log.
-// This is a destructive operation - use with caution.
+// ResetAll truncates every registered data table, its dependents and the
+// whole upload log in one transaction. This is a destructive operation - use with caution.
 func (r *ResetDbs) ResetAll() tea.Cmd {
+	return r.Reset(AllTarget(handler.Registrations(r.Pool)))
+}
+
+/* ----------------------------------------
+	Targeted Resets
+---------------------------------------- */
+
+// DependentTables lists tables derived from a data table's imports. They are
+// cleared with it so they never describe rows that no longer exist.
+var DependentTables = map[string][]string{
+	"ns_customers":   {"ns_customers_history"},
+	"sfdc_customers": {"sfdc_customers_history"},
+}
+
+// DerivedTables lists tables rebuilt from a data table's rows by its
+// handler's Rebuild. A reset rebuilds them from what is left, in the same
+// transaction, instead of clearing them, since they may also hold rows
+// derived from tables that are kept.
+var DerivedTables = map[string][]string{
+	"ns_customers":    {"customer_crosswalk"},
+	"sfdc_customers":  {"customer_crosswalk"},
+	"ns_so_detail":    {"revenue_schedule"},
+	"sfdc_opp_detail": {"revenue_schedule", "metrics_monthly"},
+}
+
+// ResetTarget is a set of tables to clear together with the csv_uploads and
+// import_warnings entries of the directories that feed them.
+type ResetTarget struct {
+	Name   string   // Shown in prompts, and typed to confirm
+	Tables []string // Dependents first, so history goes before its source
+	Paths  []string // Upload directories relative to the uploads root
+	All    bool     // Clear the whole upload log instead of matching Paths
+
+	Rebuilt []string               // Derived tables rebuilt once Tables are cleared
+	regs    []handler.Registration // Handlers whose Rebuild runs after the reset
+}
+
+// TableTarget resets one registered table and its dependents.
+func TableTarget(reg handler.Registration) ResetTarget {
+	table := reg.Props.Table()
+	return ResetTarget{
+		Name:    table,
+		Tables:  append(append([]string{}, DependentTables[table]...), table),
+		Paths:   []string{reg.Path()},
+		Rebuilt: append([]string{}, DerivedTables[table]...),
+		regs:    []handler.Registration{reg},
+	}
+}
+
+// add merges another target's tables, rebuilt tables and handlers into target.
+func (target *ResetTarget) add(t ResetTarget) {
+	target.Tables = append(target.Tables, t.Tables...)
+	for _, table := range t.Rebuilt {
+		if !slices.Contains(target.Rebuilt, table) {
+			target.Rebuilt = append(target.Rebuilt, table)
+		}
+	}
+	target.regs = append(target.regs, t.regs...)
+}
+
+// SourceTarget resets every registered table of one source (NS, SFDC, Anrok).
+func SourceTarget(source string, regs []handler.Registration) ResetTarget {
+	target := ResetTarget{Name: source}
+	for _, reg := range regs {
+		if reg.Source != source {
+			continue
+		}
+		t := TableTarget(reg)
+		target.add(t)
+		target.Paths = append(target.Paths, t.Paths...)
+	}
+	return target
+}
+
+// AllTarget resets every registered table and the whole upload log.
+func AllTarget(regs []handler.Registration) ResetTarget {
+	target := ResetTarget{Name: "all tables", All: true}
+	for _, reg := range regs {
+		target.add(TableTarget(reg))
+	}
+	return target
+}
+
+// Sources returns the distinct sources of regs in registration order.
+func Sources(regs []handler.Registration) []string {
+	var sources []string
+	seen := make(map[string]bool)
+	for _, reg := range regs {
+		if !seen[reg.Source] {
+			seen[reg.Source] = true
+			sources = append(sources, reg.Source)
+		}
+	}
+	return sources
+}
+
+// ResetCounts is the number of rows a reset affects.
+type ResetCounts struct {
+	Tables   []TableCount
+	Uploads  int64 // csv_uploads entries
+	Warnings int64 // import_warnings rows
+	Rebuilt  []string
+	Kept     []TableCount // archive tables of the reset tables, which a reset leaves alone
+}
+
+// TableCount is the number of rows in one table.
+type TableCount struct {
+	Table string
+	Rows  int64
+}
+
+// Total returns the number of data rows across all tables.
+func (c ResetCounts) Total() int64 {
+	var n int64
+	for _, t := range c.Tables {
+		n += t.Rows
+	}
+	return n
+}
+
+// countKept returns the rows in the archive tables of tables.
+func countKept(ctx context.Context, q db.DBTX, tables []string) ([]TableCount, error) {
+	var kept []TableCount
+	for _, table := range tables {
+		s, ok := archiveFor(table)
+		if !ok {
+			continue
+		}
+		c := TableCount{Table: s.ArchiveTable()}
+		if err := q.QueryRow(ctx, `SELECT count(*) FROM `+pgx.Identifier{c.Table}.Sanitize()).Scan(&c.Rows); err != nil {
+			return nil, fmt.Errorf("counting %s: %w", c.Table, err)
+		}
+		kept = append(kept, c)
+	}
+	return kept, nil
+}
+
+// Count returns how many rows resetting target would delete.
+func (r *ResetDbs) Count(ctx context.Context, target ResetTarget) (ResetCounts, error) {
+	counts := ResetCounts{Rebuilt: target.Rebuilt}
+
+	kept, err := countKept(ctx, r.Pool, target.Tables)
+	if err != nil {
+		return counts, err
+	}
+	counts.Kept = kept
+
+	for _, table := range target.Tables {
+		var n int64
+		if err := r.Pool.QueryRow(ctx, `SELECT count(*) FROM `+pgx.Identifier{table}.Sanitize()).Scan(&n); err != nil {
+			return counts, fmt.Errorf("counting %s: %w", table, err)
+		}
+		counts.Tables = append(counts.Tables, TableCount{Table: table, Rows: n})
+	}
+
+	if target.All {
+		if err := r.Pool.QueryRow(ctx, `SELECT (SELECT count(*) FROM csv_uploads), (SELECT count(*) FROM import_warnings)`).Scan(&counts.Uploads, &counts.Warnings); err != nil {
+			return counts, fmt.Errorf("counting upload log: %w", err)
+		}
+		return counts, nil
+	}
+
+	for _, path := range target.Paths {
+		pattern := uploadPathPattern(path)
+
+		var uploads, warnings int64
+		if err := r.Pool.QueryRow(ctx, `SELECT count(*) FROM csv_uploads WHERE name LIKE $1`, pattern).Scan(&uploads); err != nil {
+			return counts, fmt.Errorf("counting csv_uploads: %w", err)
+		}
+		if err := r.Pool.QueryRow(ctx, `SELECT count(*) FROM import_warnings WHERE batch LIKE $1`, pattern).Scan(&warnings); err != nil {
+			return counts, fmt.Errorf("counting import_warnings: %w", err)
+		}
+		counts.Uploads += uploads
+		counts.Warnings += warnings
+	}
+
+	return counts, nil
+}
+
+// Reset backs up the target, then deletes its rows in one transaction and
+// reports the counts.
+func (r *ResetDbs) Reset(target ResetTarget) tea.Cmd {
 	return func() tea.Msg {
+		saved, err := r.backup(target)
+		if err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("backup before reset failed, nothing was deleted: %w", err)}
+		}
+
 		ctx, cancel := context.WithTimeout(context.Background(), ResetTimeout)
 		defer cancel()
 
-		if err := r.runResets(ctx, []dbResetFn{
-			r.DB.ResetSfdcCustomers,
-			r.DB.ResetSfdcPriceBook,
-			r.DB.ResetSfdcOppDetail,
-			r.DB.ResetNsCustomers,
-			r.DB.ResetNsSoDetail,
-			r.DB.ResetNsInvoiceDetail,
-			r.DB.ResetAnrokTransactions,
-			r.DB.ResetCsvUploads,
-		}); err != nil {
+		counts, err := r.reset(ctx, target)
+		if err != nil {
 			return handler.ErrMsg{Err: err}
 		}
+		return handler.DoneMsg("Reset " + target.Name + " complete.\n\n" + FormatResetCounts(counts) +
+			"\n\nBackup saved to " + saved.Path)
+	}
+}
+
+// backup exports the target's tables, the tables it rebuilds and the upload
+// log before they are changed.
+func (r *ResetDbs) backup(target ResetTarget) (backup.Backup, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), backup.Timeout)
+	defer cancel()
+
+	tables := append(append(append([]string{}, target.Tables...), target.Rebuilt...), "csv_uploads", "import_warnings")
+	return backup.Create(ctx, r.Pool, "reset "+target.Name, tables)
+}
+
+// reset clears the target and rebuilds its derived tables in a single
+// transaction: either every table and upload log entry is cleared, or
+// nothing is.
+func (r *ResetDbs) reset(ctx context.Context, target ResetTarget) (ResetCounts, error) {
+	counts := ResetCounts{Rebuilt: target.Rebuilt}
+
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return counts, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if counts.Tables, err = truncate(ctx, tx, target.Tables); err != nil {
+		return ResetCounts{}, err
+	}
+	if counts.Kept, err = countKept(ctx, tx, target.Tables); err != nil {
+		return ResetCounts{}, err
+	}
+
+	queries := db.New(tx)
+	for _, reg := range target.regs {
+		if err := reg.Props.Rebuild(ctx, queries); err != nil {
+			return ResetCounts{}, fmt.Errorf("rebuilding tables derived from %s: %w", reg.Props.Table(), err)
+		}
+	}
+
+	if target.All {
+		log, err := truncate(ctx, tx, []string{"import_warnings", "csv_uploads"})
+		if err != nil {
+			return ResetCounts{}, err
+		}
+		counts.Warnings, counts.Uploads = log[0].Rows, log[1].Rows
+	} else {
+		for _, path := range target.Paths {
+			pattern := uploadPathPattern(path)
 
-		return handler.DoneMsg("DBs reset")
+			tag, err := tx.Exec(ctx, `DELETE FROM import_warnings WHERE batch LIKE $1`, pattern)
+			if err != nil {
+				return ResetCounts{}, fmt.Errorf("clearing import_warnings: %w", err)
+			}
+			counts.Warnings += tag.RowsAffected()
+
+			tag, err = tx.Exec(ctx, `DELETE FROM csv_uploads WHERE name LIKE $1`, pattern)
+			if err != nil {
+				return ResetCounts{}, fmt.Errorf("clearing csv_uploads: %w", err)
+			}
+			counts.Uploads += tag.RowsAffected()
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return ResetCounts{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
+	return counts, nil
 }
 
-func (r *ResetDbs) runResets(ctx context.Context, resets []dbResetFn) error {
-	for _, reset := range resets {
-		if err := reset(ctx); err != nil {
-			return err
+// truncate empties tables with a single TRUNCATE ... RESTART IDENTITY and
+// returns how many rows each held. The tables are locked before counting so
+// the counts are exactly what was removed.
+func truncate(ctx context.Context, tx pgx.Tx, tables []string) ([]TableCount, error) {
+	if len(tables) == 0 {
+		return nil, nil
+	}
+
+	idents := make([]string, len(tables))
+	for i, table := range tables {
+		idents[i] = pgx.Identifier{table}.Sanitize()
+	}
+	list := strings.Join(idents, ", ")
+
+	if _, err := tx.Exec(ctx, `LOCK TABLE `+list+` IN ACCESS EXCLUSIVE MODE`); err != nil {
+		return nil, fmt.Errorf("locking tables: %w", err)
+	}
+
+	counts := make([]TableCount, len(tables))
+	for i, table := range tables {
+		counts[i].Table = table
+		if err := tx.QueryRow(ctx, `SELECT count(*) FROM `+idents[i]).Scan(&counts[i].Rows); err != nil {
+			return nil, fmt.Errorf("counting %s: %w", table, err)
 		}
 	}
-	return nil
+
+	if _, err := tx.Exec(ctx, `TRUNCATE `+list+` RESTART IDENTITY`); err != nil {
+		return nil, fmt.Errorf("truncating %s: %w", list, err)
+	}
+	return counts, nil
+}
+
+// FormatResetCounts renders per-table row counts and the upload log entries affected.
+func FormatResetCounts(counts ResetCounts) string {
+	var sb strings.Builder
+	for _, t := range counts.Tables {
+		fmt.Fprintf(&sb, "  %-28s %d rows\n", t.Table, t.Rows)
+	}
+	for _, table := range counts.Rebuilt {
+		fmt.Fprintf(&sb, "  %-28s rebuilt\n", table)
+	}
+	for _, t := range counts.Kept {
+		fmt.Fprintf(&sb, "  %-28s %d rows kept\n", t.Table, t.Rows)
+	}
+	fmt.Fprintf(&sb, "  %-28s %d entries\n", "csv_uploads", counts.Uploads)
+	fmt.Fprintf(&sb, "  %-28s %d rows", "import_warnings", counts.Warnings)
+	return sb.String()
 }
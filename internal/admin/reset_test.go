@@ -0,0 +1,139 @@
+package admin
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/handler"
+)
+
+/* ========================================
+	ResetTarget Tests
+======================================== */
+
+func findReg(t *testing.T, regs []handler.Registration, table string) handler.Registration {
+	t.Helper()
+	for _, reg := range regs {
+		if reg.Props.Table() == table {
+			return reg
+		}
+	}
+	t.Fatalf("no registration for %s", table)
+	return handler.Registration{}
+}
+
+func TestTableTarget(t *testing.T) {
+	regs := handler.Registrations(nil)
+
+	target := TableTarget(findReg(t, regs, "ns_customers"))
+
+	if target.Name != "ns_customers" {
+		t.Errorf("Name = %q, expected ns_customers", target.Name)
+	}
+	// History must be cleared before the table it is derived from
+	if want := []string{"ns_customers_history", "ns_customers"}; !reflect.DeepEqual(target.Tables, want) {
+		t.Errorf("Tables = %v, expected %v", target.Tables, want)
+	}
+	if want := []string{"NS/Customers"}; !reflect.DeepEqual(target.Paths, want) {
+		t.Errorf("Paths = %v, expected %v", target.Paths, want)
+	}
+
+	if want := []string{"customer_crosswalk"}; !reflect.DeepEqual(target.Rebuilt, want) {
+		t.Errorf("Rebuilt = %v, expected %v", target.Rebuilt, want)
+	}
+
+	for _, table := range []string{"ns_so_detail", "sfdc_opp_detail"} {
+		if got := TableTarget(findReg(t, regs, table)).Rebuilt; !slices.Contains(got, "revenue_schedule") {
+			t.Errorf("TableTarget(%s).Rebuilt = %v, expected revenue_schedule", table, got)
+		}
+	}
+
+	if plain := TableTarget(findReg(t, regs, "anrok_transactions")); !reflect.DeepEqual(plain.Tables, []string{"anrok_transactions"}) || len(plain.Rebuilt) != 0 {
+		t.Errorf("Tables = %v, Rebuilt = %v, expected only anrok_transactions", plain.Tables, plain.Rebuilt)
+	}
+}
+
+func TestSourceTarget(t *testing.T) {
+	regs := handler.Registrations(nil)
+
+	target := SourceTarget("SFDC", regs)
+
+	for _, table := range []string{"sfdc_customers_history", "sfdc_customers", "sfdc_price_book", "sfdc_opp_detail"} {
+		found := false
+		for _, got := range target.Tables {
+			found = found || got == table
+		}
+		if !found {
+			t.Errorf("SourceTarget(SFDC) missing table %s: %v", table, target.Tables)
+		}
+	}
+	for _, path := range target.Paths {
+		if !strings.HasPrefix(path, "SFDC") {
+			t.Errorf("SourceTarget(SFDC) has path outside SFDC: %s", path)
+		}
+	}
+}
+
+func TestAllTarget(t *testing.T) {
+	regs := handler.Registrations(nil)
+
+	all := AllTarget(regs)
+
+	if !all.All || len(all.Paths) != 0 {
+		t.Errorf("AllTarget() should clear the whole upload log, got All=%v Paths=%v", all.All, all.Paths)
+	}
+	for _, reg := range regs {
+		for _, table := range TableTarget(reg).Tables {
+			found := false
+			for _, got := range all.Tables {
+				found = found || got == table
+			}
+			if !found {
+				t.Errorf("AllTarget() missing table %s", table)
+			}
+		}
+	}
+	// Each derived table is listed once, however many tables feed it
+	seen := make(map[string]bool)
+	for _, table := range all.Rebuilt {
+		if seen[table] {
+			t.Errorf("AllTarget() rebuilds %s twice: %v", table, all.Rebuilt)
+		}
+		seen[table] = true
+	}
+	for _, table := range []string{"customer_crosswalk", "revenue_schedule", "metrics_monthly"} {
+		if !seen[table] {
+			t.Errorf("AllTarget() should rebuild %s, got %v", table, all.Rebuilt)
+		}
+	}
+	if len(all.regs) != len(regs) {
+		t.Errorf("AllTarget() rebuilds for %d handlers, expected %d", len(all.regs), len(regs))
+	}
+
+	if got := Sources(regs); !reflect.DeepEqual(got, []string{"NS", "SFDC", "Anrok"}) {
+		t.Errorf("Sources() = %v, expected [NS SFDC Anrok]", got)
+	}
+}
+
+func TestFormatResetCounts(t *testing.T) {
+	counts := ResetCounts{
+		Tables:   []TableCount{{Table: "ns_customers_history", Rows: 7}, {Table: "ns_customers", Rows: 3}},
+		Uploads:  2,
+		Warnings: 5,
+		Rebuilt:  []string{"customer_crosswalk"},
+		Kept:     []TableCount{{Table: "ns_so_detail_archive", Rows: 4}},
+	}
+
+	if counts.Total() != 10 {
+		t.Errorf("Total() = %d, expected 10", counts.Total())
+	}
+
+	out := FormatResetCounts(counts)
+	for _, want := range []string{"ns_customers_history", "7 rows", "csv_uploads", "2 entries", "import_warnings", "5 rows", "customer_crosswalk", "rebuilt", "ns_so_detail_archive", "4 rows kept"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatResetCounts() missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/backup"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Restorer reloads backups written by destructive actions.
+type Restorer struct {
+	Pool *pgxpool.Pool
+}
+
+// Describe summarizes what restoring b changes, with current row counts.
+func (r *Restorer) Describe(ctx context.Context, b backup.Backup) (string, error) {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Backup %s (%s)\n\n", b.Name(), b.Manifest.Reason)
+
+	for _, t := range b.Manifest.Tables {
+		var current int64
+		if err := r.Pool.QueryRow(ctx, `SELECT count(*) FROM `+pgx.Identifier{t.Table}.Sanitize()).Scan(&current); err != nil {
+			return "", fmt.Errorf("counting %s: %w", t.Table, err)
+		}
+
+		action := "replace"
+		if backup.LogTables[t.Table] {
+			action = "merge"
+		}
+		fmt.Fprintf(&sb, "  %-28s %-7s %d rows now, %d in backup\n", t.Table, action, current, t.Rows)
+	}
+
+	sb.WriteString("\nReplaced tables lose any rows written since the backup.")
+	return sb.String(), nil
+}
+
+// Restore backs up the tables b replaces, then reloads b in one transaction,
+// so a restore of the wrong backup can itself be undone.
+func (r *Restorer) Restore(b backup.Backup) tea.Cmd {
+	return func() tea.Msg {
+		tables := make([]string, len(b.Manifest.Tables))
+		for i, t := range b.Manifest.Tables {
+			tables[i] = t.Table
+		}
+
+		bctx, bcancel := context.WithTimeout(context.Background(), backup.Timeout)
+		saved, err := backup.Create(bctx, r.Pool, "restore "+b.Name(), tables)
+		bcancel()
+		if err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("backup failed, nothing was restored: %w", err)}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), backup.Timeout)
+		defer cancel()
+
+		if err := backup.Restore(ctx, r.Pool, b); err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return handler.DoneMsg(fmt.Sprintf("Restored %d rows across %d tables from %s.\n\nBackup of the replaced data saved to %s",
+			b.Manifest.Rows(), len(b.Manifest.Tables), b.Name(), saved.Path))
+	}
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/revrec"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RevenueTimeout is the maximum duration for regenerating the revenue schedule.
+const RevenueTimeout = 120 * time.Second
+
+// RevenueSchedule regenerates revenue_schedule outside of an import, e.g.
+// after a reset or an archive removed source lines.
+type RevenueSchedule struct {
+	Pool *pgxpool.Pool
+}
+
+// Regenerate rebuilds the SFDC and NetSuite schedules in one transaction.
+func (r *RevenueSchedule) Regenerate() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), RevenueTimeout)
+		defer cancel()
+
+		var msg string
+		err := pgx.BeginFunc(ctx, r.Pool, func(tx pgx.Tx) error {
+			q := db.New(tx)
+			msg = "Revenue schedule regenerated:"
+			for _, source := range []string{revrec.SourceSFDC, revrec.SourceNS} {
+				res, err := revrec.Regenerate(ctx, q, source)
+				if err != nil {
+					return err
+				}
+				msg += fmt.Sprintf("\n  %s: %d lines, %d monthly rows, %d skipped without service dates",
+					source, res.Lines, res.Entries, res.Skipped)
+			}
+			return nil
+		})
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return handler.DoneMsg(msg)
+	}
+}
@@ -0,0 +1,180 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/migrate"
+	sqlfiles "github.com/JonMunkholm/TUI/sql"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatusTimeout is the maximum duration for gathering the database status page.
+const StatusTimeout = 15 * time.Second
+
+// DBStatus reports server, migration, connection pool and table information.
+type DBStatus struct {
+	Pool *pgxpool.Pool
+}
+
+// StatusReport is everything shown on the database status page.
+type StatusReport struct {
+	ServerVersion string
+	Database      string
+	Host          string
+	Port          uint16
+
+	Migration string // Latest applied migration file, empty if none
+	Pending   int
+
+	Acquired int32
+	Idle     int32
+	Total    int32
+	Max      int32
+
+	Tables []TableStatus
+}
+
+// TableStatus is the row count and last import of one registered data table.
+type TableStatus struct {
+	Table      string
+	Missing    bool
+	Rows       int64
+	LastImport time.Time // Zero when no file was ever imported
+}
+
+// Run gathers the status report and returns it as a message.
+func (s *DBStatus) Run() tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), StatusTimeout)
+		defer cancel()
+
+		report, err := GatherStatus(ctx, s.Pool, handler.Registrations(s.Pool))
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return handler.WdMsg(FormatStatus(report))
+	}
+}
+
+// GatherStatus queries the database for the status report.
+func GatherStatus(ctx context.Context, pool *pgxpool.Pool, regs []handler.Registration) (StatusReport, error) {
+	var r StatusReport
+
+	cfg := pool.Config().ConnConfig
+	r.Host, r.Port = cfg.Host, cfg.Port
+
+	if err := pool.QueryRow(ctx, `SELECT current_setting('server_version'), current_database()`).Scan(&r.ServerVersion, &r.Database); err != nil {
+		return r, fmt.Errorf("reading server version: %w", err)
+	}
+
+	runner, err := migrate.New(pool, sqlfiles.Migrations, sqlfiles.MigrationsDir)
+	if err != nil {
+		return r, err
+	}
+	status, err := runner.Status(ctx)
+	if err != nil {
+		return r, err
+	}
+	for _, st := range status {
+		if st.Applied {
+			r.Migration = st.File
+		} else {
+			r.Pending++
+		}
+	}
+
+	stat := pool.Stat()
+	r.Acquired, r.Idle, r.Total, r.Max = stat.AcquiredConns(), stat.IdleConns(), stat.TotalConns(), stat.MaxConns()
+
+	for _, reg := range regs {
+		ts, err := tableStatus(ctx, pool, reg)
+		if err != nil {
+			return r, fmt.Errorf("reading status for %s: %w", reg.Props.Table(), err)
+		}
+		r.Tables = append(r.Tables, ts)
+	}
+
+	return r, nil
+}
+
+func tableStatus(ctx context.Context, pool *pgxpool.Pool, reg handler.Registration) (TableStatus, error) {
+	ts := TableStatus{Table: reg.Props.Table()}
+
+	var exists bool
+	if err := pool.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, ts.Table).Scan(&exists); err != nil {
+		return ts, err
+	}
+	if !exists {
+		ts.Missing = true
+		return ts, nil
+	}
+
+	if err := pool.QueryRow(ctx, `SELECT count(*) FROM `+pgx.Identifier{ts.Table}.Sanitize()).Scan(&ts.Rows); err != nil {
+		return ts, err
+	}
+
+	var last *time.Time
+	if err := pool.QueryRow(ctx, `
+		SELECT max(uploaded_at)
+		FROM csv_uploads
+		WHERE action = 'upload' AND name LIKE $1`, uploadPathPattern(reg.Path())).Scan(&last); err != nil {
+		return ts, err
+	}
+	if last != nil {
+		ts.LastImport = *last
+	}
+
+	return ts, nil
+}
+
+// uploadPathPattern matches csv_uploads names and import_warnings batches
+// (full file paths) inside an upload directory relative to the uploads root.
+func uploadPathPattern(path string) string {
+	sep := string(filepath.Separator)
+	return "%" + likeEscape(sep+path+sep) + "%"
+}
+
+// likeEscape escapes LIKE wildcards using the default backslash escape.
+func likeEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// FormatStatus renders the status report for display.
+func FormatStatus(r StatusReport) string {
+	var sb strings.Builder
+
+	migration := "none applied"
+	if r.Migration != "" {
+		migration = r.Migration
+	}
+
+	sb.WriteString("Database Status\n\n")
+	fmt.Fprintf(&sb, "Server:       PostgreSQL %s\n", r.ServerVersion)
+	fmt.Fprintf(&sb, "Database:     %s on %s:%d\n", r.Database, r.Host, r.Port)
+	fmt.Fprintf(&sb, "Migration:    %s (%d pending)\n", migration, r.Pending)
+	fmt.Fprintf(&sb, "Connections:  %d acquired, %d idle, %d total (max %d)\n\n", r.Acquired, r.Idle, r.Total, r.Max)
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Table\tRows\tLast import")
+	for _, t := range r.Tables {
+		switch {
+		case t.Missing:
+			fmt.Fprintf(tw, "%s\t-\ttable missing\n", t.Table)
+		case t.LastImport.IsZero():
+			fmt.Fprintf(tw, "%s\t%d\tnever\n", t.Table, t.Rows)
+		default:
+			fmt.Fprintf(tw, "%s\t%d\t%s\n", t.Table, t.Rows, t.LastImport.Format("2006-01-02 15:04"))
+		}
+	}
+	tw.Flush()
+
+	return strings.TrimRight(sb.String(), "\n")
+}
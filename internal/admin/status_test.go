@@ -0,0 +1,81 @@
+package admin
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/handler"
+)
+
+/* ========================================
+	FormatStatus Tests
+======================================== */
+
+func TestFormatStatus(t *testing.T) {
+	report := StatusReport{
+		ServerVersion: "16.2",
+		Database:      "accounting",
+		Host:          "localhost",
+		Port:          5432,
+		Migration:     "009_import_warnings.sql",
+		Pending:       1,
+		Acquired:      1,
+		Idle:          2,
+		Total:         3,
+		Max:           10,
+		Tables: []TableStatus{
+			{Table: "ns_customers", Rows: 42, LastImport: time.Date(2025, 1, 2, 15, 4, 0, 0, time.UTC)},
+			{Table: "sfdc_opp_detail", Rows: 0},
+			{Table: "anrok_transactions", Missing: true},
+		},
+	}
+
+	out := FormatStatus(report)
+
+	want := []string{
+		"Server:       PostgreSQL 16.2",
+		"Database:     accounting on localhost:5432",
+		"Migration:    009_import_warnings.sql (1 pending)",
+		"Connections:  1 acquired, 2 idle, 3 total (max 10)",
+		"ns_customers        42    2025-01-02 15:04",
+		"sfdc_opp_detail     0     never",
+		"anrok_transactions  -     table missing",
+	}
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("FormatStatus() missing %q:\n%s", w, out)
+		}
+	}
+}
+
+func TestFormatStatus_NoMigrations(t *testing.T) {
+	out := FormatStatus(StatusReport{Pending: 9})
+
+	if !strings.Contains(out, "Migration:    none applied (9 pending)") {
+		t.Errorf("FormatStatus() = %q", out)
+	}
+}
+
+/* ========================================
+	uploadPathPattern Tests
+======================================== */
+
+func TestUploadPathPattern(t *testing.T) {
+	tests := []struct {
+		reg      handler.Registration
+		expected string
+	}{
+		{handler.Registration{Source: "NS", Dir: "Customers"}, `%/NS/Customers/%`},
+		{handler.Registration{Source: "NS", Dir: "SO_line_item_detail"}, `%/NS/SO\_line\_item\_detail/%`},
+		{handler.Registration{Source: "SFDC", Dir: "100%_won"}, `%/SFDC/100\%\_won/%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reg.Dir, func(t *testing.T) {
+			if got := uploadPathPattern(tt.reg.Path()); got != tt.expected {
+				t.Errorf("uploadPathPattern(%q) = %q, expected %q", tt.reg.Path(), got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JonMunkholm/TUI/internal/admin"
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ----------------------------------------
+	ARCHIVE MENU
+---------------------------------------- */
+
+func loadArchive(m *Model) *Menu {
+	archiver := &admin.Archiver{Pool: m.pool}
+
+	return &Menu{
+		Title: "Archive Periods",
+		Items: []MenuItem{
+			{Label: "Archive Status", Action: archiver.Status},
+			{Label: "Archive through period", Writes: true, Action: openScreen(func() Screen {
+				return NewPromptScreen("Archive Periods",
+					"Move live rows dated in or before this month into the archive tables.",
+					"YYYY-MM", validatePeriod, func(value string) tea.Cmd {
+						period, _ := admin.ParsePeriod(value)
+						return confirmArchive(archiver, m.profile, period)
+					})
+			})},
+			{Label: "Restore archived period", Writes: true, Action: openScreen(func() Screen {
+				return NewPromptScreen("Restore Archived Period",
+					"Move archived rows dated in this month back into the live tables.",
+					"YYYY-MM", validatePeriod, func(value string) tea.Cmd {
+						period, _ := admin.ParsePeriod(value)
+						return confirmRestorePeriod(archiver, m.profile, period)
+					})
+			})},
+			{Label: "Back"},
+		},
+	}
+}
+
+// archiveNote tells what happens to the tables derived from archived rows.
+const archiveNote = "The revenue schedule and metrics are rebuilt from live and archived rows together, so they do not change."
+
+func validatePeriod(value string) error {
+	_, err := admin.ParsePeriod(value)
+	return err
+}
+
+func confirmArchive(a *admin.Archiver, p config.Profile, through admin.Period) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), admin.StatusTimeout)
+		defer cancel()
+
+		counts, err := a.CountArchive(ctx, through)
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+
+		body := fmt.Sprintf("Rows dated on or before %s move to the archive tables:\n\n%s\n\n%s",
+			through.End().AddDate(0, 0, -1).Format("2006-01-02"), admin.FormatTableCounts(counts, "rows"), archiveNote)
+		return ScreenMsg{Screen: confirmDestructive(p, "Archive through "+through.String(), body, through.String(), func() tea.Cmd {
+			return a.Archive(through)
+		})}
+	}
+}
+
+func confirmRestorePeriod(a *admin.Archiver, p config.Profile, period admin.Period) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), admin.StatusTimeout)
+		defer cancel()
+
+		counts, err := a.CountRestore(ctx, period)
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+
+		body := fmt.Sprintf("Archived rows dated in %s move back to the live tables:\n\n%s\n\n%s",
+			period, admin.FormatTableCounts(counts, "rows"), archiveNote)
+		return ScreenMsg{Screen: confirmDestructive(p, "Restore "+period.String(), body, period.String(), func() tea.Cmd {
+			return a.RestorePeriod(period)
+		})}
+	}
+}
@@ -0,0 +1,62 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JonMunkholm/TUI/internal/admin"
+	"github.com/JonMunkholm/TUI/internal/backup"
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ----------------------------------------
+	RESTORE BACKUP MENU
+---------------------------------------- */
+
+// showBackups lists backups newest first; choosing one asks for confirmation.
+func (m *Model) showBackups() tea.Cmd {
+	restorer := &admin.Restorer{Pool: m.pool}
+
+	return func() tea.Msg {
+		backups, err := backup.List()
+		if err != nil {
+			return handler.ErrMsg{Err: fmt.Errorf("listing backups: %w", err)}
+		}
+		return MenuMsg{Menu: backupsMenu(restorer, m.profile, backups)}
+	}
+}
+
+func backupsMenu(r *admin.Restorer, p config.Profile, backups []backup.Backup) *Menu {
+	var items []MenuItem
+	if len(backups) == 0 {
+		items = append(items, MenuItem{Label: "No backups in " + backup.Dir})
+	}
+
+	for _, b := range backups {
+		label := fmt.Sprintf("%s  %s (%d tables, %d rows)",
+			b.Manifest.Created.Format("2006-01-02 15:04"), b.Manifest.Reason, len(b.Manifest.Tables), b.Manifest.Rows())
+		items = append(items, MenuItem{Label: label, Action: confirmRestore(r, p, b)})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{Title: "Restore Backup", Items: items}
+}
+
+func confirmRestore(r *admin.Restorer, p config.Profile, b backup.Backup) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), admin.ResetTimeout)
+			defer cancel()
+
+			body, err := r.Describe(ctx, b)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+			return ScreenMsg{Screen: confirmDestructive(p, "Restore Backup", body, "restore", func() tea.Cmd {
+				return r.Restore(b)
+			})}
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/browse"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// browseHeight is the number of table rows shown at once.
+	browseHeight = 15
+	// browseColumns is the number of columns shown at once; ←/→ scroll the rest.
+	browseColumns = 6
+	// browseWidth caps a column's width in the table. Enter shows full values.
+	browseWidth = 24
+)
+
+/* ----------------------------------------
+	BROWSE MENU
+---------------------------------------- */
+
+// showBrowse lists every table in the database, including derived, history
+// and archive tables; choosing one opens it.
+func (m *Model) showBrowse() tea.Cmd {
+	b := &browse.Browser{Pool: m.pool}
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), browse.Timeout)
+		defer cancel()
+
+		tables, err := b.Tables(ctx)
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return MenuMsg{Menu: browseMenu(b, tables)}
+	}
+}
+
+func browseMenu(b *browse.Browser, tables []string) *Menu {
+	var items []MenuItem
+	if len(tables) == 0 {
+		items = append(items, MenuItem{Label: "No tables"})
+	}
+	for _, table := range tables {
+		items = append(items, MenuItem{Label: table, Action: openBrowse(b, table)})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{Title: "Browse", Items: items}
+}
+
+// openBrowse is a menu action that loads table's columns and first page and
+// opens them in a BrowseScreen.
+func openBrowse(b *browse.Browser, table string) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), browse.Timeout)
+			defer cancel()
+
+			q, err := b.Open(ctx, table)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+			page, err := b.Fetch(ctx, q, nil)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+			return ScreenMsg{Screen: NewBrowseScreen(b.Fetch, q, page)}
+		}
+	}
+}
+
+/* ----------------------------------------
+	BROWSE SCREEN
+---------------------------------------- */
+
+// fetchFunc loads the page of a query starting after a cursor.
+type fetchFunc func(ctx context.Context, q browse.Query, after *browse.Cursor) (browse.Page, error)
+
+// browseInput is the value being typed at the bottom of the screen.
+type browseInput int
+
+const (
+	inputNone browseInput = iota
+	inputSearch
+	inputFilter
+	inputSort
+)
+
+// BrowseScreen pages through a table. n/p move between pages, ←/→ scroll
+// columns, / searches every column, f filters one column, s sorts, Enter
+// shows the selected row in full, Esc closes it.
+type BrowseScreen struct {
+	fetch   fetchFunc
+	query   browse.Query
+	starts  []*browse.Cursor // start of each page visited; the last is the current page
+	page    browse.Page
+	table   table.Model
+	offset  int // first column shown
+	detail  bool
+	mode    browseInput
+	input   textinput.Model
+	loading bool
+	seq     int // ignores pages from superseded requests
+	err     string
+}
+
+// browsePageMsg delivers a fetched page back to the screen.
+type browsePageMsg struct {
+	seq    int
+	query  browse.Query
+	starts []*browse.Cursor
+	page   browse.Page
+	err    error
+}
+
+// NewBrowseScreen returns a screen showing page, the first page of q.
+func NewBrowseScreen(fetch fetchFunc, q browse.Query, page browse.Page) *BrowseScreen {
+	input := textinput.New()
+	input.CharLimit = 128
+
+	s := &BrowseScreen{
+		fetch:  fetch,
+		query:  q,
+		starts: []*browse.Cursor{nil},
+		page:   page,
+		input:  input,
+		table: table.New(
+			table.WithHeight(browseHeight),
+			table.WithFocused(true),
+		),
+	}
+	s.refreshTable()
+	return s
+}
+
+// refreshTable shows the current page's visible columns.
+func (s *BrowseScreen) refreshTable() {
+	cols := s.query.Columns
+	end := min(s.offset+browseColumns, len(cols))
+
+	columns := make([]table.Column, 0, end-s.offset)
+	for i := s.offset; i < end; i++ {
+		title := cols[i].Name
+		if cols[i].Name == s.sortColumn() && s.query.Desc {
+			title += " ▼"
+		} else if cols[i].Name == s.sortColumn() {
+			title += " ▲"
+		}
+		width := len([]rune(title))
+		for _, row := range s.page.Rows {
+			width = max(width, len([]rune(row[i].String)))
+		}
+		columns = append(columns, table.Column{Title: title, Width: min(width, browseWidth)})
+	}
+
+	rows := make([]table.Row, len(s.page.Rows))
+	for r, row := range s.page.Rows {
+		cells := make(table.Row, 0, len(columns))
+		for i := s.offset; i < end; i++ {
+			cells = append(cells, row[i].String)
+		}
+		rows[r] = cells
+	}
+
+	// Rows must never be wider than the columns while either is replaced.
+	cursor := s.table.Cursor()
+	s.table.SetRows(nil)
+	s.table.SetColumns(columns)
+	s.table.SetRows(rows)
+	s.table.SetCursor(cursor)
+}
+
+func (s *BrowseScreen) sortColumn() string {
+	if s.query.Sort == "" {
+		return s.query.KeyColumn()
+	}
+	return s.query.Sort
+}
+
+// load fetches the page starting at the last of starts for q.
+func (s *BrowseScreen) load(q browse.Query, starts []*browse.Cursor) tea.Cmd {
+	s.seq++
+	s.loading = true
+	s.err = ""
+	seq, fetch, after := s.seq, s.fetch, starts[len(starts)-1]
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), browse.Timeout)
+		defer cancel()
+
+		page, err := fetch(ctx, q, after)
+		return browsePageMsg{seq: seq, query: q, starts: starts, page: page, err: err}
+	}
+}
+
+// requery reloads from the first page with a changed query.
+func (s *BrowseScreen) requery(q browse.Query) tea.Cmd {
+	return s.load(q, []*browse.Cursor{nil})
+}
+
+func (s *BrowseScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case browsePageMsg:
+		if msg.seq != s.seq {
+			return s, nil
+		}
+		s.loading = false
+		if msg.err != nil {
+			s.err = msg.err.Error()
+			return s, nil
+		}
+		s.query, s.starts, s.page = msg.query, msg.starts, msg.page
+		s.table.SetCursor(0)
+		s.refreshTable()
+		return s, nil
+
+	case tea.KeyMsg:
+		if s.mode != inputNone {
+			return s.updateInput(msg)
+		}
+
+		switch msg.String() {
+		case "esc", "q", "backspace":
+			if s.detail {
+				s.detail = false
+				return s, nil
+			}
+			return nil, nil
+		case "enter":
+			s.detail = !s.detail && len(s.page.Rows) > 0
+			return s, nil
+		case "right", "l":
+			if s.offset+browseColumns < len(s.query.Columns) {
+				s.offset++
+				s.refreshTable()
+			}
+			return s, nil
+		case "left", "h":
+			if s.offset > 0 {
+				s.offset--
+				s.refreshTable()
+			}
+			return s, nil
+		case "n":
+			if s.loading || !s.page.More {
+				return s, nil
+			}
+			return s, s.load(s.query, append(s.starts[:len(s.starts):len(s.starts)], s.page.Next))
+		case "p":
+			if s.loading || len(s.starts) < 2 {
+				return s, nil
+			}
+			return s, s.load(s.query, s.starts[:len(s.starts)-1])
+		case "/":
+			return s, s.startInput(inputSearch, "text in any column", s.query.Search)
+		case "f":
+			value := ""
+			if s.query.Filter != nil {
+				value = s.query.Filter.String()
+			}
+			return s, s.startInput(inputFilter, "column=value or column~value", value)
+		case "s":
+			value := s.query.Sort
+			if value != "" && s.query.Desc {
+				value = "-" + value
+			}
+			return s, s.startInput(inputSort, "column, -column for descending", value)
+		case "r":
+			q := s.query
+			q.Desc = !q.Desc
+			return s, s.requery(q)
+		case "c":
+			if s.query.Search == "" && s.query.Filter == nil {
+				return s, nil
+			}
+			q := s.query
+			q.Search, q.Filter = "", nil
+			return s, s.requery(q)
+		}
+	}
+
+	if s.detail {
+		return s, nil
+	}
+	var cmd tea.Cmd
+	s.table, cmd = s.table.Update(msg)
+	return s, cmd
+}
+
+// startInput opens the input line for mode, prefilled with value.
+func (s *BrowseScreen) startInput(mode browseInput, placeholder, value string) tea.Cmd {
+	s.mode = mode
+	s.err = ""
+	s.input.Placeholder = placeholder
+	s.input.SetValue(value)
+	s.input.CursorEnd()
+	return s.input.Focus()
+}
+
+// updateInput handles keys while the input line is open. Enter applies the
+// value and reloads from the first page; Esc discards it.
+func (s *BrowseScreen) updateInput(msg tea.KeyMsg) (Screen, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		s.mode = inputNone
+		s.input.Blur()
+		s.err = ""
+		return s, nil
+
+	case tea.KeyEnter:
+		q := s.query
+		value := s.input.Value()
+		switch s.mode {
+		case inputSearch:
+			q.Search = strings.TrimSpace(value)
+		case inputFilter:
+			f, err := browse.ParseFilter(q.Columns, value)
+			if err != nil {
+				s.err = err.Error()
+				return s, nil
+			}
+			q.Filter = f
+		case inputSort:
+			col, desc, err := browse.ParseSort(q.Columns, value)
+			if err != nil {
+				s.err = err.Error()
+				return s, nil
+			}
+			q.Sort, q.Desc = col, desc
+		}
+		s.mode = inputNone
+		s.input.Blur()
+		return s, s.requery(q)
+	}
+
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	s.err = ""
+	return s, cmd
+}
+
+func (s *BrowseScreen) View() string {
+	var b strings.Builder
+	b.WriteString("Browse " + s.query.Table + "\n\n")
+
+	direction := "ascending"
+	if s.query.Desc {
+		direction = "descending"
+	}
+	fmt.Fprintf(&b, "Sorted by %s, %s", s.sortColumn(), direction)
+	if s.query.Search != "" {
+		fmt.Fprintf(&b, " | search %q", s.query.Search)
+	}
+	if s.query.Filter != nil {
+		fmt.Fprintf(&b, " | filter %s", s.query.Filter)
+	}
+	b.WriteString("\n")
+
+	first := (len(s.starts)-1)*s.query.PageSize() + 1
+	if len(s.page.Rows) == 0 {
+		fmt.Fprintf(&b, "Page %d, no rows", len(s.starts))
+	} else {
+		fmt.Fprintf(&b, "Page %d, rows %d-%d", len(s.starts), first, first+len(s.page.Rows)-1)
+	}
+	if s.page.More {
+		b.WriteString(", more follow")
+	}
+	fmt.Fprintf(&b, " | columns %d-%d of %d\n\n", s.offset+1, min(s.offset+browseColumns, len(s.query.Columns)), len(s.query.Columns))
+
+	switch {
+	case len(s.page.Rows) == 0:
+		b.WriteString("No rows.\n")
+	case s.detail:
+		b.WriteString(s.detailView())
+	default:
+		b.WriteString(s.table.View() + "\n")
+	}
+
+	if s.mode != inputNone {
+		label := map[browseInput]string{inputSearch: "Search", inputFilter: "Filter", inputSort: "Sort"}[s.mode]
+		fmt.Fprintf(&b, "\n%s: %s\nEnter to apply, Esc to cancel.\n", label, s.input.View())
+	}
+	if s.loading {
+		b.WriteString("\nLoading...\n")
+	}
+	if s.err != "" {
+		b.WriteString("\n" + s.err + "\n")
+	}
+	b.WriteString("\n↑/↓ scroll, ←/→ columns, n/p page, / search, f filter, s sort, r reverse, c clear, Enter details, Esc back.\n")
+	return b.String()
+}
+
+// detailView lists every column of the selected row without truncation.
+func (s *BrowseScreen) detailView() string {
+	row := s.page.Rows[s.table.Cursor()]
+
+	width := 0
+	for _, c := range s.query.Columns {
+		width = max(width, len(c.Name))
+	}
+
+	var b strings.Builder
+	for i, c := range s.query.Columns {
+		value := "NULL"
+		if row[i].Valid {
+			value = row[i].String
+		}
+		fmt.Fprintf(&b, "%-*s  %s\n", width, c.Name, value)
+	}
+	return b.String()
+}
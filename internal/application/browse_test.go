@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/browse"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	BrowseScreen Tests
+======================================== */
+
+func testBrowseQuery() browse.Query {
+	names := []string{"id", "name", "balance", "c3", "c4", "c5", "c6", "source_file"}
+	cols := make([]browse.Column, len(names))
+	for i, name := range names {
+		cols[i] = browse.Column{Name: name, Type: "text"}
+	}
+	return browse.Query{Table: "ns_customers", Columns: cols, Limit: 2}
+}
+
+func testRecord(id, name string) browse.Record {
+	row := make(browse.Record, len(testBrowseQuery().Columns))
+	row[0] = pgtype.Text{String: id, Valid: true}
+	row[1] = pgtype.Text{String: name, Valid: true}
+	return row
+}
+
+// recordingFetch returns pages in order and records each request.
+type recordingFetch struct {
+	pages  []browse.Page
+	afters []*browse.Cursor
+	query  browse.Query
+}
+
+func (f *recordingFetch) fetch(_ context.Context, q browse.Query, after *browse.Cursor) (browse.Page, error) {
+	f.query = q
+	f.afters = append(f.afters, after)
+	if len(f.pages) == 0 {
+		return browse.Page{}, errors.New("no more pages")
+	}
+	page := f.pages[0]
+	f.pages = f.pages[1:]
+	return page, nil
+}
+
+func browseKey(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// sendBrowse sends msg to s and feeds the command's message back, as the
+// model does for an open screen.
+func sendBrowse(t *testing.T, s Screen, msg tea.Msg) Screen {
+	t.Helper()
+	s, cmd := s.Update(msg)
+	if cmd == nil {
+		t.Fatalf("Update(%v) returned no command", msg)
+	}
+	s, _ = s.Update(cmd())
+	return s
+}
+
+func TestBrowseMenu(t *testing.T) {
+	menu := browseMenu(nil, []string{"customer_crosswalk", "metrics_monthly", "ns_customers"})
+
+	var labels []string
+	for _, item := range menu.Items {
+		labels = append(labels, item.Label)
+	}
+	if got := strings.Join(labels, ", "); got != "customer_crosswalk, metrics_monthly, ns_customers, Back" {
+		t.Errorf("browseMenu() items = %s", got)
+	}
+	if menu.Items[0].Action == nil {
+		t.Error("each table should open when chosen")
+	}
+}
+
+func TestBrowseScreen_View(t *testing.T) {
+	page := browse.Page{Rows: []browse.Record{testRecord("1", "Acme"), testRecord("2", "Globex")}, More: true}
+	view := NewBrowseScreen(nil, testBrowseQuery(), page).View()
+
+	for _, want := range []string{"Browse ns_customers", "Sorted by id, ascending", "Page 1, rows 1-2, more follow", "columns 1-6 of 8", "id ▲", "Acme"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "source_file") {
+		t.Errorf("View() should only show the first %d columns:\n%s", browseColumns, view)
+	}
+}
+
+func TestBrowseScreen_SortsByKey(t *testing.T) {
+	q := browse.Query{Table: "metrics_monthly", Columns: []browse.Column{{Name: "period"}, {Name: "mrr"}}, Key: "period"}
+	page := browse.Page{Rows: []browse.Record{{{String: "2025-01-01", Valid: true}, {String: "10", Valid: true}}}}
+	view := NewBrowseScreen(nil, q, page).View()
+
+	if !strings.Contains(view, "Sorted by period, ascending") || !strings.Contains(view, "period ▲") {
+		t.Errorf("a table without id should sort by its key:\n%s", view)
+	}
+}
+
+func TestBrowseScreen_ScrollColumns(t *testing.T) {
+	page := browse.Page{Rows: []browse.Record{testRecord("1", "Acme")}}
+	var s Screen = NewBrowseScreen(nil, testBrowseQuery(), page)
+
+	s, _ = s.Update(browseKey("right"))
+	s, _ = s.Update(browseKey("right"))
+	s, _ = s.Update(browseKey("right")) // already at the last column
+	view := s.View()
+	if !strings.Contains(view, "columns 3-8 of 8") || !strings.Contains(view, "source_file") {
+		t.Errorf("View() after scrolling right:\n%s", view)
+	}
+}
+
+func TestBrowseScreen_Detail(t *testing.T) {
+	page := browse.Page{Rows: []browse.Record{testRecord("1", "Acme")}}
+	var s Screen = NewBrowseScreen(nil, testBrowseQuery(), page)
+
+	s, _ = s.Update(browseKey("enter"))
+	view := s.View()
+	if !strings.Contains(view, "source_file  NULL") || !strings.Contains(view, "name         Acme") {
+		t.Errorf("detail view should list every column:\n%s", view)
+	}
+
+	s, _ = s.Update(browseKey("esc"))
+	if s == nil {
+		t.Fatal("Esc in the detail view should return to the table")
+	}
+	if next, _ := s.Update(browseKey("esc")); next != nil {
+		t.Error("Esc in the table should close the screen")
+	}
+}
+
+func TestBrowseScreen_Paging(t *testing.T) {
+	next := &browse.Cursor{Key: "2"}
+	first := browse.Page{Rows: []browse.Record{testRecord("1", "Acme"), testRecord("2", "Globex")}, More: true, Next: next}
+	second := browse.Page{Rows: []browse.Record{testRecord("3", "Initech")}}
+	f := &recordingFetch{pages: []browse.Page{second, first}}
+	var s Screen = NewBrowseScreen(f.fetch, testBrowseQuery(), first)
+
+	s = sendBrowse(t, s, browseKey("n"))
+	if view := s.View(); !strings.Contains(view, "Page 2, rows 3-3") || !strings.Contains(view, "Initech") {
+		t.Errorf("View() after n:\n%s", view)
+	}
+	if f.afters[0] != next {
+		t.Errorf("n fetched after %+v, expected %+v", f.afters[0], next)
+	}
+	if _, cmd := s.Update(browseKey("n")); cmd != nil {
+		t.Error("n on the last page should not fetch")
+	}
+
+	s = sendBrowse(t, s, browseKey("p"))
+	if view := s.View(); !strings.Contains(view, "Page 1, rows 1-2") {
+		t.Errorf("View() after p:\n%s", view)
+	}
+	if f.afters[1] != nil {
+		t.Errorf("p fetched after %+v, expected the first page", f.afters[1])
+	}
+	if _, cmd := s.Update(browseKey("p")); cmd != nil {
+		t.Error("p on the first page should not fetch")
+	}
+}
+
+func TestBrowseScreen_Inputs(t *testing.T) {
+	page := browse.Page{Rows: []browse.Record{testRecord("1", "Acme")}}
+	f := &recordingFetch{pages: []browse.Page{page, page, page, page}}
+	var s Screen = NewBrowseScreen(f.fetch, testBrowseQuery(), page)
+
+	s, _ = s.Update(browseKey("/"))
+	s, _ = s.Update(browseKey("acme"))
+	s = sendBrowse(t, s, browseKey("enter"))
+	if f.query.Search != "acme" {
+		t.Errorf("Search = %q, expected acme", f.query.Search)
+	}
+
+	s, _ = s.Update(browseKey("f"))
+	s, _ = s.Update(browseKey("bogus"))
+	s, _ = s.Update(browseKey("enter"))
+	if view := s.View(); !strings.Contains(view, "not column=value") {
+		t.Errorf("an invalid filter should show an error:\n%s", view)
+	}
+	s, _ = s.Update(browseKey("esc"))
+	if s == nil {
+		t.Fatal("Esc in the input line should only close the input")
+	}
+
+	s, _ = s.Update(browseKey("f"))
+	s, _ = s.Update(browseKey("name=Acme"))
+	s = sendBrowse(t, s, browseKey("enter"))
+	if f.query.Filter == nil || f.query.Filter.String() != "name=Acme" || f.query.Search != "acme" {
+		t.Errorf("query = %+v, expected the filter added to the search", f.query)
+	}
+
+	s, _ = s.Update(browseKey("s"))
+	s, _ = s.Update(browseKey("-balance"))
+	s = sendBrowse(t, s, browseKey("enter"))
+	if f.query.Sort != "balance" || !f.query.Desc {
+		t.Errorf("Sort = %q desc %v, expected balance descending", f.query.Sort, f.query.Desc)
+	}
+	if view := s.View(); !strings.Contains(view, "balance ▼") || !strings.Contains(view, `search "acme" | filter name=Acme`) {
+		t.Errorf("View() should show the sort and filters:\n%s", view)
+	}
+
+	s = sendBrowse(t, s, browseKey("c"))
+	if f.query.Search != "" || f.query.Filter != nil || f.query.Sort != "balance" {
+		t.Errorf("c should clear the filters and keep the sort, got %+v", f.query)
+	}
+}
+
+func TestBrowseScreen_FetchError(t *testing.T) {
+	page := browse.Page{Rows: []browse.Record{testRecord("1", "Acme")}}
+	f := &recordingFetch{}
+	var s Screen = NewBrowseScreen(f.fetch, testBrowseQuery(), page)
+
+	s = sendBrowse(t, s, browseKey("r"))
+	view := s.View()
+	if !strings.Contains(view, "no more pages") || !strings.Contains(view, "Acme") || !strings.Contains(view, "ascending") {
+		t.Errorf("a failed fetch should keep the current page and show the error:\n%s", view)
+	}
+}
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/JonMunkholm/TUI/internal/admin"
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/report"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ----------------------------------------
+	DUPLICATES MENU
+---------------------------------------- */
+
+func loadDuplicates(m *Model) *Menu {
+	d := &admin.Duplicates{Pool: m.pool}
+
+	var items []MenuItem
+	for _, reg := range handler.Registrations(m.pool) {
+		items = append(items, MenuItem{Label: reg.Props.Table() + " ->", Submenu: loadDuplicateTable(d, m.profile, reg)})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{Title: "Duplicates", Items: items}
+}
+
+func loadDuplicateTable(d *admin.Duplicates, p config.Profile, reg handler.Registration) *Menu {
+	table := reg.Props.Table()
+
+	return &Menu{
+		Title: "Duplicates - " + table,
+		Items: []MenuItem{
+			{Label: "Scan", Action: promptDuplicateKey(table, func(key []string) tea.Cmd {
+				return runReport(func(ctx context.Context) (report.Report, error) {
+					groups, err := d.Scan(ctx, table, key)
+					if err != nil {
+						return report.Report{}, err
+					}
+					return admin.DuplicateReport(table, key, groups), nil
+				})()
+			})},
+			{Label: "Remove duplicates", Writes: true, Action: promptDuplicateKey(table, func(key []string) tea.Cmd {
+				return confirmRemoveDuplicates(d, p, reg, key)
+			})},
+			{Label: "Back"},
+		},
+	}
+}
+
+// promptDuplicateKey asks for the key columns to group rows by.
+func promptDuplicateKey(table string, run func(key []string) tea.Cmd) func() tea.Cmd {
+	return openScreen(func() Screen {
+		body := fmt.Sprintf("Key columns, comma-separated. Blank uses the default (%s); %s compares whole rows except id and source_file.",
+			admin.FormatDuplicateKey(admin.DefaultDuplicateKey(table)), admin.FullRow)
+		return NewPromptScreen("Duplicates in "+table, body, admin.FormatDuplicateKey(admin.DefaultDuplicateKey(table)), nil,
+			func(value string) tea.Cmd {
+				return run(admin.ParseDuplicateKey(table, value))
+			})
+	})
+}
+
+// confirmRemoveDuplicates counts the rows a removal would delete and asks
+// for the table name to be typed before running it.
+func confirmRemoveDuplicates(d *admin.Duplicates, p config.Profile, reg handler.Registration, key []string) tea.Cmd {
+	table := reg.Props.Table()
+
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), admin.DuplicateTimeout)
+		defer cancel()
+
+		groups, err := d.Scan(ctx, table, key)
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		if len(groups) == 0 {
+			return handler.DoneMsg(fmt.Sprintf("No duplicates in %s (key: %s).", table, admin.FormatDuplicateKey(key)))
+		}
+
+		var extra int64
+		for _, g := range groups {
+			extra += g.Extra()
+		}
+		body := fmt.Sprintf("%d groups share a key (%s). One row per group is kept and %d rows are deleted. The table is backed up first.",
+			len(groups), admin.FormatDuplicateKey(key), extra)
+		return ScreenMsg{Screen: confirmDestructive(p, "Remove duplicates in "+table, body, table, func() tea.Cmd {
+			return d.Remove(reg, key)
+		})}
+	}
+}
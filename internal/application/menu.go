@@ -1,9 +1,13 @@
 package application
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/JonMunkholm/TUI/internal/admin"
+	"github.com/JonMunkholm/TUI/internal/config"
 	"github.com/JonMunkholm/TUI/internal/handler"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -16,6 +20,7 @@ type MenuItem struct {
 	Label 		string
 	Submenu		*Menu
 	Action 		func() tea.Cmd
+	Writes		bool // hidden and refused in read-only mode
 }
 
 type Menu struct {
@@ -50,18 +55,19 @@ func linkParents(menu *Menu, parent *Menu) {
 func buildMenuTree(m *Model) *Menu {
 
 	/* Submenus */
+	dbStatus := &admin.DBStatus{Pool: m.pool}
 	submenuInfo := &Menu{
 		Title: "Info",
 		Items: []MenuItem{
-			{Label: "Show DB Version", Action: func() tea.Cmd{
-				return func() tea.Msg { return handler.WdMsg("Database version: placeholder")}
-			}},
+			{Label: "Show DB Version", Action: dbStatus.Run},
 			{Label: "Back"},
 		},
 	}
 
 	upload := loadUpload(m)
 	resetDbs := loadResetDbs(m)
+	adminMenu := loadAdmin(m)
+	reports := loadReports(m)
 
 	/* Root Menu */
 	root := &Menu{
@@ -76,33 +82,123 @@ func buildMenuTree(m *Model) *Menu {
 		},
 	},
 	{Label: "Info ->", Submenu: submenuInfo},
-	{Label: "Upload ->", Submenu: upload},
-	{Label: "Reset DBs ->", Submenu: resetDbs},
+	{Label: "Upload ->", Submenu: upload, Writes: true},
+	{Label: "Reports ->", Submenu: reports},
+	{Label: "Browse ->", Action: m.showBrowse},
+	{Label: "Reset DBs ->", Submenu: resetDbs, Writes: true},
+	{Label: "Admin ->", Submenu: adminMenu},
+	{Label: "Switch Profile ->", Action: m.showProfiles},
 		},
 	}
 
+	if m.profile.ReadOnly {
+		hideWrites(root)
+	}
 	linkParents(root, nil)
 
 	return root
 }
 
+// hideWrites removes items that write to the database, recursively.
+func hideWrites(menu *Menu) {
+	items := menu.Items[:0]
+	for _, item := range menu.Items {
+		if item.Writes {
+			continue
+		}
+		if item.Submenu != nil && item.Label != "Back" {
+			hideWrites(item.Submenu)
+		}
+		items = append(items, item)
+	}
+	menu.Items = items
+}
+
 
 /* ----------------------------------------
 	LOAD MENUS
 ---------------------------------------- */
 
-func loadResetDbs(m *Model) *Menu {
-	resetDbsHandler := &admin.ResetDbs{DB: m.db}
+func loadAdmin(m *Model) *Menu {
+	checker := &admin.SchemaChecker{Pool: m.pool}
 
 	return &Menu{
-		Title: "Reset DBs",
+		Title: "Admin",
 		Items: []MenuItem{
-			{Label: "Reset All", Action: resetDbsHandler.ResetAll},
+			{Label: "Check Schema", Action: checker.Run},
+			{Label: "Migrations ->", Action: m.showMigrations},
+			{Label: "Restore Backup ->", Action: m.showBackups, Writes: true},
+			{Label: "Archive Periods ->", Submenu: loadArchive(m)},
+			{Label: "Duplicates ->", Submenu: loadDuplicates(m)},
 			{Label: "Back"},
 		},
 	}
 }
 
+func loadResetDbs(m *Model) *Menu {
+	resetDbsHandler := &admin.ResetDbs{Pool: m.pool}
+	regs := handler.Registrations(m.pool)
+
+	items := []MenuItem{
+		{Label: "Reset All", Action: confirmReset(resetDbsHandler, m.profile, admin.AllTarget(regs), resetDbsHandler.ResetAll)},
+	}
+	for _, source := range admin.Sources(regs) {
+		items = append(items, MenuItem{Label: source + " ->", Submenu: loadResetSourceMenu(resetDbsHandler, m.profile, source, regs)})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{
+		Title: "Reset DBs",
+		Items: items,
+	}
+}
+
+// loadResetSourceMenu offers a reset of every table of one source, or of one table.
+func loadResetSourceMenu(r *admin.ResetDbs, p config.Profile, source string, regs []handler.Registration) *Menu {
+	target := admin.SourceTarget(source, regs)
+	items := []MenuItem{
+		{Label: "All " + source + " tables", Action: confirmReset(r, p, target, func() tea.Cmd { return r.Reset(target) })},
+	}
+
+	for _, reg := range regs {
+		if reg.Source != source {
+			continue
+		}
+		t := admin.TableTarget(reg)
+		label := t.Name
+		if deps := admin.DependentTables[t.Name]; len(deps) > 0 {
+			label += " (+ " + strings.Join(deps, ", ") + ")"
+		}
+		items = append(items, MenuItem{Label: label, Action: confirmReset(r, p, t, func() tea.Cmd { return r.Reset(t) })})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{Title: "Reset DBs - " + source, Items: items}
+}
+
+// confirmReset counts the rows a reset would delete and asks for the target
+// name to be typed before running it.
+func confirmReset(r *admin.ResetDbs, p config.Profile, target admin.ResetTarget, reset func() tea.Cmd) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), admin.ResetTimeout)
+			defer cancel()
+
+			counts, err := r.Count(ctx, target)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+
+			body := fmt.Sprintf("This deletes %d rows and clears the upload log so these files can be re-imported:\n\n%s",
+				counts.Total(), admin.FormatResetCounts(counts))
+			if len(counts.Kept) > 0 {
+				body += "\n\nArchived rows are not deleted. They stay in the *_archive tables and in the rebuilt revenue schedule and metrics; restore them from Admin → Archive Periods."
+			}
+			return ScreenMsg{Screen: confirmDestructive(p, "Reset "+target.Name, body, target.Name, reset)}
+		}
+	}
+}
+
 func loadUpload(m *Model) *Menu {
 	return &Menu{
 		Title: "Upload",
@@ -116,8 +212,9 @@ func loadUpload(m *Model) *Menu {
 }
 
 // loadUploadMenu is a generic helper that initializes an uploader and builds a menu.
-// If SetProps fails, it returns an error menu instead.
-func loadUploadMenu(title string, uploader handler.Uploader, items []MenuItem) *Menu {
+// If SetProps fails, it returns an error menu instead. Upload actions refuse to
+// run while migrations are pending.
+func loadUploadMenu(m *Model, title string, uploader handler.Uploader, items []MenuItem) *Menu {
 	if err := uploader.SetProps(); err != nil {
 		return &Menu{
 			Title: title,
@@ -128,6 +225,10 @@ func loadUploadMenu(title string, uploader handler.Uploader, items []MenuItem) *
 		}
 	}
 
+	for i := range items {
+		items[i].Action = requireMigrated(m.migrator, items[i].Action)
+	}
+
 	return &Menu{
 		Title: title,
 		Items: items,
@@ -136,7 +237,7 @@ func loadUploadMenu(title string, uploader handler.Uploader, items []MenuItem) *
 
 func loadNsUploadMenu(m *Model) *Menu {
 	h := handler.NewNsUpload(m.pool)
-	return loadUploadMenu("NS - Upload", h, []MenuItem{
+	return loadUploadMenu(m, "NS - Upload", h, []MenuItem{
 		{Label: "Upload Customers", Action: h.InsertNsCustomers},
 		{Label: "Upload SO Detail", Action: h.InsertNsSoDetail},
 		{Label: "Upload Invoice Detail", Action: h.InsertNsInvoiceDetail},
@@ -146,7 +247,7 @@ func loadNsUploadMenu(m *Model) *Menu {
 
 func loadSfdcUploadMenu(m *Model) *Menu {
 	h := handler.NewSfdcUpload(m.pool)
-	return loadUploadMenu("SFDC - Upload", h, []MenuItem{
+	return loadUploadMenu(m, "SFDC - Upload", h, []MenuItem{
 		{Label: "Upload Customers", Action: h.InsertSfdcCustomers},
 		{Label: "Upload Price Book", Action: h.InsertSfdcPriceBook},
 		{Label: "Upload Opps Detail", Action: h.InsertSfdcOppDetail},
@@ -156,7 +257,7 @@ func loadSfdcUploadMenu(m *Model) *Menu {
 
 func loadAnrokUploadMenu(m *Model) *Menu {
 	h := handler.NewAnrokUpload(m.pool)
-	return loadUploadMenu("Anrok - Upload", h, []MenuItem{
+	return loadUploadMenu(m, "Anrok - Upload", h, []MenuItem{
 		{Label: "Upload Anrok Transactions", Action: h.InsertAnrokTransactions},
 		{Label: "Back"},
 	})
@@ -362,3 +362,35 @@ func TestLinkParents_BackNotAtEnd(t *testing.T) {
 		t.Error("Back item at beginning should still point to parent")
 	}
 }
+
+/* ========================================
+	hideWrites Tests
+======================================== */
+
+func TestHideWrites(t *testing.T) {
+	archive := &Menu{
+		Title: "Archive",
+		Items: []MenuItem{
+			{Label: "Status"},
+			{Label: "Archive", Writes: true},
+			{Label: "Back"},
+		},
+	}
+	root := &Menu{
+		Title: "Root",
+		Items: []MenuItem{
+			{Label: "Upload ->", Submenu: &Menu{Title: "Upload"}, Writes: true},
+			{Label: "Archive ->", Submenu: archive},
+			{Label: "Reset", Writes: true},
+		},
+	}
+
+	hideWrites(root)
+
+	if len(root.Items) != 1 || root.Items[0].Label != "Archive ->" {
+		t.Errorf("root items = %v, expected only Archive ->", root.Items)
+	}
+	if len(archive.Items) != 2 || archive.Items[0].Label != "Status" || archive.Items[1].Label != "Back" {
+		t.Errorf("archive items = %v, expected Status and Back", archive.Items)
+	}
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/migrate"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// MigrationTimeout is the maximum duration for applying or rolling back migrations.
+const MigrationTimeout = 5 * time.Minute
+
+// migrationStatusTimeout bounds reading goose_db_version.
+const migrationStatusTimeout = 10 * time.Second
+
+/* ----------------------------------------
+	MIGRATIONS MENU
+---------------------------------------- */
+
+// showMigrations opens the Migrations menu with the current status.
+func (m *Model) showMigrations() tea.Cmd {
+	return migrationsMenuCmd(m.migrator, m.profile, "", false)
+}
+
+// migrationsMenuCmd reads migration status and returns it as a menu. With
+// replace set, the new menu takes the place of the current one so Back still
+// returns to Admin after an apply or rollback.
+func migrationsMenuCmd(r *migrate.Runner, p config.Profile, note string, replace bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), migrationStatusTimeout)
+		defer cancel()
+
+		status, err := r.Status(ctx)
+		if err != nil {
+			return handler.ErrMsg{Err: err}
+		}
+		return MenuMsg{Menu: migrationsMenu(r, p, status, note), Replace: replace}
+	}
+}
+
+// migrationsMenu lists migrations with apply and rollback actions. Rolling back
+// on a production profile asks for the profile name first.
+func migrationsMenu(r *migrate.Runner, p config.Profile, status []migrate.Status, note string) *Menu {
+	var items []MenuItem
+	if note != "" {
+		items = append(items, MenuItem{Label: note})
+	}
+
+	pending := 0
+	latest := ""
+	for _, s := range status {
+		state := "pending"
+		if s.Applied {
+			state = "applied"
+			if !s.AppliedAt.IsZero() {
+				state += " " + s.AppliedAt.Format("2006-01-02 15:04")
+			}
+			latest = s.File
+		} else {
+			pending++
+		}
+		items = append(items, MenuItem{Label: fmt.Sprintf("  %-32s %s", s.File, state)})
+	}
+
+	if pending > 0 {
+		items = append(items, MenuItem{
+			Label:  fmt.Sprintf("Apply pending (%d)", pending),
+			Action: func() tea.Cmd { return applyMigrations(r, p) },
+			Writes: true,
+		})
+	}
+	if latest != "" {
+		items = append(items, MenuItem{
+			Label:  "Roll back " + latest,
+			Action: productionGuard(p, "Roll back "+latest, func() tea.Cmd { return rollbackMigration(r, p) }),
+			Writes: true,
+		})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{Title: "Migrations", Items: items}
+}
+
+func applyMigrations(r *migrate.Runner, p config.Profile) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), MigrationTimeout)
+		defer cancel()
+
+		done, err := r.Up(ctx)
+		note := fmt.Sprintf("Applied %d migration(s).", len(done))
+		if err != nil {
+			note = fmt.Sprintf("Applied %d migration(s), then failed: %v", len(done), err)
+		}
+		return migrationsMenuCmd(r, p, note, true)()
+	}
+}
+
+func rollbackMigration(r *migrate.Runner, p config.Profile) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), MigrationTimeout)
+		defer cancel()
+
+		mig, ok, err := r.Down(ctx)
+		var note string
+		switch {
+		case err != nil:
+			note = "Rollback failed: " + err.Error()
+		case !ok:
+			note = "Nothing to roll back."
+		default:
+			note = "Rolled back " + mig.File + "."
+		}
+		return migrationsMenuCmd(r, p, note, true)()
+	}
+}
+
+/* ----------------------------------------
+	UPLOAD GUARD
+---------------------------------------- */
+
+// requireMigrated wraps an action so it fails while migrations are pending.
+// The check runs each time, so applying migrations unblocks uploads without a restart.
+func requireMigrated(r *migrate.Runner, action func() tea.Cmd) func() tea.Cmd {
+	if r == nil || action == nil {
+		return action
+	}
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), migrationStatusTimeout)
+			pending, err := r.Pending(ctx)
+			cancel()
+			if err != nil {
+				return handler.ErrMsg{Err: fmt.Errorf("checking migrations: %w", err)}
+			}
+			if err := migrate.Behind(pending); err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+			return action()()
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package application
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/migrate"
+)
+
+/* ========================================
+	migrationsMenu Tests
+======================================== */
+
+func TestMigrationsMenu(t *testing.T) {
+	at := time.Date(2025, 3, 4, 5, 6, 0, 0, time.UTC)
+	status := []migrate.Status{
+		{Migration: migrate.Migration{Version: 1, File: "001_a.sql"}, Applied: true, AppliedAt: at},
+		{Migration: migrate.Migration{Version: 2, File: "002_b.sql"}, Applied: true},
+		{Migration: migrate.Migration{Version: 3, File: "003_c.sql"}},
+	}
+
+	menu := migrationsMenu(nil, config.Profile{}, status, "Applied 1 migration(s).")
+
+	labels := make([]string, len(menu.Items))
+	for i, item := range menu.Items {
+		labels[i] = strings.Join(strings.Fields(item.Label), " ")
+	}
+
+	expected := []string{
+		"Applied 1 migration(s).",
+		"001_a.sql applied 2025-03-04 05:06",
+		"002_b.sql applied",
+		"003_c.sql pending",
+		"Apply pending (1)",
+		"Roll back 002_b.sql",
+		"Back",
+	}
+	if strings.Join(labels, "|") != strings.Join(expected, "|") {
+		t.Errorf("labels = %q, expected %q", labels, expected)
+	}
+
+	if menu.Items[4].Action == nil || menu.Items[5].Action == nil {
+		t.Error("apply and rollback items should have actions")
+	}
+}
+
+func TestMigrationsMenu_UpToDate(t *testing.T) {
+	status := []migrate.Status{
+		{Migration: migrate.Migration{Version: 1, File: "001_a.sql"}, Applied: true},
+	}
+
+	for _, item := range migrationsMenu(nil, config.Profile{}, status, "").Items {
+		if strings.HasPrefix(item.Label, "Apply pending") {
+			t.Error("no apply item expected when nothing is pending")
+		}
+	}
+}
+
+func TestMigrationsMenu_NothingApplied(t *testing.T) {
+	status := []migrate.Status{
+		{Migration: migrate.Migration{Version: 1, File: "001_a.sql"}},
+	}
+
+	for _, item := range migrationsMenu(nil, config.Profile{}, status, "").Items {
+		if strings.HasPrefix(item.Label, "Roll back") {
+			t.Error("no rollback item expected when nothing is applied")
+		}
+	}
+}
+
+/* ========================================
+	MenuMsg Replace Tests
+======================================== */
+
+func TestUpdate_MenuMsgReplace(t *testing.T) {
+	adminMenu := &Menu{Title: "Admin"}
+	current := &Menu{Title: "Migrations", Parent: adminMenu}
+	m := &Model{currentMenu: current}
+
+	refreshed := &Menu{Title: "Migrations", Items: []MenuItem{{Label: "Back"}}}
+	m.Update(MenuMsg{Menu: refreshed, Replace: true})
+
+	if m.currentMenu != refreshed {
+		t.Fatal("currentMenu should be the refreshed menu")
+	}
+	if refreshed.Parent != adminMenu {
+		t.Error("replaced menu should inherit the old menu's parent")
+	}
+	if refreshed.Items[0].Submenu != adminMenu {
+		t.Error("Back should return to the old menu's parent")
+	}
+}
+
+func TestUpdate_MenuMsgNested(t *testing.T) {
+	current := &Menu{Title: "Admin"}
+	m := &Model{currentMenu: current}
+
+	generated := &Menu{Title: "Migrations", Items: []MenuItem{{Label: "Back"}}}
+	m.Update(MenuMsg{Menu: generated})
+
+	if generated.Parent != current || generated.Items[0].Submenu != current {
+		t.Error("generated menu should nest under the current menu")
+	}
+}
+
+func TestRequireMigrated_NilRunner(t *testing.T) {
+	if requireMigrated(nil, nil) != nil {
+		t.Error("requireMigrated(nil, nil) should return nil action")
+	}
+}
@@ -6,8 +6,12 @@ import (
 	"os"
 	"time"
 
+	"github.com/JonMunkholm/TUI/internal/admin"
+	"github.com/JonMunkholm/TUI/internal/config"
 	db "github.com/JonMunkholm/TUI/internal/database"
 	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/migrate"
+	sqlfiles "github.com/JonMunkholm/TUI/sql"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -33,26 +37,71 @@ type Model struct {
 	spinner		spinner.Model
 	db			*db.Queries
 	pool        *pgxpool.Pool
+	migrator    *migrate.Runner
+	profile     config.Profile
+	profiles    config.Config
+	readOnly    bool // --read-only: every profile connects read-only
+	screen      Screen
 	output		string
 }
 
 // InitialModel creates and initializes the application model with database connection.
 // Returns an error if initialization fails. Caller must call Close() on the returned
 // model when done, even if an error is returned (to clean up partial initialization).
-func InitialModel() (*Model, error) {
+// With readOnly set, every profile is opened in read-only mode.
+func InitialModel(readOnly bool) (*Model, error) {
 	model := &Model{
-		spinner: spinnerModel(),
+		spinner:  spinnerModel(),
+		readOnly: readOnly,
 	}
 
-	dbURL := os.Getenv("DB_URL")
-	if dbURL == "" {
-		return model, fmt.Errorf("DB_URL environment variable must be set")
+	// Row warnings are always stored in import_warnings; the CSV copy is optional
+	if os.Getenv("WARNINGS_CSV") == "false" {
+		handler.WriteWarningsFile = false
+	}
+
+	profiles, err := config.Load(config.Path())
+	if err != nil {
+		return model, err
+	}
+	model.profiles = profiles
+
+	profile, err := profiles.Initial()
+	if err != nil {
+		return model, err
+	}
+	profile.ReadOnly = profile.ReadOnly || readOnly
+
+	conn, err := connect(profile)
+	if err != nil {
+		return model, err
+	}
+	model.use(conn)
+
+	return model, nil
+}
+
+// connection is an open pool for one profile, with the startup notes to show.
+type connection struct {
+	profile  config.Profile
+	pool     *pgxpool.Pool
+	migrator *migrate.Runner
+	output   string
+}
+
+// connect opens and checks a pool for profile. The pool is closed again on error.
+// Read-only profiles set default_transaction_read_only, so PostgreSQL rejects
+// any write made through the pool.
+func connect(profile config.Profile) (*connection, error) {
+	dbURL, err := profile.ConnString()
+	if err != nil {
+		return nil, err
 	}
 
 	// Parse and configure connection pool
 	config, err := pgxpool.ParseConfig(dbURL)
 	if err != nil {
-		return model, fmt.Errorf("unable to parse DB_URL: %w", err)
+		return nil, fmt.Errorf("unable to parse URL for profile %q: %w", profile.Name, err)
 	}
 
 	// Connection pool settings
@@ -63,25 +112,71 @@ func InitialModel() (*Model, error) {
 	config.HealthCheckPeriod = 1 * time.Minute         // Check connection health every minute
 	config.ConnConfig.ConnectTimeout = 5 * time.Second // Connection timeout
 
+	if profile.ReadOnly {
+		config.ConnConfig.RuntimeParams["default_transaction_read_only"] = "on"
+	}
+
 	// Create pool with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
-		return model, fmt.Errorf("unable to create connection pool: %w", err)
+		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
-	model.pool = pool
 
 	// Verify database is reachable
 	if err := pool.Ping(ctx); err != nil {
-		return model, fmt.Errorf("unable to connect to database: %w", err)
+		pool.Close()
+		return nil, fmt.Errorf("unable to connect to database %q: %w", profile.Name, err)
 	}
 
-	model.db = db.New(pool)
-	model.currentMenu = buildMenuTree(model)
+	migrator, err := migrate.New(pool, sqlfiles.Migrations, sqlfiles.MigrationsDir)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
 
-	return model, nil
+	return &connection{
+		profile:  profile,
+		pool:     pool,
+		migrator: migrator,
+		output:   startupNotes(ctx, pool, migrator),
+	}, nil
+}
+
+// startupNotes reports pending migrations and schema drift before the user
+// starts an upload. Uploads are blocked until pending migrations are applied.
+func startupNotes(ctx context.Context, pool *pgxpool.Pool, migrator *migrate.Runner) string {
+	if pending, err := migrator.Pending(ctx); err != nil {
+		return "Migration check failed: " + err.Error()
+	} else if err := migrate.Behind(pending); err != nil {
+		return "Uploads are disabled: " + err.Error() + "."
+	}
+
+	issues, err := admin.CheckSchema(ctx, pool, handler.Registrations(pool))
+	if err != nil {
+		return "Schema check failed: " + err.Error()
+	} else if len(issues) > 0 {
+		return admin.FormatSchemaIssues(issues)
+	}
+	return ""
+}
+
+// use switches the model to conn, closing the previous pool and rebuilding
+// the menus so every action runs against the new database.
+func (m *Model) use(conn *connection) {
+	if m.pool != nil {
+		m.pool.Close()
+	}
+
+	m.profile = conn.profile
+	m.pool = conn.pool
+	m.db = db.New(conn.pool)
+	m.migrator = conn.migrator
+	m.currentMenu = buildMenuTree(m)
+	m.cursor = 0
+	m.output = conn.output
 }
 
 // Close releases database resources. Call this when the application exits.
@@ -114,9 +209,36 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds...)
 	}
 
+	switch msg := msg.(type) {
+	case ScreenMsg:
+		m.screen = msg.Screen
+		m.loading = false
+		return m, nil
+	case profileMsg:
+		m.use(msg.conn)
+		m.output = switchedNote(msg.conn)
+		m.screen = nil
+		m.loading = false
+		return m, nil
+	}
+
+	if m.screen != nil && m.output == "" && !m.loading {
+		if key, ok := msg.(tea.KeyMsg); ok && key.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		return m, tea.Batch(append(cmds, updateScreen(m, msg))...)
+	}
+
 	switch msg := msg.(type) {
 	case MenuMsg:
-		msg.Menu.Parent = m.currentMenu
+		parent := m.currentMenu
+		if msg.Replace {
+			parent = m.currentMenu.Parent
+		}
+		if m.profile.ReadOnly {
+			hideWrites(msg.Menu)
+		}
+		linkParents(msg.Menu, parent)
 		m.currentMenu = msg.Menu
 		m.cursor = 0
 		m.loading = false
@@ -149,6 +271,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if item.Submenu != nil {
 				m.currentMenu = item.Submenu
 				m.cursor = 0
+			} else if item.Writes && m.profile.ReadOnly {
+				m.output = "Read-only mode: " + item.Label + " is disabled."
 			} else if item.Action != nil {
 				m.loading = true
 				cmds = append(cmds,
@@ -161,6 +285,19 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// updateScreen forwards msg to the open screen. A screen that closes with a
+// command hands it off as a menu action.
+func updateScreen(m *Model, msg tea.Msg) tea.Cmd {
+	next, cmd := m.screen.Update(msg)
+	m.screen = next
+	if next != nil || cmd == nil {
+		return cmd
+	}
+
+	m.loading = true
+	return tea.Batch(m.spinner.Tick, HandleTeaCmdErrorWithTitle(m.currentMenu.Title, m.currentMenu, cmd))
+}
+
 func handleResultMessage(m *Model, msg tea.Msg) bool {
 	switch msg := msg.(type) {
 	case handler.DoneMsg, handler.WdMsg:
@@ -191,6 +328,11 @@ func (m Model) View() string {
 		return fmt.Sprintf("\n%s\n\nPress any key to return.\n", m.output)
 	}
 
+	// Screen view
+	if m.screen != nil {
+		return "\n" + withBadge(m.profile, helpStyle.Render(m.screen.View())) + "\n"
+	}
+
 	// Menu view
 	s := fmt.Sprintf("%s\n\n", m.currentMenu.Title)
 
@@ -204,14 +346,19 @@ func (m Model) View() string {
 
 	s += "\nBackspace to go back, q to quit.\n"
 
-	return "\n" + helpStyle.Render(s) + "\n"
+	return "\n" + withBadge(m.profile, helpStyle.Render(s)) + "\n"
 }
 
 
 /* ----------------------------------------
 	HANDLER MIDDLEWARE
 ---------------------------------------- */
-type MenuMsg struct { Menu *Menu }
+// MenuMsg switches to a generated menu. Replace swaps out the current menu
+// (e.g. to refresh it) instead of nesting the new one under it.
+type MenuMsg struct {
+	Menu    *Menu
+	Replace bool
+}
 
 func HandleTeaCmdErrorWithTitle(title string, parent *Menu, cmd tea.Cmd) tea.Cmd {
 	return func() tea.Msg {
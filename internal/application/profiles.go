@@ -0,0 +1,142 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	productionStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#ffffff")).Background(lipgloss.Color("#c0392b"))
+	readOnlyStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#000000")).Background(lipgloss.Color("#f1c40f"))
+)
+
+/* ----------------------------------------
+	PROFILE SWITCHER
+---------------------------------------- */
+
+// profileMsg carries a new connection opened by the profile switcher.
+type profileMsg struct{ conn *connection }
+
+// showProfiles lists the configured profiles; choosing one reconnects.
+func (m *Model) showProfiles() tea.Cmd {
+	menu := profilesMenu(m.profiles, m.profile, m.readOnly)
+	return func() tea.Msg { return MenuMsg{Menu: menu} }
+}
+
+// profilesMenu lists profiles to switch to. With forceReadOnly set (the
+// --read-only flag), every profile is opened read-only.
+func profilesMenu(profiles config.Config, active config.Profile, forceReadOnly bool) *Menu {
+	var items []MenuItem
+	for _, p := range profiles.Profiles {
+		p.ReadOnly = p.ReadOnly || forceReadOnly
+
+		label := p.Name
+		if p.Production {
+			label += " (production)"
+		}
+		if p.ReadOnly {
+			label += " (read-only)"
+		}
+		if p.Name == active.Name {
+			items = append(items, MenuItem{Label: label + " - active"})
+			continue
+		}
+		items = append(items, MenuItem{Label: label, Action: switchProfile(p)})
+	}
+	items = append(items, MenuItem{Label: "Back"})
+
+	return &Menu{Title: "Switch Profile", Items: items}
+}
+
+// switchProfile connects to p. Switching to a production profile asks for
+// its name to be typed first.
+func switchProfile(p config.Profile) func() tea.Cmd {
+	connectCmd := func() tea.Cmd {
+		return func() tea.Msg {
+			conn, err := connect(p)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+			return profileMsg{conn: conn}
+		}
+	}
+
+	if !p.Production {
+		return connectCmd
+	}
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			body := fmt.Sprintf("%q is a production database. Resets, restores, archiving and rollbacks there need a second confirmation.", p.Name)
+			return ScreenMsg{Screen: NewConfirmScreen("Switch to "+p.Name, body, p.Name, connectCmd)}
+		}
+	}
+}
+
+// switchedNote is shown after a profile switch, followed by any startup notes.
+func switchedNote(conn *connection) string {
+	note := "Connected to " + conn.profile.Name + "."
+	if conn.output != "" {
+		note += "\n\n" + conn.output
+	}
+	return note
+}
+
+/* ----------------------------------------
+	PRODUCTION GUARD
+---------------------------------------- */
+
+// confirmDestructive asks for phrase before running action and, on a
+// production profile, for the profile name as well.
+func confirmDestructive(p config.Profile, title, body, phrase string, action func() tea.Cmd) Screen {
+	return NewConfirmScreen(title, body, phrase, productionGuard(p, title, action))
+}
+
+// productionGuard wraps action so that on a production profile it only runs
+// after the profile name is typed.
+func productionGuard(p config.Profile, title string, action func() tea.Cmd) func() tea.Cmd {
+	if !p.Production {
+		return action
+	}
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			body := fmt.Sprintf("%q is a PRODUCTION database. Type the profile name to run %q there.", p.Name, title)
+			return ScreenMsg{Screen: NewConfirmScreen(title+" on "+p.Name, body, p.Name, action)}
+		}
+	}
+}
+
+// profileBadge renders the active profile and its mode for the top of every view.
+func profileBadge(p config.Profile) string {
+	if p.Name == "" {
+		return ""
+	}
+
+	label := p.Name
+	if p.Production {
+		label += " - PRODUCTION"
+	}
+	if p.ReadOnly {
+		label += " - READ-ONLY"
+	}
+
+	switch {
+	case p.Production:
+		return productionStyle.Render(" " + label + " ")
+	case p.ReadOnly:
+		return readOnlyStyle.Render(" " + label + " ")
+	}
+	return "[" + label + "]"
+}
+
+// withBadge puts the profile badge above a rendered view.
+func withBadge(p config.Profile, view string) string {
+	badge := profileBadge(p)
+	if badge == "" {
+		return view
+	}
+	return badge + "\n\n" + view
+}
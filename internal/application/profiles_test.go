@@ -0,0 +1,197 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ========================================
+	Production Guard Tests
+======================================== */
+
+func TestProductionGuard_NonProduction(t *testing.T) {
+	action := func() tea.Cmd {
+		return func() tea.Msg { return handler.DoneMsg("reset") }
+	}
+
+	cmd := productionGuard(config.Profile{Name: "sandbox"}, "Reset All", action)()
+	if msg := cmd(); msg != handler.DoneMsg("reset") {
+		t.Errorf("cmd() = %v, expected the action to run directly", msg)
+	}
+}
+
+func TestProductionGuard_Production(t *testing.T) {
+	ran := false
+	action := func() tea.Cmd {
+		ran = true
+		return func() tea.Msg { return handler.DoneMsg("reset") }
+	}
+
+	msg := productionGuard(config.Profile{Name: "prod", Production: true}, "Reset All", action)()()
+	screenMsg, ok := msg.(ScreenMsg)
+	if !ok {
+		t.Fatalf("msg = %T, expected ScreenMsg", msg)
+	}
+	if ran {
+		t.Fatal("action should not run before the second confirmation")
+	}
+	if !strings.Contains(screenMsg.Screen.View(), "PRODUCTION") {
+		t.Errorf("View() should warn about production:\n%s", screenMsg.Screen.View())
+	}
+
+	// The first phrase is not enough; the profile name is required
+	s := typeString(screenMsg.Screen, "ALL")
+	if next, _ := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); next == nil || ran {
+		t.Fatal("wrong phrase should keep the screen open")
+	}
+
+	fresh := productionGuard(config.Profile{Name: "prod", Production: true}, "Reset All", action)()().(ScreenMsg)
+	s = typeString(fresh.Screen, "prod")
+	if next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter}); next != nil || cmd == nil || !ran {
+		t.Error("typing the profile name should run the action")
+	}
+}
+
+func TestConfirmDestructive_ProductionNeedsTwoPhrases(t *testing.T) {
+	ran := false
+	action := func() tea.Cmd {
+		ran = true
+		return nil
+	}
+
+	s := confirmDestructive(config.Profile{Name: "prod", Production: true}, "Reset All", "body", "ALL", action)
+	s = typeString(s, "ALL")
+	next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if next != nil || cmd == nil {
+		t.Fatal("first confirmation should close with a command")
+	}
+	if ran {
+		t.Fatal("action should wait for the second confirmation")
+	}
+
+	second, ok := cmd().(ScreenMsg)
+	if !ok {
+		t.Fatal("first confirmation should open the production confirmation")
+	}
+	s = typeString(second.Screen, "prod")
+	s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !ran {
+		t.Error("action should run after the profile name is typed")
+	}
+}
+
+/* ========================================
+	Profiles Menu Tests
+======================================== */
+
+func TestProfilesMenu(t *testing.T) {
+	profiles := config.Config{Profiles: []config.Profile{
+		{Name: "sandbox", URL: "x"},
+		{Name: "prod", URL: "y", Production: true},
+	}}
+
+	menu := profilesMenu(profiles, profiles.Profiles[0], false)
+
+	labels := make([]string, len(menu.Items))
+	for i, item := range menu.Items {
+		labels[i] = item.Label
+	}
+	expected := []string{"sandbox - active", "prod (production)", "Back"}
+	if strings.Join(labels, "|") != strings.Join(expected, "|") {
+		t.Errorf("labels = %q, expected %q", labels, expected)
+	}
+
+	if menu.Items[0].Action != nil {
+		t.Error("the active profile should not have an action")
+	}
+	if menu.Items[1].Action == nil {
+		t.Error("other profiles should have a switch action")
+	}
+}
+
+func TestSwitchProfile_ProductionConfirms(t *testing.T) {
+	msg := switchProfile(config.Profile{Name: "prod", URL: "postgres://prod", Production: true})()()
+
+	screenMsg, ok := msg.(ScreenMsg)
+	if !ok {
+		t.Fatalf("msg = %T, expected ScreenMsg before connecting to production", msg)
+	}
+	if !strings.Contains(screenMsg.Screen.View(), "production database") {
+		t.Errorf("View() should warn about production:\n%s", screenMsg.Screen.View())
+	}
+}
+
+func TestWithBadge(t *testing.T) {
+	if got := withBadge(config.Profile{}, "menu"); got != "menu" {
+		t.Errorf("withBadge(no profile) = %q, expected %q", got, "menu")
+	}
+	if got := withBadge(config.Profile{Name: "sandbox"}, "menu"); got != "[sandbox]\n\nmenu" {
+		t.Errorf("withBadge(sandbox) = %q", got)
+	}
+	if got := withBadge(config.Profile{Name: "prod", Production: true}, "menu"); !strings.Contains(got, "prod - PRODUCTION") {
+		t.Errorf("withBadge(prod) = %q, expected production badge", got)
+	}
+}
+
+/* ========================================
+	Read-only Mode Tests
+======================================== */
+
+func TestUpdate_ReadOnlyRefusesWrites(t *testing.T) {
+	ran := false
+	menu := &Menu{Title: "Reset DBs", Items: []MenuItem{
+		{Label: "Reset All", Writes: true, Action: func() tea.Cmd {
+			ran = true
+			return nil
+		}},
+	}}
+	m := &Model{currentMenu: menu, profile: config.Profile{Name: "audit", ReadOnly: true}}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if ran || m.loading {
+		t.Error("write actions should not run in read-only mode")
+	}
+	if !strings.Contains(m.output, "Read-only mode") {
+		t.Errorf("output = %q, expected a read-only message", m.output)
+	}
+}
+
+func TestUpdate_ReadOnlyHidesWritesInMenuMsg(t *testing.T) {
+	m := &Model{currentMenu: &Menu{Title: "Admin"}, profile: config.Profile{Name: "audit", ReadOnly: true}}
+
+	m.Update(MenuMsg{Menu: &Menu{Title: "Migrations", Items: []MenuItem{
+		{Label: "001_a.sql applied"},
+		{Label: "Roll back 001_a.sql", Writes: true},
+		{Label: "Back"},
+	}}})
+
+	for _, item := range m.currentMenu.Items {
+		if item.Writes {
+			t.Errorf("item %q should be hidden in read-only mode", item.Label)
+		}
+	}
+}
+
+func TestProfilesMenu_ForceReadOnly(t *testing.T) {
+	profiles := config.Config{Profiles: []config.Profile{
+		{Name: "sandbox", URL: "x"},
+		{Name: "audit", URL: "y"},
+	}}
+
+	menu := profilesMenu(profiles, profiles.Profiles[0], true)
+
+	if got := menu.Items[1].Label; got != "audit (read-only)" {
+		t.Errorf("label = %q, expected %q", got, "audit (read-only)")
+	}
+}
+
+func TestProfileBadge_ReadOnly(t *testing.T) {
+	if got := profileBadge(config.Profile{Name: "audit", ReadOnly: true}); !strings.Contains(got, "audit - READ-ONLY") {
+		t.Errorf("profileBadge() = %q, expected read-only badge", got)
+	}
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/report"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reportHeight is the number of table rows shown at once.
+const reportHeight = 15
+
+/* ----------------------------------------
+	REPORT SCREEN
+---------------------------------------- */
+
+// ReportScreen shows a report as a scrollable table. Enter shows the selected
+// row in full, e exports the report to CSV, Esc closes it.
+type ReportScreen struct {
+	report report.Report
+	table  table.Model
+	detail bool
+	status string
+}
+
+// exportedMsg reports the outcome of an export back to the screen.
+type exportedMsg struct {
+	path string
+	err  error
+}
+
+// NewReportScreen returns a screen for r.
+func NewReportScreen(r report.Report) *ReportScreen {
+	columns := []table.Column{{Title: "!", Width: 1}}
+	for i, c := range r.Columns {
+		columns = append(columns, table.Column{Title: c.Title, Width: columnWidth(r, i)})
+	}
+
+	rows := make([]table.Row, len(r.Rows))
+	for i, row := range r.Rows {
+		flag := ""
+		if row.Flagged {
+			flag = "!"
+		}
+		rows[i] = append(table.Row{flag}, row.Cells...)
+	}
+
+	t := table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(reportHeight),
+		table.WithFocused(true),
+	)
+
+	return &ReportScreen{report: r, table: t}
+}
+
+// columnWidth fits column i to its content, capped at the column's Width.
+func columnWidth(r report.Report, i int) int {
+	c := r.Columns[i]
+	width := len(c.Title)
+	for _, row := range r.Rows {
+		if i < len(row.Cells) && len(row.Cells[i]) > width {
+			width = len(row.Cells[i])
+		}
+	}
+	if c.Width > 0 && width > c.Width {
+		width = c.Width
+	}
+	return width
+}
+
+func (s *ReportScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	switch msg := msg.(type) {
+	case exportedMsg:
+		if msg.err != nil {
+			s.status = "Export failed: " + msg.err.Error()
+		} else {
+			s.status = "Exported to " + msg.path
+		}
+		return s, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "q", "backspace":
+			if s.detail {
+				s.detail = false
+				return s, nil
+			}
+			return nil, nil
+		case "enter":
+			s.detail = !s.detail && len(s.report.Rows) > 0
+			return s, nil
+		case "e":
+			r := s.report
+			s.status = "Exporting..."
+			return s, func() tea.Msg {
+				path, err := report.Export(r, time.Now())
+				return exportedMsg{path: path, err: err}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	s.table, cmd = s.table.Update(msg)
+	return s, cmd
+}
+
+func (s *ReportScreen) View() string {
+	var b strings.Builder
+	b.WriteString(s.report.Title + "\n\n")
+	if s.report.Summary != "" {
+		b.WriteString(s.report.Summary + "\n")
+	}
+	if n := s.report.Flagged(); n > 0 {
+		fmt.Fprintf(&b, "%d row(s) flagged with !\n", n)
+	}
+	b.WriteString("\n")
+
+	if len(s.report.Rows) == 0 {
+		b.WriteString("No rows.\n")
+	} else if s.detail {
+		b.WriteString(s.detailView())
+	} else {
+		b.WriteString(s.table.View() + "\n")
+	}
+
+	if s.status != "" {
+		b.WriteString("\n" + s.status + "\n")
+	}
+	b.WriteString("\n↑/↓ scroll, Enter details, e export CSV, Esc back.\n")
+	return b.String()
+}
+
+// detailView lists every column of the selected row without truncation.
+func (s *ReportScreen) detailView() string {
+	row := s.report.Rows[s.table.Cursor()]
+
+	width := 0
+	for _, c := range s.report.Columns {
+		width = max(width, len(c.Title))
+	}
+
+	var b strings.Builder
+	for i, c := range s.report.Columns {
+		value := ""
+		if i < len(row.Cells) {
+			value = row.Cells[i]
+		}
+		fmt.Fprintf(&b, "%-*s  %s\n", width, c.Title, value)
+	}
+	return b.String()
+}
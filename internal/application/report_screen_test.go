@@ -0,0 +1,85 @@
+package application
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/report"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ========================================
+	ReportScreen Tests
+======================================== */
+
+func testReport() report.Report {
+	return report.Report{
+		Title:   "Recon",
+		Summary: "2 lines",
+		Columns: []report.Column{{Title: "Status", Width: 8}, {Title: "Differences", Width: 10}},
+		Rows: []report.Row{
+			{Cells: []string{"Mismatch", "quantity 10 vs 12; price 1.00 vs 2.00"}, Flagged: true},
+			{Cells: []string{"Missing", ""}},
+		},
+	}
+}
+
+func TestReportScreen_View(t *testing.T) {
+	view := NewReportScreen(testReport()).View()
+
+	for _, want := range []string{"Recon", "2 lines", "1 row(s) flagged", "Status", "e export CSV"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() missing %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestReportScreen_Detail(t *testing.T) {
+	var s Screen = NewReportScreen(testReport())
+
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(s.View(), "quantity 10 vs 12; price 1.00 vs 2.00") {
+		t.Errorf("detail view should show the full row:\n%s", s.View())
+	}
+
+	// Esc leaves the detail view first, then closes the screen
+	s, _ = s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if s == nil {
+		t.Fatal("Esc in the detail view should return to the table")
+	}
+	if next, _ := s.Update(tea.KeyMsg{Type: tea.KeyEsc}); next != nil {
+		t.Error("Esc in the table should close the screen")
+	}
+}
+
+func TestReportScreen_ExportStatus(t *testing.T) {
+	var s Screen = NewReportScreen(testReport())
+
+	s, cmd := s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	if cmd == nil {
+		t.Fatal("e should start an export")
+	}
+
+	s, _ = s.Update(exportedMsg{path: "accounting/reports/recon.csv"})
+	if !strings.Contains(s.View(), "Exported to accounting/reports/recon.csv") {
+		t.Errorf("View() should show the export path:\n%s", s.View())
+	}
+
+	s, _ = s.Update(exportedMsg{err: errors.New("disk full")})
+	if !strings.Contains(s.View(), "Export failed: disk full") {
+		t.Errorf("View() should show the export error:\n%s", s.View())
+	}
+}
+
+func TestColumnWidth(t *testing.T) {
+	r := testReport()
+
+	if got := columnWidth(r, 0); got != 8 {
+		t.Errorf("columnWidth(Status) = %d, expected 8", got)
+	}
+	// Capped at the column's Width
+	if got := columnWidth(r, 1); got != 10 {
+		t.Errorf("columnWidth(Differences) = %d, expected 10", got)
+	}
+}
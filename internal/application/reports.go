@@ -0,0 +1,294 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/admin"
+	"github.com/JonMunkholm/TUI/internal/config"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/report"
+	"github.com/JonMunkholm/TUI/internal/sfid"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ----------------------------------------
+	REPORTS MENU
+---------------------------------------- */
+
+func loadReports(m *Model) *Menu {
+	return &Menu{
+		Title: "Reports",
+		Items: []MenuItem{
+			{Label: "SFDC vs NetSuite SO Lines", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				return report.LoadOppSo(ctx, m.db, report.DefaultTolerance)
+			})},
+			{Label: "NetSuite Invoices vs Anrok", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				return report.LoadInvoiceAnrok(ctx, m.db, report.DefaultTolerance, config.LoadTaxLines())
+			})},
+			{Label: "Customer Crosswalk ->", Submenu: loadCrosswalk(m)},
+			{Label: "Price Book & Discounts ->", Submenu: loadPriceBook(m)},
+			{Label: "Revenue Schedule ->", Submenu: loadRevenue(m)},
+			{Label: "ARR / MRR Metrics ->", Submenu: loadMetrics(m)},
+			{Label: "Billed vs Contracted ->", Submenu: loadBilling(m)},
+			{Label: "Sales Tax ->", Submenu: loadSalesTax(m)},
+			{Label: "AR Aging", Action: openScreen(func() Screen {
+				return NewPromptScreen("AR Aging", "Age receivables as of (YYYY-MM-DD).\nLeave blank for today.", "YYYY-MM-DD",
+					validateAsOf, func(value string) tea.Cmd {
+						asOf, _ := parseAsOf(value)
+						return runReport(func(ctx context.Context) (report.Report, error) {
+							return report.LoadAging(ctx, m.db, asOf, report.DefaultTolerance)
+						})()
+					})
+			})},
+			{Label: "Back"},
+		},
+	}
+}
+
+func loadCrosswalk(m *Model) *Menu {
+	cw := &admin.Crosswalk{Pool: m.pool}
+
+	return &Menu{
+		Title: "Customer Crosswalk",
+		Items: []MenuItem{
+			{Label: "Crosswalk Report", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				return report.LoadCustomers(ctx, m.db)
+			})},
+			{Label: "Set manual link", Writes: true, Action: openScreen(func() Screen {
+				return NewPromptScreen("Set Manual Link", "NetSuite customer internal ID:", "internal ID",
+					required("NS internal ID"), func(nsID string) tea.Cmd {
+						nsID = strings.TrimSpace(nsID)
+						return func() tea.Msg {
+							return ScreenMsg{Screen: NewPromptScreen("Set Manual Link",
+								"Salesforce account ID for NS customer "+nsID+" (15 or 18 characters).\nLeave blank to record that it has no account.",
+								"account ID", validateAccountID, func(accountID string) tea.Cmd {
+									return cw.SetOverride(nsID, strings.TrimSpace(accountID))
+								})}
+						}
+					})
+			})},
+			{Label: "Clear manual link", Writes: true, Action: openScreen(func() Screen {
+				return NewPromptScreen("Clear Manual Link", "NetSuite customer internal ID:", "internal ID",
+					required("NS internal ID"), func(nsID string) tea.Cmd {
+						return cw.ClearOverride(strings.TrimSpace(nsID))
+					})
+			})},
+			{Label: "Rebuild crosswalk", Writes: true, Action: cw.Rebuild},
+			{Label: "Back"},
+		},
+	}
+}
+
+// required is a prompt validator that rejects blank input.
+func required(name string) func(string) error {
+	return func(value string) error {
+		if strings.TrimSpace(value) == "" {
+			return fmt.Errorf("%s is required", name)
+		}
+		return nil
+	}
+}
+
+func validateAccountID(value string) error {
+	if strings.TrimSpace(value) == "" || sfid.Valid(value) {
+		return nil
+	}
+	return fmt.Errorf("%q is not a 15- or 18-character Salesforce ID", value)
+}
+
+// parseAsOf reads a YYYY-MM-DD date, defaulting to today when blank.
+func parseAsOf(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		y, mo, d := time.Now().Date()
+		return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC), nil
+	}
+	asOf, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a YYYY-MM-DD date", value)
+	}
+	return asOf, nil
+}
+
+func validateAsOf(value string) error {
+	_, err := parseAsOf(value)
+	return err
+}
+
+// runReport is a menu action that builds a report and opens it in a ReportScreen.
+func runReport(load func(ctx context.Context) (report.Report, error)) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), report.Timeout)
+			defer cancel()
+
+			r, err := load(ctx)
+			if err != nil {
+				return handler.ErrMsg{Err: err}
+			}
+			return ScreenMsg{Screen: NewReportScreen(r)}
+		}
+	}
+}
+
+func loadPriceBook(m *Model) *Menu {
+	lines := func(build func([]report.PriceLine, float64) report.Report) func() tea.Cmd {
+		return promptThreshold(func(threshold float64) tea.Cmd {
+			return runReport(func(ctx context.Context) (report.Report, error) {
+				priceLines, err := report.LoadPriceLines(ctx, m.db)
+				if err != nil {
+					return report.Report{}, err
+				}
+				return build(priceLines, threshold), nil
+			})()
+		})
+	}
+	rollup := func(by string) func([]report.PriceLine, float64) report.Report {
+		return func(l []report.PriceLine, threshold float64) report.Report {
+			return report.DiscountRollupReport(l, by, threshold)
+		}
+	}
+
+	return &Menu{
+		Title: "Price Book & Discounts",
+		Items: []MenuItem{
+			{Label: "Line Compliance", Action: lines(report.PriceLinesReport)},
+			{Label: "Discount by Product", Action: lines(rollup(report.ByProduct))},
+			{Label: "Discount by Account", Action: lines(rollup(report.ByAccount))},
+			{Label: "Discount by Fiscal Period", Action: lines(rollup(report.ByPeriod))},
+			{Label: "Back"},
+		},
+	}
+}
+
+// promptThreshold asks for a discount threshold in percent before running a
+// report. A blank answer uses report.DefaultDiscountThreshold.
+func promptThreshold(run func(threshold float64) tea.Cmd) func() tea.Cmd {
+	return openScreen(func() Screen {
+		body := fmt.Sprintf("Flag discounts over this percentage (blank for %.0f%%):", report.DefaultDiscountThreshold*100)
+		return NewPromptScreen("Discount Threshold", body, fmt.Sprintf("%.0f", report.DefaultDiscountThreshold*100),
+			func(value string) error {
+				_, err := parseThreshold(value)
+				return err
+			},
+			func(value string) tea.Cmd {
+				threshold, _ := parseThreshold(value)
+				return run(threshold)
+			})
+	})
+}
+
+// parseThreshold reads a percentage such as "15" or "15%" as a ratio.
+func parseThreshold(value string) (float64, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "%")
+	if value == "" {
+		return report.DefaultDiscountThreshold, nil
+	}
+
+	pct, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || pct < 0 || pct > 100 {
+		return 0, fmt.Errorf("enter a percentage between 0 and 100")
+	}
+	return pct / 100, nil
+}
+
+func loadRevenue(m *Model) *Menu {
+	schedule := &admin.RevenueSchedule{Pool: m.pool}
+
+	rollup := func(by string) func() tea.Cmd {
+		return openScreen(func() Screen {
+			body := fmt.Sprintf("Show %ss containing (blank for all):", strings.ToLower(by))
+			return NewPromptScreen("Revenue by "+by, body, strings.ToLower(by), nil, func(filter string) tea.Cmd {
+				filter = strings.TrimSpace(filter)
+				return runReport(func(ctx context.Context) (report.Report, error) {
+					customer, product := filter, ""
+					if by == report.ByProduct {
+						customer, product = "", filter
+					}
+					rows, err := report.LoadRevenueSchedule(ctx, m.db, customer, product)
+					if err != nil {
+						return report.Report{}, err
+					}
+					return report.RevenueRollupReport(rows, by), nil
+				})()
+			})
+		})
+	}
+
+	return &Menu{
+		Title: "Revenue Schedule",
+		Items: []MenuItem{
+			{Label: "Revenue by Period", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				rows, err := report.LoadRevenueSchedule(ctx, m.db, "", "")
+				if err != nil {
+					return report.Report{}, err
+				}
+				return report.RevenueByPeriodReport(rows, report.DefaultTolerance), nil
+			})},
+			{Label: "Revenue by Customer", Action: rollup(report.ByCustomer)},
+			{Label: "Revenue by Product", Action: rollup(report.ByProduct)},
+			{Label: "Regenerate schedule", Writes: true, Action: schedule.Regenerate},
+			{Label: "Back"},
+		},
+	}
+}
+
+func loadMetrics(m *Model) *Menu {
+	recompute := &admin.Metrics{Pool: m.pool}
+
+	return &Menu{
+		Title: "ARR / MRR Metrics",
+		Items: []MenuItem{
+			{Label: "Monthly Metrics", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				return report.LoadMetrics(ctx, m.db)
+			})},
+			{Label: "Recompute metrics", Writes: true, Action: recompute.Recompute},
+			{Label: "Back"},
+		},
+	}
+}
+
+func loadBilling(m *Model) *Menu {
+	billing := func(build func([]report.BillingLine, report.Tolerance, time.Time) report.Report) func() tea.Cmd {
+		return runReport(func(ctx context.Context) (report.Report, error) {
+			asOf := time.Now()
+			lines, err := report.LoadBilling(ctx, m.db, asOf, config.LoadTaxLines())
+			if err != nil {
+				return report.Report{}, err
+			}
+			return build(lines, report.DefaultTolerance, asOf), nil
+		})
+	}
+
+	return &Menu{
+		Title: "Billed vs Contracted",
+		Items: []MenuItem{
+			{Label: "By SO Line", Action: billing(report.BillingLinesReport)},
+			{Label: "By Customer", Action: billing(report.BillingByCustomerReport)},
+			{Label: "Back"},
+		},
+	}
+}
+
+func loadSalesTax(m *Model) *Menu {
+	return &Menu{
+		Title: "Sales Tax",
+		Items: []MenuItem{
+			{Label: "By Jurisdiction", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				return report.LoadTaxByJurisdiction(ctx, m.db)
+			})},
+			{Label: "Nexus Exposure", Action: runReport(func(ctx context.Context) (report.Report, error) {
+				nexus, err := config.LoadNexus(config.NexusPath())
+				if err != nil {
+					return report.Report{}, err
+				}
+				return report.LoadNexus(ctx, m.db, nexus)
+			})},
+			{Label: "Back"},
+		},
+	}
+}
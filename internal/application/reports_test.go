@@ -0,0 +1,81 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+/* ========================================
+	Report Prompt Validation Tests
+======================================== */
+
+func TestParseThreshold(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+		wantErr  bool
+	}{
+		{"", 0.20, false},
+		{"15", 0.15, false},
+		{" 12.5% ", 0.125, false},
+		{"0", 0, false},
+		{"101", 0, true},
+		{"-5", 0, true},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseThreshold(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseThreshold(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("parseThreshold(%q) = %v, expected %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateAccountID(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{"", false},
+		{"001A0000006Vm9r", false},
+		{"001A0000006Vm9rIAC", false},
+		{"001A", true},
+	}
+
+	for _, tt := range tests {
+		if err := validateAccountID(tt.input); (err != nil) != tt.wantErr {
+			t.Errorf("validateAccountID(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRequired(t *testing.T) {
+	if err := required("NS internal ID")("  "); err == nil || err.Error() != "NS internal ID is required" {
+		t.Errorf("required() error = %v", err)
+	}
+	if err := required("NS internal ID")("123"); err != nil {
+		t.Errorf("required() error = %v, expected nil", err)
+	}
+}
+
+func TestParseAsOf(t *testing.T) {
+	got, err := parseAsOf(" 2025-03-31 ")
+	if err != nil || got.Format("2006-01-02") != "2025-03-31" {
+		t.Errorf("parseAsOf() = %v, %v", got, err)
+	}
+
+	today, err := parseAsOf("")
+	if err != nil || today.Format("2006-01-02") != time.Now().Format("2006-01-02") {
+		t.Errorf("parseAsOf(\"\") = %v, %v, expected today", today, err)
+	}
+
+	if _, err := parseAsOf("03/31/2025"); err == nil {
+		t.Error("parseAsOf(\"03/31/2025\") expected error")
+	}
+}
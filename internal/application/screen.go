@@ -0,0 +1,154 @@
+package application
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ----------------------------------------
+	SCREENS
+---------------------------------------- */
+
+// Screen is an interactive view shown in place of the menu, for flows that
+// need more than a list of items (prompts, tables, forms).
+//
+// Update receives every message the model does not handle itself. Returning a
+// nil Screen closes it and returns to the menu; a command returned while
+// closing is treated like a menu action (spinner, errors shown under the
+// current menu). Commands returned while the screen stays open run as-is,
+// and their messages come back to the screen.
+type Screen interface {
+	Update(msg tea.Msg) (Screen, tea.Cmd)
+	View() string
+}
+
+// ScreenMsg opens a screen over the current menu.
+type ScreenMsg struct{ Screen Screen }
+
+/* ----------------------------------------
+	CONFIRM SCREEN
+---------------------------------------- */
+
+// ConfirmScreen asks the user to type a phrase before running a destructive action.
+type ConfirmScreen struct {
+	title     string
+	body      string
+	phrase    string
+	onConfirm func() tea.Cmd
+	input     textinput.Model
+	err       string
+}
+
+// NewConfirmScreen returns a screen that runs onConfirm once phrase is typed exactly.
+func NewConfirmScreen(title, body, phrase string, onConfirm func() tea.Cmd) *ConfirmScreen {
+	input := textinput.New()
+	input.Placeholder = phrase
+	input.CharLimit = 64
+	input.Focus()
+
+	return &ConfirmScreen{
+		title:     title,
+		body:      body,
+		phrase:    phrase,
+		onConfirm: onConfirm,
+		input:     input,
+	}
+}
+
+func (c *ConfirmScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyEsc:
+			return nil, nil
+		case tea.KeyEnter:
+			if c.input.Value() != c.phrase {
+				c.err = fmt.Sprintf("Type %q exactly to confirm.", c.phrase)
+				return c, nil
+			}
+			return nil, c.onConfirm()
+		}
+	}
+
+	var cmd tea.Cmd
+	c.input, cmd = c.input.Update(msg)
+	c.err = ""
+	return c, cmd
+}
+
+func (c *ConfirmScreen) View() string {
+	s := fmt.Sprintf("%s\n\n%s\n\nType %q to confirm, Esc to cancel.\n\n%s\n", c.title, c.body, c.phrase, c.input.View())
+	if c.err != "" {
+		s += "\n" + c.err + "\n"
+	}
+	return s
+}
+
+/* ----------------------------------------
+	PROMPT SCREEN
+---------------------------------------- */
+
+// PromptScreen asks for a single value, validates it, and hands it to onSubmit.
+type PromptScreen struct {
+	title    string
+	body     string
+	validate func(string) error
+	onSubmit func(string) tea.Cmd
+	input    textinput.Model
+	err      string
+}
+
+// NewPromptScreen returns a screen that reads one value. validate may be nil.
+func NewPromptScreen(title, body, placeholder string, validate func(string) error, onSubmit func(string) tea.Cmd) *PromptScreen {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.CharLimit = 64
+	input.Focus()
+
+	return &PromptScreen{
+		title:    title,
+		body:     body,
+		validate: validate,
+		onSubmit: onSubmit,
+		input:    input,
+	}
+}
+
+func (p *PromptScreen) Update(msg tea.Msg) (Screen, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.Type {
+		case tea.KeyEsc:
+			return nil, nil
+		case tea.KeyEnter:
+			value := p.input.Value()
+			if p.validate != nil {
+				if err := p.validate(value); err != nil {
+					p.err = err.Error()
+					return p, nil
+				}
+			}
+			return nil, p.onSubmit(value)
+		}
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.err = ""
+	return p, cmd
+}
+
+func (p *PromptScreen) View() string {
+	s := fmt.Sprintf("%s\n\n%s\n\n%s\n\nEnter to continue, Esc to cancel.\n", p.title, p.body, p.input.View())
+	if p.err != "" {
+		s += "\n" + p.err + "\n"
+	}
+	return s
+}
+
+// openScreen is a menu action that opens a screen.
+func openScreen(build func() Screen) func() tea.Cmd {
+	return func() tea.Cmd {
+		return func() tea.Msg { return ScreenMsg{Screen: build()} }
+	}
+}
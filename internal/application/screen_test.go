@@ -0,0 +1,151 @@
+package application
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/handler"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+/* ========================================
+	ConfirmScreen Tests
+======================================== */
+
+func typeString(s Screen, text string) Screen {
+	for _, r := range text {
+		s, _ = s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return s
+}
+
+func TestConfirmScreen_WrongPhrase(t *testing.T) {
+	confirmed := false
+	var s Screen = NewConfirmScreen("Reset", "body", "ns_customers", func() tea.Cmd {
+		confirmed = true
+		return nil
+	})
+
+	s = typeString(s, "ns_customer")
+	next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if next == nil {
+		t.Fatal("screen should stay open after a wrong phrase")
+	}
+	if cmd != nil || confirmed {
+		t.Error("action should not run after a wrong phrase")
+	}
+	if !strings.Contains(next.View(), `Type "ns_customers" exactly`) {
+		t.Errorf("View() should explain the error:\n%s", next.View())
+	}
+}
+
+func TestConfirmScreen_Confirm(t *testing.T) {
+	var s Screen = NewConfirmScreen("Reset", "body", "NS", func() tea.Cmd {
+		return func() tea.Msg { return handler.DoneMsg("done") }
+	})
+
+	s = typeString(s, "NS")
+	next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if next != nil {
+		t.Error("screen should close after confirmation")
+	}
+	if cmd == nil {
+		t.Fatal("confirmation should return the action's command")
+	}
+	if msg := cmd(); msg != handler.DoneMsg("done") {
+		t.Errorf("cmd() = %v, expected DoneMsg", msg)
+	}
+}
+
+func TestConfirmScreen_Cancel(t *testing.T) {
+	var s Screen = NewConfirmScreen("Reset", "body", "NS", func() tea.Cmd {
+		t.Error("action should not run on cancel")
+		return nil
+	})
+
+	next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if next != nil || cmd != nil {
+		t.Error("Esc should close the screen without a command")
+	}
+}
+
+/* ========================================
+	Model Screen Routing Tests
+======================================== */
+
+func TestUpdate_ScreenRouting(t *testing.T) {
+	menu := &Menu{Title: "Reset DBs", Items: []MenuItem{{Label: "Back"}}}
+	m := &Model{currentMenu: menu}
+
+	screen := NewConfirmScreen("Reset", "body", "q", func() tea.Cmd {
+		return func() tea.Msg { return handler.DoneMsg("done") }
+	})
+	m.Update(ScreenMsg{Screen: screen})
+	if m.screen == nil {
+		t.Fatal("ScreenMsg should open the screen")
+	}
+
+	// "q" is typed into the screen rather than quitting
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if m.screen == nil {
+		t.Fatal("typing should keep the screen open")
+	}
+	if cmd != nil {
+		if _, quit := cmd().(tea.QuitMsg); quit {
+			t.Fatal("typing q in a screen should not quit")
+		}
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.screen != nil {
+		t.Error("confirming should close the screen")
+	}
+	if !m.loading {
+		t.Error("closing with a command should show the spinner")
+	}
+}
+
+/* ========================================
+	PromptScreen Tests
+======================================== */
+
+func TestPromptScreen_Invalid(t *testing.T) {
+	var s Screen = NewPromptScreen("Archive", "body", "YYYY-MM", validatePeriod, func(string) tea.Cmd {
+		t.Error("onSubmit should not run for an invalid value")
+		return nil
+	})
+
+	s = typeString(s, "2024-13")
+	next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if next == nil || cmd != nil {
+		t.Fatal("screen should stay open after an invalid value")
+	}
+	if !strings.Contains(next.View(), "use YYYY-MM") {
+		t.Errorf("View() should show the validation error:\n%s", next.View())
+	}
+}
+
+func TestPromptScreen_Submit(t *testing.T) {
+	var got string
+	var s Screen = NewPromptScreen("Archive", "body", "YYYY-MM", validatePeriod, func(value string) tea.Cmd {
+		got = value
+		return func() tea.Msg { return handler.DoneMsg(value) }
+	})
+
+	s = typeString(s, "2024-03")
+	next, cmd := s.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if next != nil {
+		t.Error("screen should close after a valid value")
+	}
+	if cmd == nil {
+		t.Fatal("submit should return onSubmit's command")
+	}
+	if got != "2024-03" {
+		t.Errorf("onSubmit value = %q, expected %q", got, "2024-03")
+	}
+}
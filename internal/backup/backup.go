@@ -0,0 +1,329 @@
+// Package backup exports tables to CSV before destructive actions and
+// restores them. Each backup is a directory holding one CSV per table
+// (COPY format with a header row) and a manifest.json describing it.
+package backup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Timeout is the maximum duration for creating or restoring a backup.
+var Timeout = 10 * time.Minute
+
+// Dir is where backups are written, relative to the working directory.
+var Dir = "accounting/backups"
+
+// ManifestFile is the name of the manifest inside each backup directory.
+const ManifestFile = "manifest.json"
+
+// LogTables are bookkeeping tables that are merged on restore rather than
+// replaced, so entries written after the backup are kept.
+var LogTables = map[string]bool{
+	"csv_uploads":     true,
+	"import_warnings": true,
+}
+
+// Manifest describes a backup.
+type Manifest struct {
+	Created time.Time   `json:"created"`
+	Reason  string      `json:"reason"`
+	Tables  []TableFile `json:"tables"`
+}
+
+// TableFile is one table's export within a backup.
+type TableFile struct {
+	Table string `json:"table"`
+	File  string `json:"file"`
+	Rows  int64  `json:"rows"`
+}
+
+// Rows returns the total number of rows in the backup.
+func (m Manifest) Rows() int64 {
+	var n int64
+	for _, t := range m.Tables {
+		n += t.Rows
+	}
+	return n
+}
+
+// Backup is a backup directory on disk with its manifest.
+type Backup struct {
+	Path     string
+	Manifest Manifest
+}
+
+// Name returns the backup's directory name.
+func (b Backup) Name() string {
+	return filepath.Base(b.Path)
+}
+
+/* ----------------------------------------
+	Create
+---------------------------------------- */
+
+// Create exports tables from one consistent snapshot into a new timestamped
+// directory under Dir. reason is recorded in the manifest and directory name.
+func Create(ctx context.Context, pool *pgxpool.Pool, reason string, tables []string) (Backup, error) {
+	now := time.Now()
+	path, err := newBackupDir(Dir, now, reason)
+	if err != nil {
+		return Backup{}, err
+	}
+
+	manifest := Manifest{Created: now, Reason: reason}
+
+	// Repeatable read gives every COPY the same snapshot
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return Backup{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, table := range dedupe(tables) {
+		file := table + ".csv"
+		rows, err := copyOut(ctx, tx, table, filepath.Join(path, file))
+		if err != nil {
+			return Backup{}, fmt.Errorf("backing up %s: %w", table, err)
+		}
+		manifest.Tables = append(manifest.Tables, TableFile{Table: table, File: file, Rows: rows})
+	}
+
+	if err := writeManifest(path, manifest); err != nil {
+		return Backup{}, err
+	}
+	return Backup{Path: path, Manifest: manifest}, nil
+}
+
+func copyOut(ctx context.Context, tx pgx.Tx, table, dest string) (int64, error) {
+	f, err := os.Create(dest)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	tag, err := tx.Conn().PgConn().CopyTo(ctx, w,
+		`COPY `+pgx.Identifier{table}.Sanitize()+` TO STDOUT WITH (FORMAT csv, HEADER true)`)
+	if err != nil {
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), f.Close()
+}
+
+var slugRegex = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// newBackupDir creates <root>/<YYYYMMDD-HHMMSS>-<reason>, adding a counter if
+// a backup with the same name already exists.
+func newBackupDir(root string, now time.Time, reason string) (string, error) {
+	slug := strings.Trim(slugRegex.ReplaceAllString(strings.ToLower(reason), "-"), "-")
+	base := now.Format("20060102-150405")
+	if slug != "" {
+		base += "-" + slug
+	}
+
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", fmt.Errorf("creating backup directory: %w", err)
+	}
+
+	name := base
+	for i := 2; ; i++ {
+		path := filepath.Join(root, name)
+		err := os.Mkdir(path, 0755)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("creating backup directory: %w", err)
+		}
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func writeManifest(path string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(path, ManifestFile), append(data, '\n'), 0644)
+}
+
+func dedupe(tables []string) []string {
+	seen := make(map[string]bool, len(tables))
+	out := make([]string, 0, len(tables))
+	for _, t := range tables {
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+/* ----------------------------------------
+	List
+---------------------------------------- */
+
+// List returns the backups under Dir, newest first. Directories without a
+// readable manifest are skipped. A missing Dir means no backups.
+func List() ([]Backup, error) {
+	return list(Dir)
+}
+
+func list(root string) ([]Backup, error) {
+	entries, err := os.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		path := filepath.Join(root, e.Name())
+		m, err := ReadManifest(path)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: path, Manifest: m})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Manifest.Created.After(backups[j].Manifest.Created)
+	})
+	return backups, nil
+}
+
+// ReadManifest reads the manifest of the backup at path.
+func ReadManifest(path string) (Manifest, error) {
+	var m Manifest
+	data, err := os.ReadFile(filepath.Join(path, ManifestFile))
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("reading %s: %w", ManifestFile, err)
+	}
+	return m, nil
+}
+
+/* ----------------------------------------
+	Restore
+---------------------------------------- */
+
+// Restore reloads a backup in one transaction. Data tables are truncated and
+// replaced; LogTables are merged, keeping rows that already exist.
+func Restore(ctx context.Context, pool *pgxpool.Pool, b Backup) error {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var replace []string
+	for _, t := range b.Manifest.Tables {
+		if !LogTables[t.Table] {
+			replace = append(replace, pgx.Identifier{t.Table}.Sanitize())
+		}
+	}
+	if len(replace) > 0 {
+		if _, err := tx.Exec(ctx, `TRUNCATE `+strings.Join(replace, ", ")); err != nil {
+			return fmt.Errorf("clearing tables: %w", err)
+		}
+	}
+
+	for _, t := range b.Manifest.Tables {
+		if err := restoreTable(ctx, tx, t, filepath.Join(b.Path, t.File)); err != nil {
+			return fmt.Errorf("restoring %s: %w", t.Table, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func restoreTable(ctx context.Context, tx pgx.Tx, t TableFile, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	cols, err := readHeader(r)
+	if err != nil {
+		return err
+	}
+	if len(cols) == 0 {
+		return nil // Empty export
+	}
+	colList := strings.Join(cols, ", ")
+
+	table := pgx.Identifier{t.Table}.Sanitize()
+	into := table
+	if LogTables[t.Table] {
+		into = pgx.Identifier{"restore_" + t.Table}.Sanitize()
+		if _, err := tx.Exec(ctx, `CREATE TEMP TABLE `+into+` (LIKE `+table+`) ON COMMIT DROP`); err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Conn().PgConn().CopyFrom(ctx, r, `COPY `+into+` (`+colList+`) FROM STDIN WITH (FORMAT csv)`)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() != t.Rows {
+		return fmt.Errorf("loaded %d rows, manifest lists %d", tag.RowsAffected(), t.Rows)
+	}
+
+	if LogTables[t.Table] {
+		if _, err := tx.Exec(ctx, `INSERT INTO `+table+` (`+colList+`) SELECT `+colList+` FROM `+into+` ON CONFLICT DO NOTHING`); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readHeader consumes the CSV header line and returns its columns as
+// sanitized identifiers. COPY headers are plain column names, quoted only
+// when they contain special characters.
+func readHeader(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return nil, nil
+	}
+
+	var cols []string
+	for _, name := range strings.Split(line, ",") {
+		name = strings.Trim(name, `"`)
+		cols = append(cols, pgx.Identifier{name}.Sanitize())
+	}
+	return cols, nil
+}
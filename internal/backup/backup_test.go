@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+/* ========================================
+	newBackupDir Tests
+======================================== */
+
+func TestNewBackupDir(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "backups")
+	now := time.Date(2025, 3, 31, 17, 4, 5, 0, time.UTC)
+
+	first, err := newBackupDir(root, now, "reset ns_customers")
+	if err != nil {
+		t.Fatalf("newBackupDir() error = %v", err)
+	}
+	if filepath.Base(first) != "20250331-170405-reset-ns_customers" {
+		t.Errorf("newBackupDir() = %q", filepath.Base(first))
+	}
+
+	second, err := newBackupDir(root, now, "reset ns_customers")
+	if err != nil {
+		t.Fatalf("newBackupDir() second error = %v", err)
+	}
+	if filepath.Base(second) != "20250331-170405-reset-ns_customers-2" {
+		t.Errorf("newBackupDir() on collision = %q", filepath.Base(second))
+	}
+
+	bare, _ := newBackupDir(root, now, "../..")
+	if filepath.Dir(bare) != root || filepath.Base(bare) != "20250331-170405" {
+		t.Errorf("newBackupDir() with unsafe reason = %q", bare)
+	}
+}
+
+/* ========================================
+	List Tests
+======================================== */
+
+func TestList(t *testing.T) {
+	root := t.TempDir()
+	older := Manifest{Created: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Reason: "older",
+		Tables: []TableFile{{Table: "ns_customers", File: "ns_customers.csv", Rows: 2}}}
+	newer := Manifest{Created: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Reason: "newer",
+		Tables: []TableFile{{Table: "a", Rows: 3}, {Table: "b", Rows: 4}}}
+
+	for name, m := range map[string]Manifest{"a": older, "b": newer} {
+		if err := os.Mkdir(filepath.Join(root, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeManifest(filepath.Join(root, name), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// No manifest: not a backup
+	if err := os.Mkdir(filepath.Join(root, "stray"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := list(root)
+	if err != nil {
+		t.Fatalf("list() error = %v", err)
+	}
+	if len(backups) != 2 {
+		t.Fatalf("list() returned %d backups, expected 2", len(backups))
+	}
+	if backups[0].Manifest.Reason != "newer" || backups[1].Manifest.Reason != "older" {
+		t.Errorf("list() order = %q, %q; expected newest first", backups[0].Manifest.Reason, backups[1].Manifest.Reason)
+	}
+	if backups[0].Manifest.Rows() != 7 {
+		t.Errorf("Rows() = %d, expected 7", backups[0].Manifest.Rows())
+	}
+	if !reflect.DeepEqual(backups[1].Manifest, older) {
+		t.Errorf("manifest round trip = %+v, expected %+v", backups[1].Manifest, older)
+	}
+}
+
+func TestList_MissingDir(t *testing.T) {
+	backups, err := list(filepath.Join(t.TempDir(), "none"))
+	if err != nil || backups != nil {
+		t.Errorf("list(missing) = (%v, %v), expected (nil, nil)", backups, err)
+	}
+}
+
+/* ========================================
+	readHeader Tests
+======================================== */
+
+func TestReadHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		rest     string
+	}{
+		{"plain", "id,name,type\n1,a,b\n", []string{`"id"`, `"name"`, `"type"`}, "1,a,b\n"},
+		{"quoted", "\"id\",\"Customer Name\"\r\n", []string{`"id"`, `"Customer Name"`}, ""},
+		{"no trailing newline", "id", []string{`"id"`}, ""},
+		{"empty", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := bufio.NewReader(strings.NewReader(tt.input))
+			cols, err := readHeader(r)
+			if err != nil {
+				t.Fatalf("readHeader() error = %v", err)
+			}
+			if !reflect.DeepEqual(cols, tt.expected) {
+				t.Errorf("readHeader() = %v, expected %v", cols, tt.expected)
+			}
+			rest := new(strings.Builder)
+			if _, err := r.WriteTo(rest); err != nil {
+				t.Fatal(err)
+			}
+			if rest.String() != tt.rest {
+				t.Errorf("remaining input = %q, expected %q", rest.String(), tt.rest)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	got := dedupe([]string{"a", "b", "a", "csv_uploads", "b"})
+	if want := []string{"a", "b", "csv_uploads"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("dedupe() = %v, expected %v", got, want)
+	}
+}
@@ -0,0 +1,428 @@
+// Package browse pages through a table's rows for the table browser.
+//
+// Pages are fetched with keyset pagination: each page starts after the sort
+// value and key of the previous page's last row, so reading page 10,000 of a
+// large table costs the same as reading page 1. Every value is read as text.
+package browse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Timeout is the maximum duration for loading columns or a page.
+const Timeout = 30 * time.Second
+
+// PageSize is the number of rows fetched per page.
+const PageSize = 100
+
+// IDColumn is the key column of tables that have one. Tables without it are
+// keyed by their single-column primary key instead.
+const IDColumn = "id"
+
+// Column is a table column and its SQL type.
+type Column struct {
+	Name string
+	Type string // information_schema data_type, e.g. "numeric" or "date"
+}
+
+// castable reports whether text values can be cast back to the column's
+// type. Arrays and user-defined types are sorted as text instead.
+func (c Column) castable() bool {
+	return c.Type != "" && c.Type != "ARRAY" && c.Type != "USER-DEFINED"
+}
+
+// expr is the column in SQL.
+func (c Column) expr() string { return "t." + pgx.Identifier{c.Name}.Sanitize() }
+
+// sortExpr is the expression rows are ordered by.
+func (c Column) sortExpr() string {
+	if c.castable() {
+		return c.expr()
+	}
+	return c.expr() + "::text"
+}
+
+// param casts a text parameter to the column's sort type.
+func (c Column) param(n int) string {
+	if c.castable() {
+		return fmt.Sprintf("CAST($%d::text AS %s)", n, c.Type)
+	}
+	return fmt.Sprintf("$%d::text", n)
+}
+
+// Filter limits rows to those whose column matches Value, case-insensitively.
+// Exact filters match the whole value; a blank exact value matches NULL or
+// empty. Other filters match values containing Value.
+type Filter struct {
+	Column string
+	Value  string
+	Exact  bool
+}
+
+// String renders the filter the way ParseFilter reads it.
+func (f Filter) String() string {
+	if f.Exact {
+		return f.Column + "=" + f.Value
+	}
+	return f.Column + "~" + f.Value
+}
+
+// Query describes which rows of a table to show and in what order.
+type Query struct {
+	Table   string
+	Columns []Column
+	Key     string // unique column breaking ties; IDColumn when blank
+	Sort    string // column name; blank sorts by the key
+	Desc    bool
+	Search  string  // matched against every column
+	Filter  *Filter // optional
+	Limit   int     // rows per page; PageSize when zero
+}
+
+// Cursor is the sort value and key of the row a page starts after.
+type Cursor struct {
+	Value pgtype.Text
+	Key   string
+}
+
+// Record is one row, a text value per column. NULLs are invalid values.
+type Record []pgtype.Text
+
+// Page is one page of rows.
+type Page struct {
+	Rows []Record
+	More bool    // there are rows after this page
+	Next *Cursor // start of the next page, when More
+}
+
+// PageSize is the number of rows per page.
+func (q Query) PageSize() int {
+	if q.Limit > 0 {
+		return q.Limit
+	}
+	return PageSize
+}
+
+// KeyColumn is the name of the column that breaks ties between rows.
+func (q Query) KeyColumn() string {
+	if q.Key != "" {
+		return q.Key
+	}
+	return IDColumn
+}
+
+// column returns the named column.
+func (q Query) column(name string) (Column, int, error) {
+	for i, c := range q.Columns {
+		if c.Name == name {
+			return c, i, nil
+		}
+	}
+	return Column{}, -1, fmt.Errorf("%s has no column %q", q.Table, name)
+}
+
+// sortColumn returns the column rows are ordered by.
+func (q Query) sortColumn() (Column, int, error) {
+	if q.Sort == "" {
+		return q.column(q.KeyColumn())
+	}
+	return q.column(q.Sort)
+}
+
+// escapeLike escapes the ILIKE wildcards in s so it matches literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// build returns the SQL and arguments for the page starting after after, or
+// the first page when after is nil. One extra row is fetched to tell whether
+// another page follows.
+func (q Query) build(after *Cursor) (string, []any, error) {
+	if len(q.Columns) == 0 {
+		return "", nil, fmt.Errorf("%s has no columns", q.Table)
+	}
+	key, _, err := q.column(q.KeyColumn())
+	if err != nil {
+		return "", nil, err
+	}
+	sort, _, err := q.sortColumn()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var args []any
+	arg := func(v any) int {
+		args = append(args, v)
+		return len(args)
+	}
+
+	var where []string
+	if q.Search != "" {
+		texts := make([]string, len(q.Columns))
+		for i, c := range q.Columns {
+			texts[i] = c.expr() + "::text"
+		}
+		where = append(where, fmt.Sprintf("concat_ws(' ', %s) ILIKE $%d",
+			strings.Join(texts, ", "), arg("%"+escapeLike(q.Search)+"%")))
+	}
+	if f := q.Filter; f != nil {
+		c, _, err := q.column(f.Column)
+		if err != nil {
+			return "", nil, err
+		}
+		switch {
+		case f.Exact && f.Value == "":
+			where = append(where, fmt.Sprintf("(%[1]s IS NULL OR %[1]s::text = '')", c.expr()))
+		case f.Exact:
+			where = append(where, fmt.Sprintf("%s::text ILIKE $%d", c.expr(), arg(escapeLike(f.Value))))
+		default:
+			where = append(where, fmt.Sprintf("%s::text ILIKE $%d", c.expr(), arg("%"+escapeLike(f.Value)+"%")))
+		}
+	}
+
+	cmp, dir, nulls := ">", "ASC", "NULLS LAST"
+	if q.Desc {
+		cmp, dir, nulls = "<", "DESC", "NULLS FIRST"
+	}
+	order := fmt.Sprintf("%s %s", key.expr(), dir)
+
+	if sort.Name != key.Name {
+		order = fmt.Sprintf("%s %s %s, %s", sort.sortExpr(), dir, nulls, order)
+	}
+
+	if after != nil {
+		keyCmp := fmt.Sprintf("%s %s %s", key.expr(), cmp, key.param(arg(after.Key)))
+		s := sort.sortExpr()
+		switch {
+		case sort.Name == key.Name:
+			where = append(where, keyCmp)
+		case !after.Value.Valid && !q.Desc:
+			// NULLs sort last: only NULLs with a later key remain.
+			where = append(where, fmt.Sprintf("(%s IS NULL AND %s)", s, keyCmp))
+		case !after.Value.Valid:
+			// NULLs sort first: later NULLs, then every value.
+			where = append(where, fmt.Sprintf("(%s IS NOT NULL OR %s)", s, keyCmp))
+		default:
+			p := sort.param(arg(after.Value.String))
+			cond := fmt.Sprintf("(%[1]s %[2]s %[3]s OR (%[1]s = %[3]s AND %[4]s)", s, cmp, p, keyCmp)
+			if !q.Desc {
+				cond += fmt.Sprintf(" OR %s IS NULL", s)
+			}
+			where = append(where, cond+")")
+		}
+	}
+
+	cols := make([]string, len(q.Columns))
+	for i, c := range q.Columns {
+		cols[i] = c.expr() + "::text"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "SELECT %s FROM %s t", strings.Join(cols, ", "), pgx.Identifier{q.Table}.Sanitize())
+	if len(where) > 0 {
+		b.WriteString(" WHERE " + strings.Join(where, " AND "))
+	}
+	fmt.Fprintf(&b, " ORDER BY %s LIMIT %d", order, q.PageSize()+1)
+	return b.String(), args, nil
+}
+
+// cursorAfter returns the cursor for the page following row.
+func (q Query) cursorAfter(row Record) (*Cursor, error) {
+	_, sortIdx, err := q.sortColumn()
+	if err != nil {
+		return nil, err
+	}
+	_, keyIdx, err := q.column(q.KeyColumn())
+	if err != nil {
+		return nil, err
+	}
+	return &Cursor{Value: row[sortIdx], Key: row[keyIdx].String}, nil
+}
+
+/* ----------------------------------------
+	PARSING
+---------------------------------------- */
+
+// ParseSort reads a sort column, optionally prefixed with "-" or followed by
+// "desc" for descending order. Blank sorts by the key.
+func ParseSort(cols []Column, s string) (string, bool, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	desc := false
+	if rest, ok := strings.CutPrefix(s, "-"); ok {
+		s, desc = strings.TrimSpace(rest), true
+	}
+	if rest, ok := strings.CutSuffix(s, " desc"); ok {
+		s, desc = strings.TrimSpace(rest), true
+	} else if rest, ok := strings.CutSuffix(s, " asc"); ok {
+		s = strings.TrimSpace(rest)
+	}
+	if s == "" {
+		return "", desc, nil
+	}
+	if !hasColumn(cols, s) {
+		return "", false, fmt.Errorf("no column %q", s)
+	}
+	return s, desc, nil
+}
+
+// ParseFilter reads "column=value" (exact) or "column~value" (contains).
+// Blank clears the filter.
+func ParseFilter(cols []Column, s string) (*Filter, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	i := strings.IndexAny(s, "=~")
+	if i < 0 {
+		return nil, fmt.Errorf("%q is not column=value or column~value", s)
+	}
+	f := &Filter{
+		Column: strings.ToLower(strings.TrimSpace(s[:i])),
+		Value:  strings.TrimSpace(s[i+1:]),
+		Exact:  s[i] == '=',
+	}
+	if !hasColumn(cols, f.Column) {
+		return nil, fmt.Errorf("no column %q", f.Column)
+	}
+	if !f.Exact && f.Value == "" {
+		return nil, fmt.Errorf("%s~ needs a value", f.Column)
+	}
+	return f, nil
+}
+
+func hasColumn(cols []Column, name string) bool {
+	for _, c := range cols {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+/* ----------------------------------------
+	DATABASE
+---------------------------------------- */
+
+// Browser reads tables for the table browser. Every read runs in a
+// read-only transaction.
+type Browser struct {
+	Pool *pgxpool.Pool
+}
+
+// Tables returns the tables in the current schema, by name.
+func (b *Browser) Tables(ctx context.Context) ([]string, error) {
+	rows, err := b.Pool.Query(ctx, `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'
+		ORDER BY table_name`)
+	if err != nil {
+		return nil, fmt.Errorf("listing tables: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// Open returns the query for table's first page: its columns in table order,
+// keyed by id or, without one, by its primary key.
+func (b *Browser) Open(ctx context.Context, table string) (Query, error) {
+	rows, err := b.Pool.Query(ctx, `
+		SELECT column_name, data_type
+		FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1
+		ORDER BY ordinal_position`, table)
+	if err != nil {
+		return Query{}, fmt.Errorf("reading columns for %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var cols []Column
+	for rows.Next() {
+		var c Column
+		if err := rows.Scan(&c.Name, &c.Type); err != nil {
+			return Query{}, err
+		}
+		cols = append(cols, c)
+	}
+	if err := rows.Err(); err != nil {
+		return Query{}, err
+	}
+	if len(cols) == 0 {
+		return Query{}, fmt.Errorf("table %s not found", table)
+	}
+
+	q := Query{Table: table, Columns: cols, Key: IDColumn}
+	if hasColumn(cols, IDColumn) {
+		return q, nil
+	}
+
+	keyRows, err := b.Pool.Query(ctx, `
+		SELECT kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+		WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type = 'PRIMARY KEY'`, table)
+	if err != nil {
+		return Query{}, fmt.Errorf("reading primary key for %s: %w", table, err)
+	}
+	key, err := pgx.CollectRows(keyRows, pgx.RowTo[string])
+	if err != nil {
+		return Query{}, fmt.Errorf("reading primary key for %s: %w", table, err)
+	}
+	if len(key) != 1 {
+		return Query{}, fmt.Errorf("%s has no %s column or single-column primary key to page by", table, IDColumn)
+	}
+	q.Key = key[0]
+	return q, nil
+}
+
+// Fetch returns the page of q starting after after, or the first page when
+// after is nil.
+func (b *Browser) Fetch(ctx context.Context, q Query, after *Cursor) (Page, error) {
+	sql, args, err := q.build(after)
+	if err != nil {
+		return Page{}, err
+	}
+
+	tx, err := b.Pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return Page{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return Page{}, fmt.Errorf("reading %s: %w", q.Table, err)
+	}
+	defer rows.Close()
+
+	var page Page
+	for rows.Next() {
+		row := make(Record, len(q.Columns))
+		dest := make([]any, len(row))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return Page{}, err
+		}
+		page.Rows = append(page.Rows, row)
+	}
+	if err := rows.Err(); err != nil {
+		return Page{}, err
+	}
+
+	if limit := q.PageSize(); len(page.Rows) > limit {
+		page.Rows, page.More = page.Rows[:limit], true
+		if page.Next, err = q.cursorAfter(page.Rows[limit-1]); err != nil {
+			return Page{}, err
+		}
+	}
+	return page, nil
+}
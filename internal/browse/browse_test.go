@@ -0,0 +1,287 @@
+package browse
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var testColumns = []Column{
+	{Name: "id", Type: "uuid"},
+	{Name: "name", Type: "text"},
+	{Name: "amount", Type: "numeric"},
+	{Name: "tags", Type: "ARRAY"},
+}
+
+func testQuery() Query {
+	return Query{Table: "ns_customers", Columns: testColumns, Limit: 50}
+}
+
+/* ========================================
+	Query Building Tests
+======================================== */
+
+func TestBuild_FirstPage(t *testing.T) {
+	sql, args, err := testQuery().build(nil)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	expected := `SELECT t."id"::text, t."name"::text, t."amount"::text, t."tags"::text FROM "ns_customers" t ORDER BY t."id" ASC LIMIT 51`
+	if sql != expected {
+		t.Errorf("build() =\n%s\nexpected\n%s", sql, expected)
+	}
+	if len(args) != 0 {
+		t.Errorf("args = %v, expected none", args)
+	}
+}
+
+func TestBuild_Keyset(t *testing.T) {
+	value := pgtype.Text{String: "12.50", Valid: true}
+	null := pgtype.Text{}
+
+	tests := []struct {
+		name    string
+		sort    string
+		desc    bool
+		after   Cursor
+		where   string
+		order   string
+		numArgs int
+	}{
+		{
+			name:    "id ascending",
+			after:   Cursor{Key: "a"},
+			where:   `t."id" > CAST($1::text AS uuid)`,
+			order:   `t."id" ASC`,
+			numArgs: 1,
+		},
+		{
+			name:    "id descending",
+			desc:    true,
+			after:   Cursor{Key: "a"},
+			where:   `t."id" < CAST($1::text AS uuid)`,
+			order:   `t."id" DESC`,
+			numArgs: 1,
+		},
+		{
+			name:    "value ascending",
+			sort:    "amount",
+			after:   Cursor{Value: value, Key: "a"},
+			where:   `(t."amount" > CAST($2::text AS numeric) OR (t."amount" = CAST($2::text AS numeric) AND t."id" > CAST($1::text AS uuid)) OR t."amount" IS NULL)`,
+			order:   `t."amount" ASC NULLS LAST, t."id" ASC`,
+			numArgs: 2,
+		},
+		{
+			name:    "value descending",
+			sort:    "amount",
+			desc:    true,
+			after:   Cursor{Value: value, Key: "a"},
+			where:   `(t."amount" < CAST($2::text AS numeric) OR (t."amount" = CAST($2::text AS numeric) AND t."id" < CAST($1::text AS uuid)))`,
+			order:   `t."amount" DESC NULLS FIRST, t."id" DESC`,
+			numArgs: 2,
+		},
+		{
+			name:    "null ascending",
+			sort:    "amount",
+			after:   Cursor{Value: null, Key: "a"},
+			where:   `(t."amount" IS NULL AND t."id" > CAST($1::text AS uuid))`,
+			numArgs: 1,
+		},
+		{
+			name:    "null descending",
+			sort:    "amount",
+			desc:    true,
+			after:   Cursor{Value: null, Key: "a"},
+			where:   `(t."amount" IS NOT NULL OR t."id" < CAST($1::text AS uuid))`,
+			numArgs: 1,
+		},
+		{
+			name:    "array sorts as text",
+			sort:    "tags",
+			after:   Cursor{Value: value, Key: "a"},
+			where:   `(t."tags"::text > $2::text OR`,
+			order:   `t."tags"::text ASC NULLS LAST`,
+			numArgs: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := testQuery()
+			q.Sort, q.Desc = tt.sort, tt.desc
+			after := tt.after
+			sql, args, err := q.build(&after)
+			if err != nil {
+				t.Fatalf("build() error = %v", err)
+			}
+			if !strings.Contains(sql, "WHERE "+tt.where) {
+				t.Errorf("build() = %s\nexpected WHERE %s", sql, tt.where)
+			}
+			if tt.order != "" && !strings.Contains(sql, "ORDER BY "+tt.order) {
+				t.Errorf("build() = %s\nexpected ORDER BY %s", sql, tt.order)
+			}
+			if len(args) != tt.numArgs {
+				t.Errorf("args = %v, expected %d", args, tt.numArgs)
+			}
+		})
+	}
+}
+
+func TestBuild_Filters(t *testing.T) {
+	q := testQuery()
+	q.Search = "50%_off"
+	q.Filter = &Filter{Column: "name", Value: "Acme", Exact: true}
+
+	sql, args, err := q.build(nil)
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !strings.Contains(sql, `WHERE concat_ws(' ', t."id"::text, t."name"::text, t."amount"::text, t."tags"::text) ILIKE $1 AND t."name"::text ILIKE $2 ORDER BY`) {
+		t.Errorf("build() = %s", sql)
+	}
+	if !reflect.DeepEqual(args, []any{`%50\%\_off%`, "Acme"}) {
+		t.Errorf("args = %#v", args)
+	}
+
+	q.Search = ""
+	q.Filter = &Filter{Column: "name", Exact: true}
+	sql, args, _ = q.build(nil)
+	if !strings.Contains(sql, `WHERE (t."name" IS NULL OR t."name"::text = '')`) || len(args) != 0 {
+		t.Errorf("blank exact filter: %s %v", sql, args)
+	}
+
+	q.Filter = &Filter{Column: "name", Value: "cme"}
+	_, args, _ = q.build(nil)
+	if !reflect.DeepEqual(args, []any{"%cme%"}) {
+		t.Errorf("contains filter args = %#v", args)
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	q := testQuery()
+	q.Sort = "missing"
+	if _, _, err := q.build(nil); err == nil {
+		t.Error("expected error for unknown sort column")
+	}
+
+	q = Query{Table: "t", Columns: []Column{{Name: "name", Type: "text"}}}
+	if _, _, err := q.build(nil); err == nil {
+		t.Error("expected error for a table without id")
+	}
+}
+
+func TestBuild_PrimaryKey(t *testing.T) {
+	q := Query{
+		Table:   "metrics_monthly",
+		Columns: []Column{{Name: "period", Type: "date"}, {Name: "mrr", Type: "numeric"}},
+		Key:     "period",
+		Sort:    "mrr",
+	}
+	sql, _, err := q.build(&Cursor{Value: pgtype.Text{String: "10", Valid: true}, Key: "2025-01-01"})
+	if err != nil {
+		t.Fatalf("build() error = %v", err)
+	}
+	if !strings.Contains(sql, `t."period" > CAST($1::text AS date)`) || !strings.Contains(sql, `ORDER BY t."mrr" ASC NULLS LAST, t."period" ASC`) {
+		t.Errorf("build() = %s, expected period to break ties", sql)
+	}
+
+	c, err := q.cursorAfter(Record{{String: "2025-02-01", Valid: true}, {String: "12", Valid: true}})
+	if err != nil {
+		t.Fatalf("cursorAfter() error = %v", err)
+	}
+	if c.Key != "2025-02-01" || c.Value.String != "12" {
+		t.Errorf("cursorAfter() = %+v, expected the period and mrr", c)
+	}
+}
+
+func TestCursorAfter(t *testing.T) {
+	q := testQuery()
+	q.Sort = "amount"
+	row := Record{
+		{String: "id-1", Valid: true},
+		{String: "Acme", Valid: true},
+		{},
+		{},
+	}
+
+	c, err := q.cursorAfter(row)
+	if err != nil {
+		t.Fatalf("cursorAfter() error = %v", err)
+	}
+	if c.Key != "id-1" || c.Value.Valid {
+		t.Errorf("cursorAfter() = %+v, expected id-1 and a NULL value", c)
+	}
+}
+
+/* ========================================
+	Parsing Tests
+======================================== */
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		input   string
+		column  string
+		desc    bool
+		wantErr bool
+	}{
+		{"", "", false, false},
+		{"-", "", true, false},
+		{"Amount", "amount", false, false},
+		{"-amount", "amount", true, false},
+		{"amount desc", "amount", true, false},
+		{"amount asc", "amount", false, false},
+		{"missing", "", false, true},
+	}
+
+	for _, tt := range tests {
+		column, desc, err := ParseSort(testColumns, tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSort(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if column != tt.column || desc != tt.desc {
+			t.Errorf("ParseSort(%q) = %q, %v, expected %q, %v", tt.input, column, desc, tt.column, tt.desc)
+		}
+	}
+}
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Filter
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"name = Acme Corp", &Filter{Column: "name", Value: "Acme Corp", Exact: true}, false},
+		{"Name~acme", &Filter{Column: "name", Value: "acme"}, false},
+		{"name=", &Filter{Column: "name", Exact: true}, false},
+		{"name~", nil, true},
+		{"acme", nil, true},
+		{"missing=1", nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFilter(testColumns, tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFilter(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseFilter(%q) = %+v, expected %+v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestFilter_String(t *testing.T) {
+	for _, input := range []string{"name=Acme", "name~cme"} {
+		f, err := ParseFilter(testColumns, input)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) error = %v", input, err)
+		}
+		if f.String() != input {
+			t.Errorf("String() = %q, expected %q", f.String(), input)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/schema"
+)
+
+// DefaultNexusPath is the nexus thresholds file read when NEXUS_THRESHOLDS is not set.
+const DefaultNexusPath = "nexus.json"
+
+// DefaultNexusWarn is the share of a threshold at which a state is approaching it.
+const DefaultNexusWarn = 0.8
+
+// NexusThreshold is one state's economic-nexus test: nexus is established
+// once sales or the transaction count in a calendar year reach the given
+// value. A zero value disables that test.
+type NexusThreshold struct {
+	State        string  `json:"state"`
+	Sales        float64 `json:"sales,omitempty"`
+	Transactions int     `json:"transactions,omitempty"`
+}
+
+// Nexus is the contents of the nexus thresholds file. Default applies to
+// every state without its own entry.
+type Nexus struct {
+	Warn       float64          `json:"warn,omitempty"`
+	Default    NexusThreshold   `json:"default"`
+	Thresholds []NexusThreshold `json:"thresholds"`
+}
+
+// DefaultNexus is used when there is no thresholds file: the common
+// $100,000 or 200 transactions test for every state. Check each state's
+// current rules before relying on it.
+var DefaultNexus = Nexus{
+	Warn:    DefaultNexusWarn,
+	Default: NexusThreshold{Sales: 100000, Transactions: 200},
+}
+
+// NexusPath returns the thresholds file location: NEXUS_THRESHOLDS, or DefaultNexusPath.
+func NexusPath() string {
+	if p := os.Getenv("NEXUS_THRESHOLDS"); p != "" {
+		return p
+	}
+	return DefaultNexusPath
+}
+
+// LoadNexus reads the thresholds file at path. A missing file yields DefaultNexus.
+func LoadNexus(path string) (Nexus, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return DefaultNexus, nil
+	}
+	if err != nil {
+		return Nexus{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return ParseNexus(data)
+}
+
+// ParseNexus decodes and validates a thresholds file. A missing warn level
+// defaults to DefaultNexusWarn.
+func ParseNexus(data []byte) (Nexus, error) {
+	var n Nexus
+	if err := json.Unmarshal(data, &n); err != nil {
+		return Nexus{}, fmt.Errorf("parsing nexus thresholds: %w", err)
+	}
+	if n.Warn == 0 {
+		n.Warn = DefaultNexusWarn
+	}
+	if err := n.Validate(); err != nil {
+		return Nexus{}, err
+	}
+	return n, nil
+}
+
+// Validate checks the warn level and that each threshold names a unique
+// state and is not negative.
+func (n Nexus) Validate() error {
+	if n.Warn <= 0 || n.Warn > 1 {
+		return fmt.Errorf("nexus: warn must be between 0 and 1, got %v", n.Warn)
+	}
+	if n.Default.Sales < 0 || n.Default.Transactions < 0 {
+		return errors.New("nexus: default threshold cannot be negative")
+	}
+
+	seen := make(map[string]bool)
+	for i, t := range n.Thresholds {
+		state := stateCode(t.State)
+		if state == "" {
+			return fmt.Errorf("nexus thresholds[%d]: state is required", i)
+		}
+		if seen[state] {
+			return fmt.Errorf("nexus: duplicate state %q", t.State)
+		}
+		seen[state] = true
+		if t.Sales < 0 || t.Transactions < 0 {
+			return fmt.Errorf("nexus %q: threshold cannot be negative", t.State)
+		}
+	}
+	return nil
+}
+
+// For returns the threshold for state, or the default. States match by
+// two-letter code, so "California" finds a "CA" threshold and vice versa.
+func (n Nexus) For(state string) NexusThreshold {
+	code := stateCode(state)
+	for _, t := range n.Thresholds {
+		if stateCode(t.State) == code {
+			return t
+		}
+	}
+	t := n.Default
+	t.State = state
+	return t
+}
+
+// stateCode returns state as an upper-case two-letter code where it names a
+// US state, or as written otherwise.
+func stateCode(state string) string {
+	return strings.ToUpper(schema.NormalizeUsState(state))
+}
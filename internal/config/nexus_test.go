@@ -0,0 +1,68 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/* ========================================
+	ParseNexus Tests
+======================================== */
+
+func TestParseNexus(t *testing.T) {
+	n, err := ParseNexus([]byte(`{
+		"default": {"sales": 100000, "transactions": 200},
+		"thresholds": [{"state": "CA", "sales": 500000}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseNexus() error = %v", err)
+	}
+
+	if n.Warn != DefaultNexusWarn {
+		t.Errorf("Warn = %v, expected default %v", n.Warn, DefaultNexusWarn)
+	}
+	if ca := n.For("ca"); ca.Sales != 500000 || ca.Transactions != 0 {
+		t.Errorf("For(ca) = %+v, expected the CA entry", ca)
+	}
+	if ca := n.For("California"); ca.Sales != 500000 {
+		t.Errorf("For(California) = %+v, expected the CA entry", ca)
+	}
+	if wa := n.For("WA"); wa.State != "WA" || wa.Sales != 100000 || wa.Transactions != 200 {
+		t.Errorf("For(WA) = %+v, expected the default threshold", wa)
+	}
+}
+
+func TestParseNexus_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"bad json", `{`, "parsing nexus thresholds"},
+		{"warn over 1", `{"warn": 1.5}`, "warn must be between 0 and 1"},
+		{"no state", `{"thresholds": [{"sales": 1}]}`, "state is required"},
+		{"duplicate", `{"thresholds": [{"state": "CA"}, {"state": "ca"}]}`, "duplicate state"},
+		{"duplicate by name", `{"thresholds": [{"state": "CA"}, {"state": "California"}]}`, "duplicate state"},
+		{"negative", `{"thresholds": [{"state": "CA", "sales": -1}]}`, "cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNexus([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseNexus() error = %v, expected %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadNexus_MissingFileUsesDefault(t *testing.T) {
+	n, err := LoadNexus(filepath.Join(t.TempDir(), "nexus.json"))
+	if err != nil {
+		t.Fatalf("LoadNexus() error = %v", err)
+	}
+	if n.Default != DefaultNexus.Default || n.Warn != DefaultNexusWarn {
+		t.Errorf("LoadNexus() = %+v, expected DefaultNexus", n)
+	}
+}
@@ -0,0 +1,144 @@
+// Package config loads the named database profiles the TUI can connect to
+// and the settings files its reports read.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// DefaultPath is the profiles file read when DB_PROFILES is not set.
+const DefaultPath = "profiles.json"
+
+// DefaultProfile is the name of the profile built from DB_URL when there is
+// no profiles file.
+const DefaultProfile = "default"
+
+// Profile is one named database connection.
+//
+// The connection string is given directly in URL or, to keep credentials in
+// .env, in the environment variable named by URLEnv. ReadOnly profiles are
+// for browsing and reports only.
+type Profile struct {
+	Name       string `json:"name"`
+	URL        string `json:"url,omitempty"`
+	URLEnv     string `json:"url_env,omitempty"`
+	Production bool   `json:"production,omitempty"`
+	ReadOnly   bool   `json:"read_only,omitempty"`
+}
+
+// ConnString returns the profile's connection string.
+func (p Profile) ConnString() (string, error) {
+	if p.URL != "" {
+		return p.URL, nil
+	}
+	if p.URLEnv == "" {
+		return "", fmt.Errorf("profile %q has no url or url_env", p.Name)
+	}
+	url := os.Getenv(p.URLEnv)
+	if url == "" {
+		return "", fmt.Errorf("profile %q: %s environment variable must be set", p.Name, p.URLEnv)
+	}
+	return url, nil
+}
+
+// Config is the contents of the profiles file.
+type Config struct {
+	Default  string    `json:"default,omitempty"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// Path returns the profiles file location: DB_PROFILES, or DefaultPath.
+func Path() string {
+	if p := os.Getenv("DB_PROFILES"); p != "" {
+		return p
+	}
+	return DefaultPath
+}
+
+// Load reads the profiles file at path. A missing file yields a single
+// non-production profile that reads DB_URL, so existing .env setups keep working.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{Profiles: []Profile{{Name: DefaultProfile, URLEnv: "DB_URL"}}}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a profiles file.
+func Parse(data []byte) (Config, error) {
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("parsing profiles: %w", err)
+	}
+	if err := c.Validate(); err != nil {
+		return Config{}, err
+	}
+	return c, nil
+}
+
+// Validate checks that profiles are named, unique, and have a connection source.
+func (c Config) Validate() error {
+	if len(c.Profiles) == 0 {
+		return errors.New("profiles: at least one profile is required")
+	}
+
+	seen := make(map[string]bool)
+	for i, p := range c.Profiles {
+		name := strings.TrimSpace(p.Name)
+		if name == "" {
+			return fmt.Errorf("profiles[%d]: name is required", i)
+		}
+		if seen[name] {
+			return fmt.Errorf("profiles: duplicate name %q", name)
+		}
+		seen[name] = true
+
+		if p.URL == "" && p.URLEnv == "" {
+			return fmt.Errorf("profile %q: url or url_env is required", name)
+		}
+	}
+
+	if c.Default != "" {
+		if _, ok := c.Find(c.Default); !ok {
+			return fmt.Errorf("profiles: default %q is not defined", c.Default)
+		}
+	}
+	return nil
+}
+
+// Find returns the profile with the given name.
+func (c Config) Find(name string) (Profile, bool) {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Initial returns the profile to connect to at startup: DB_PROFILE if set,
+// then the file's default, then the first profile.
+func (c Config) Initial() (Profile, error) {
+	name := os.Getenv("DB_PROFILE")
+	if name == "" {
+		name = c.Default
+	}
+	if name == "" {
+		return c.Profiles[0], nil
+	}
+
+	p, ok := c.Find(name)
+	if !ok {
+		return Profile{}, fmt.Errorf("profile %q is not defined", name)
+	}
+	return p, nil
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+/* ========================================
+	Parse Tests
+======================================== */
+
+func TestParse(t *testing.T) {
+	c, err := Parse([]byte(`{
+		"default": "sandbox",
+		"profiles": [
+			{"name": "sandbox", "url_env": "DB_URL"},
+			{"name": "prod", "url": "postgres://prod", "production": true}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if len(c.Profiles) != 2 || c.Default != "sandbox" {
+		t.Fatalf("Parse() = %+v", c)
+	}
+	prod, ok := c.Find("prod")
+	if !ok || !prod.Production {
+		t.Errorf("Find(prod) = %+v, %v, expected production profile", prod, ok)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"no profiles", `{"profiles": []}`, "at least one profile"},
+		{"missing name", `{"profiles": [{"url": "x"}]}`, "name is required"},
+		{"duplicate", `{"profiles": [{"name": "a", "url": "x"}, {"name": "a", "url": "y"}]}`, `duplicate name "a"`},
+		{"no url", `{"profiles": [{"name": "a"}]}`, "url or url_env is required"},
+		{"unknown default", `{"default": "b", "profiles": [{"name": "a", "url": "x"}]}`, `default "b" is not defined`},
+		{"bad json", `{`, "parsing profiles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, expected %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+/* ========================================
+	Load Tests
+======================================== */
+
+func TestLoad_MissingFileFallsBackToDBURL(t *testing.T) {
+	c, err := Load(filepath.Join(t.TempDir(), "profiles.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	want := Profile{Name: DefaultProfile, URLEnv: "DB_URL"}
+	if len(c.Profiles) != 1 || c.Profiles[0] != want {
+		t.Errorf("Load() = %+v, expected single %+v", c.Profiles, want)
+	}
+}
+
+func TestLoad_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	if err := os.WriteFile(path, []byte(`{"profiles": [{"name": "a", "url": "x"}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if c.Profiles[0].Name != "a" {
+		t.Errorf("Profiles[0].Name = %q, expected %q", c.Profiles[0].Name, "a")
+	}
+}
+
+/* ========================================
+	Profile Tests
+======================================== */
+
+func TestProfile_ConnString(t *testing.T) {
+	t.Setenv("TEST_PROFILE_URL", "postgres://env")
+
+	tests := []struct {
+		name    string
+		profile Profile
+		want    string
+		wantErr bool
+	}{
+		{"url wins", Profile{Name: "a", URL: "postgres://direct", URLEnv: "TEST_PROFILE_URL"}, "postgres://direct", false},
+		{"from env", Profile{Name: "a", URLEnv: "TEST_PROFILE_URL"}, "postgres://env", false},
+		{"env unset", Profile{Name: "a", URLEnv: "TEST_PROFILE_UNSET"}, "", true},
+		{"neither", Profile{Name: "a"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.profile.ConnString()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConnString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ConnString() = %q, expected %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_Initial(t *testing.T) {
+	c := Config{
+		Default:  "b",
+		Profiles: []Profile{{Name: "a", URL: "x"}, {Name: "b", URL: "y"}},
+	}
+
+	tests := []struct {
+		name    string
+		env     string
+		config  Config
+		want    string
+		wantErr bool
+	}{
+		{"default", "", c, "b", false},
+		{"env overrides default", "a", c, "a", false},
+		{"first without default", "", Config{Profiles: c.Profiles}, "a", false},
+		{"unknown env profile", "z", c, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("DB_PROFILE", tt.env)
+			got, err := tt.config.Initial()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Initial() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.Name != tt.want {
+				t.Errorf("Initial().Name = %q, expected %q", got.Name, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// DefaultTaxItems are the invoice item names treated as sales tax when
+// TAX_ITEMS is not set.
+var DefaultTaxItems = []string{"Sales Tax", "Tax"}
+
+// TaxLines identifies NetSuite invoice lines that carry sales tax rather than
+// a sale, by item name or by the GL account they post to.
+type TaxLines struct {
+	Items    []string // item names, matched case-insensitively
+	Accounts []string // account names or numbers, matched case-insensitively
+}
+
+// DefaultTaxLines matches DefaultTaxItems and no accounts.
+var DefaultTaxLines = TaxLines{Items: DefaultTaxItems}
+
+// LoadTaxLines reads TAX_ITEMS and TAX_ACCOUNTS, comma-separated lists.
+// TAX_ITEMS defaults to DefaultTaxItems; TAX_ACCOUNTS to none.
+func LoadTaxLines() TaxLines {
+	t := DefaultTaxLines
+	if s := os.Getenv("TAX_ITEMS"); s != "" {
+		t.Items = splitList(s)
+	}
+	if s := os.Getenv("TAX_ACCOUNTS"); s != "" {
+		t.Accounts = splitList(s)
+	}
+	return t
+}
+
+// IsTax reports whether a line with item and account is a tax line. Items
+// match by whole name. Accounts match by whole name, or by account number
+// when the account is written "2200 Sales Tax Payable".
+func (t TaxLines) IsTax(item, account string) bool {
+	item, account = strings.TrimSpace(item), strings.TrimSpace(account)
+
+	for _, name := range t.Items {
+		if item != "" && strings.EqualFold(item, name) {
+			return true
+		}
+	}
+	for _, name := range t.Accounts {
+		if account == "" {
+			break
+		}
+		if strings.EqualFold(account, name) {
+			return true
+		}
+		if number, _, ok := strings.Cut(account, " "); ok && strings.EqualFold(number, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitList splits a comma-separated list, dropping blank entries.
+func splitList(s string) []string {
+	var list []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
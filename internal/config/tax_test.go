@@ -0,0 +1,50 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+/* ========================================
+	TaxLines Tests
+======================================== */
+
+func TestTaxLines_IsTax(t *testing.T) {
+	tax := TaxLines{Items: []string{"Sales Tax"}, Accounts: []string{"2200"}}
+
+	tests := []struct {
+		item     string
+		account  string
+		expected bool
+	}{
+		{"Sales Tax", "", true},
+		{" sales tax ", "", true},
+		{"Taxonomy add-on", "", false},
+		{"Tax Filing Service", "4000 Revenue", false},
+		{"Anrok Tax", "2200 Sales Tax Payable", true},
+		{"Anrok Tax", "2200", true},
+		{"Platform", "22000 Deferred Revenue", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := tax.IsTax(tt.item, tt.account); got != tt.expected {
+			t.Errorf("IsTax(%q, %q) = %v, expected %v", tt.item, tt.account, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadTaxLines(t *testing.T) {
+	t.Setenv("TAX_ITEMS", "")
+	t.Setenv("TAX_ACCOUNTS", "")
+	if got := LoadTaxLines(); !reflect.DeepEqual(got, DefaultTaxLines) {
+		t.Errorf("LoadTaxLines() = %+v, expected the defaults", got)
+	}
+
+	t.Setenv("TAX_ITEMS", "Anrok Tax, ,GST")
+	t.Setenv("TAX_ACCOUNTS", "2200")
+	expected := TaxLines{Items: []string{"Anrok Tax", "GST"}, Accounts: []string{"2200"}}
+	if got := LoadTaxLines(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("LoadTaxLines() = %+v, expected %+v", got, expected)
+	}
+}
@@ -0,0 +1,95 @@
+// Package crosswalk maintains customer_crosswalk, the link from each NetSuite
+// customer to its Salesforce account.
+//
+// Imported links come from ns_customers.salesforce_id_io, normalized to the
+// 18-character ID form, and are rebuilt after every customer import. Manual
+// overrides fix links the export gets wrong (merged accounts, typos) and are
+// kept across rebuilds.
+package crosswalk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/sfid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Link sources stored in customer_crosswalk.source.
+const (
+	SourceNS     = "ns"
+	SourceManual = "manual"
+)
+
+// Rebuild replaces the imported links with the current ns_customers
+// salesforce_id_io values. NS customers with a manual override keep it.
+func Rebuild(ctx context.Context, queries *db.Queries) error {
+	if err := queries.DeleteImportedCrosswalk(ctx); err != nil {
+		return fmt.Errorf("clearing imported crosswalk: %w", err)
+	}
+
+	customers, err := queries.ListNsCustomers(ctx)
+	if err != nil {
+		return fmt.Errorf("loading ns_customers: %w", err)
+	}
+
+	for _, c := range customers {
+		id := strings.TrimSpace(c.InternalID.String)
+		if !c.InternalID.Valid || id == "" {
+			continue
+		}
+
+		err := queries.InsertImportedCrosswalk(ctx, db.InsertImportedCrosswalkParams{
+			NsInternalID:  id,
+			SfdcAccountID: AccountID(c.SalesforceIDIo.String),
+		})
+		if err != nil {
+			return fmt.Errorf("linking ns customer %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// AccountID normalizes a Salesforce account ID for storage: 18 characters,
+// or NULL when blank.
+func AccountID(id string) pgtype.Text {
+	id = sfid.To18(id)
+	return pgtype.Text{String: id, Valid: id != ""}
+}
+
+// SetOverride links an NS customer to a Salesforce account manually. A blank
+// account ID records that the customer has no Salesforce account.
+func SetOverride(ctx context.Context, queries *db.Queries, nsInternalID, sfdcAccountID, note string) error {
+	nsInternalID = strings.TrimSpace(nsInternalID)
+	if nsInternalID == "" {
+		return errors.New("NS internal ID is required")
+	}
+
+	err := queries.SetCrosswalkOverride(ctx, db.SetCrosswalkOverrideParams{
+		NsInternalID:  nsInternalID,
+		SfdcAccountID: AccountID(sfdcAccountID),
+		Note:          pgtype.Text{String: note, Valid: note != ""},
+	})
+	if err != nil {
+		return fmt.Errorf("saving override for %s: %w", nsInternalID, err)
+	}
+	return nil
+}
+
+// ClearOverride removes a manual override and restores the imported link.
+// It reports whether there was an override to remove.
+func ClearOverride(ctx context.Context, queries *db.Queries, nsInternalID string) (bool, error) {
+	nsInternalID = strings.TrimSpace(nsInternalID)
+
+	n, err := queries.DeleteCrosswalkOverride(ctx, nsInternalID)
+	if err != nil {
+		return false, fmt.Errorf("clearing override for %s: %w", nsInternalID, err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+	return true, Rebuild(ctx, queries)
+}
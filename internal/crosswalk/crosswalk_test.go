@@ -0,0 +1,27 @@
+package crosswalk
+
+import "testing"
+
+/* ========================================
+	AccountID Tests
+======================================== */
+
+func TestAccountID(t *testing.T) {
+	tests := []struct {
+		input     string
+		expected  string
+		wantValid bool
+	}{
+		{"001A0000006Vm9r", "001A0000006Vm9rIAC", true},
+		{"001A0000006Vm9rIAC", "001A0000006Vm9rIAC", true},
+		{"  ", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got := AccountID(tt.input)
+		if got.String != tt.expected || got.Valid != tt.wantValid {
+			t.Errorf("AccountID(%q) = %+v, expected %q (valid %v)", tt.input, got, tt.expected, tt.wantValid)
+		}
+	}
+}
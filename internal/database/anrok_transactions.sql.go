@@ -11,6 +11,45 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getAnrokTransactionByKey = `-- name: GetAnrokTransactionByKey :one
+SELECT id, transaction_id, customer_id, customer_name, overall_vat_id_status, valid_vat_ids, other_vat_ids, invoice_date, tax_date, transaction_currency, sales_amount, exempt_reason, tax_amount, invoice_amount, void, customer_address_line_1, customer_address_city, customer_address_region, customer_address_postal_code, customer_address_country, customer_country_code, jurisdictions, jurisdiction_ids, return_ids, source_file
+FROM anrok_transactions
+WHERE transaction_id = $1
+`
+
+func (q *Queries) GetAnrokTransactionByKey(ctx context.Context, transactionID pgtype.Text) (AnrokTransaction, error) {
+	row := q.db.QueryRow(ctx, getAnrokTransactionByKey, transactionID)
+	var i AnrokTransaction
+	err := row.Scan(
+		&i.ID,
+		&i.TransactionID,
+		&i.CustomerID,
+		&i.CustomerName,
+		&i.OverallVatIDStatus,
+		&i.ValidVatIds,
+		&i.OtherVatIds,
+		&i.InvoiceDate,
+		&i.TaxDate,
+		&i.TransactionCurrency,
+		&i.SalesAmount,
+		&i.ExemptReason,
+		&i.TaxAmount,
+		&i.InvoiceAmount,
+		&i.Void,
+		&i.CustomerAddressLine1,
+		&i.CustomerAddressCity,
+		&i.CustomerAddressRegion,
+		&i.CustomerAddressPostalCode,
+		&i.CustomerAddressCountry,
+		&i.CustomerCountryCode,
+		&i.Jurisdictions,
+		&i.JurisdictionIds,
+		&i.ReturnIds,
+		&i.SourceFile,
+	)
+	return i, err
+}
+
 const insertAnrokTransaction = `-- name: InsertAnrokTransaction :exec
 INSERT INTO anrok_transactions (
     transaction_id,
@@ -95,6 +134,58 @@ func (q *Queries) InsertAnrokTransaction(ctx context.Context, arg InsertAnrokTra
 	return err
 }
 
+const listAnrokTransactions = `-- name: ListAnrokTransactions :many
+SELECT id, transaction_id, customer_id, customer_name, overall_vat_id_status, valid_vat_ids, other_vat_ids, invoice_date, tax_date, transaction_currency, sales_amount, exempt_reason, tax_amount, invoice_amount, void, customer_address_line_1, customer_address_city, customer_address_region, customer_address_postal_code, customer_address_country, customer_country_code, jurisdictions, jurisdiction_ids, return_ids, source_file
+FROM anrok_transactions
+ORDER BY transaction_id, id
+`
+
+func (q *Queries) ListAnrokTransactions(ctx context.Context) ([]AnrokTransaction, error) {
+	rows, err := q.db.Query(ctx, listAnrokTransactions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AnrokTransaction{}
+	for rows.Next() {
+		var i AnrokTransaction
+		if err := rows.Scan(
+			&i.ID,
+			&i.TransactionID,
+			&i.CustomerID,
+			&i.CustomerName,
+			&i.OverallVatIDStatus,
+			&i.ValidVatIds,
+			&i.OtherVatIds,
+			&i.InvoiceDate,
+			&i.TaxDate,
+			&i.TransactionCurrency,
+			&i.SalesAmount,
+			&i.ExemptReason,
+			&i.TaxAmount,
+			&i.InvoiceAmount,
+			&i.Void,
+			&i.CustomerAddressLine1,
+			&i.CustomerAddressCity,
+			&i.CustomerAddressRegion,
+			&i.CustomerAddressPostalCode,
+			&i.CustomerAddressCountry,
+			&i.CustomerCountryCode,
+			&i.Jurisdictions,
+			&i.JurisdictionIds,
+			&i.ReturnIds,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetAnrokTransactions = `-- name: ResetAnrokTransactions :exec
 DELETE FROM anrok_transactions
 `
@@ -33,6 +33,7 @@ type AnrokTransaction struct {
 	Jurisdictions             pgtype.Text    `json:"jurisdictions"`
 	JurisdictionIds           pgtype.Text    `json:"jurisdiction_ids"`
 	ReturnIds                 pgtype.Text    `json:"return_ids"`
+	SourceFile                pgtype.Text    `json:"source_file"`
 }
 
 type CsvUpload struct {
@@ -41,6 +42,37 @@ type CsvUpload struct {
 	UploadedAt pgtype.Timestamp `json:"uploaded_at"`
 }
 
+type CustomerCrosswalk struct {
+	NsInternalID  string           `json:"ns_internal_id"`
+	SfdcAccountID pgtype.Text      `json:"sfdc_account_id"`
+	Source        string           `json:"source"`
+	Note          pgtype.Text      `json:"note"`
+	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+}
+
+type ImportWarning struct {
+	ID        pgtype.UUID      `json:"id"`
+	Batch     string           `json:"batch"`
+	Line      int32            `json:"line"`
+	Field     string           `json:"field"`
+	Value     pgtype.Text      `json:"value"`
+	Message   string           `json:"message"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+type MetricsMonthly struct {
+	Period         pgtype.Date      `json:"period"`
+	Mrr            pgtype.Numeric   `json:"mrr"`
+	Arr            pgtype.Numeric   `json:"arr"`
+	NewMrr         pgtype.Numeric   `json:"new_mrr"`
+	ExpansionMrr   pgtype.Numeric   `json:"expansion_mrr"`
+	ContractionMrr pgtype.Numeric   `json:"contraction_mrr"`
+	ChurnedMrr     pgtype.Numeric   `json:"churned_mrr"`
+	Bookings       pgtype.Numeric   `json:"bookings"`
+	Customers      int32            `json:"customers"`
+	ComputedAt     pgtype.Timestamp `json:"computed_at"`
+}
+
 type NsCustomer struct {
 	ID             pgtype.UUID    `json:"id"`
 	SalesforceIDIo pgtype.Text    `json:"salesforce_id_io"`
@@ -52,6 +84,22 @@ type NsCustomer struct {
 	UnbilledOrders pgtype.Numeric `json:"unbilled_orders"`
 	OverdueBalance pgtype.Numeric `json:"overdue_balance"`
 	DaysOverdue    pgtype.Numeric `json:"days_overdue"`
+	SourceFile     pgtype.Text    `json:"source_file"`
+}
+
+type NsCustomersHistory struct {
+	ID             pgtype.UUID      `json:"id"`
+	InternalID     string           `json:"internal_id"`
+	SalesforceIDIo pgtype.Text      `json:"salesforce_id_io"`
+	Name           pgtype.Text      `json:"name"`
+	Duplicate      pgtype.Text      `json:"duplicate"`
+	CompanyName    pgtype.Text      `json:"company_name"`
+	Balance        pgtype.Numeric   `json:"balance"`
+	UnbilledOrders pgtype.Numeric   `json:"unbilled_orders"`
+	OverdueBalance pgtype.Numeric   `json:"overdue_balance"`
+	DaysOverdue    pgtype.Numeric   `json:"days_overdue"`
+	ValidFrom      pgtype.Timestamp `json:"valid_from"`
+	ValidTo        pgtype.Timestamp `json:"valid_to"`
 }
 
 type NsInvoiceDetail struct {
@@ -78,6 +126,7 @@ type NsInvoiceDetail struct {
 	ShippingAddressCity    pgtype.Text    `json:"shipping_address_city"`
 	ShippingAddressState   pgtype.Text    `json:"shipping_address_state"`
 	ShippingAddressCountry pgtype.Text    `json:"shipping_address_country"`
+	SourceFile             pgtype.Text    `json:"source_file"`
 }
 
 type NsSoDetail struct {
@@ -99,6 +148,20 @@ type NsSoDetail struct {
 	UnitPrice           pgtype.Numeric `json:"unit_price"`
 	AmountGross         pgtype.Numeric `json:"amount_gross"`
 	TermsDaysTillNetDue pgtype.Numeric `json:"terms_days_till_net_due"`
+	SourceFile          pgtype.Text    `json:"source_file"`
+}
+
+type RevenueSchedule struct {
+	ID          pgtype.UUID      `json:"id"`
+	Source      string           `json:"source"`
+	LineID      pgtype.UUID      `json:"line_id"`
+	LineRef     pgtype.Text      `json:"line_ref"`
+	Customer    pgtype.Text      `json:"customer"`
+	Product     pgtype.Text      `json:"product"`
+	Period      pgtype.Date      `json:"period"`
+	Days        int32            `json:"days"`
+	Amount      pgtype.Numeric   `json:"amount"`
+	GeneratedAt pgtype.Timestamp `json:"generated_at"`
 }
 
 type SfdcCustomer struct {
@@ -107,6 +170,17 @@ type SfdcCustomer struct {
 	AccountName       pgtype.Text `json:"account_name"`
 	LastActivity      pgtype.Date `json:"last_activity"`
 	Type              pgtype.Text `json:"type"`
+	SourceFile        pgtype.Text `json:"source_file"`
+}
+
+type SfdcCustomersHistory struct {
+	ID                pgtype.UUID      `json:"id"`
+	AccountIDCasesafe string           `json:"account_id_casesafe"`
+	AccountName       pgtype.Text      `json:"account_name"`
+	LastActivity      pgtype.Date      `json:"last_activity"`
+	Type              pgtype.Text      `json:"type"`
+	ValidFrom         pgtype.Timestamp `json:"valid_from"`
+	ValidTo           pgtype.Timestamp `json:"valid_to"`
 }
 
 type SfdcOppDetail struct {
@@ -137,6 +211,7 @@ type SfdcOppDetail struct {
 	TotalAmountDueCustomer       pgtype.Numeric `json:"total_amount_due_customer"`
 	TotalAmountDuePartner        pgtype.Numeric `json:"total_amount_due_partner"`
 	ActiveProduct                pgtype.Bool    `json:"active_product"`
+	SourceFile                   pgtype.Text    `json:"source_file"`
 }
 
 type SfdcPriceBook struct {
@@ -146,4 +221,5 @@ type SfdcPriceBook struct {
 	ProductName       pgtype.Text    `json:"product_name"`
 	ProductCode       pgtype.Text    `json:"product_code"`
 	ProductIDCasesafe pgtype.Text    `json:"product_id_casesafe"`
+	SourceFile        pgtype.Text    `json:"source_file"`
 }
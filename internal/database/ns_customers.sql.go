@@ -11,6 +11,31 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getNsCustomerByKey = `-- name: GetNsCustomerByKey :one
+SELECT id, salesforce_id_io, internal_id, name, duplicate, company_name, balance, unbilled_orders, overdue_balance, days_overdue, source_file
+FROM ns_customers
+WHERE internal_id = $1
+`
+
+func (q *Queries) GetNsCustomerByKey(ctx context.Context, internalID pgtype.Text) (NsCustomer, error) {
+	row := q.db.QueryRow(ctx, getNsCustomerByKey, internalID)
+	var i NsCustomer
+	err := row.Scan(
+		&i.ID,
+		&i.SalesforceIDIo,
+		&i.InternalID,
+		&i.Name,
+		&i.Duplicate,
+		&i.CompanyName,
+		&i.Balance,
+		&i.UnbilledOrders,
+		&i.OverdueBalance,
+		&i.DaysOverdue,
+		&i.SourceFile,
+	)
+	return i, err
+}
+
 const insertNsCustomer = `-- name: InsertNsCustomer :exec
 INSERT INTO ns_customers (
     salesforce_id_io,
@@ -24,6 +49,15 @@ INSERT INTO ns_customers (
     days_overdue
 )
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (internal_id) DO UPDATE SET
+    salesforce_id_io = EXCLUDED.salesforce_id_io,
+    name = EXCLUDED.name,
+    duplicate = EXCLUDED.duplicate,
+    company_name = EXCLUDED.company_name,
+    balance = EXCLUDED.balance,
+    unbilled_orders = EXCLUDED.unbilled_orders,
+    overdue_balance = EXCLUDED.overdue_balance,
+    days_overdue = EXCLUDED.days_overdue
 `
 
 type InsertNsCustomerParams struct {
@@ -53,6 +87,44 @@ func (q *Queries) InsertNsCustomer(ctx context.Context, arg InsertNsCustomerPara
 	return err
 }
 
+const listNsCustomers = `-- name: ListNsCustomers :many
+SELECT id, salesforce_id_io, internal_id, name, duplicate, company_name, balance, unbilled_orders, overdue_balance, days_overdue, source_file
+FROM ns_customers
+ORDER BY internal_id, id
+`
+
+func (q *Queries) ListNsCustomers(ctx context.Context) ([]NsCustomer, error) {
+	rows, err := q.db.Query(ctx, listNsCustomers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NsCustomer{}
+	for rows.Next() {
+		var i NsCustomer
+		if err := rows.Scan(
+			&i.ID,
+			&i.SalesforceIDIo,
+			&i.InternalID,
+			&i.Name,
+			&i.Duplicate,
+			&i.CompanyName,
+			&i.Balance,
+			&i.UnbilledOrders,
+			&i.OverdueBalance,
+			&i.DaysOverdue,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetNsCustomers = `-- name: ResetNsCustomers :exec
 DELETE FROM ns_customers
 `
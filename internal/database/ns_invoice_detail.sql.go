@@ -11,6 +11,49 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getNsInvoiceDetailByKey = `-- name: GetNsInvoiceDetailByKey :one
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, sfdc_pricebook_id, customer_internal_id, product_internal_id, type, date, date_due, document_number, name, memo, item, qty, contract_quantity, unit_price, amount, start_date_line, end_date_line_level, account, shipping_address_city, shipping_address_state, shipping_address_country, source_file
+FROM ns_invoice_detail
+WHERE document_number = $1 AND sfdc_opp_line_id = $2
+`
+
+type GetNsInvoiceDetailByKeyParams struct {
+	DocumentNumber pgtype.Text `json:"document_number"`
+	SfdcOppLineID  pgtype.Text `json:"sfdc_opp_line_id"`
+}
+
+func (q *Queries) GetNsInvoiceDetailByKey(ctx context.Context, arg GetNsInvoiceDetailByKeyParams) (NsInvoiceDetail, error) {
+	row := q.db.QueryRow(ctx, getNsInvoiceDetailByKey, arg.DocumentNumber, arg.SfdcOppLineID)
+	var i NsInvoiceDetail
+	err := row.Scan(
+		&i.ID,
+		&i.SfdcOppID,
+		&i.SfdcOppLineID,
+		&i.SfdcPricebookID,
+		&i.CustomerInternalID,
+		&i.ProductInternalID,
+		&i.Type,
+		&i.Date,
+		&i.DateDue,
+		&i.DocumentNumber,
+		&i.Name,
+		&i.Memo,
+		&i.Item,
+		&i.Qty,
+		&i.ContractQuantity,
+		&i.UnitPrice,
+		&i.Amount,
+		&i.StartDateLine,
+		&i.EndDateLineLevel,
+		&i.Account,
+		&i.ShippingAddressCity,
+		&i.ShippingAddressState,
+		&i.ShippingAddressCountry,
+		&i.SourceFile,
+	)
+	return i, err
+}
+
 const insertNsInvoiceDetail = `-- name: InsertNsInvoiceDetail :exec
 INSERT INTO ns_invoice_detail (
     sfdc_opp_id,
@@ -92,6 +135,111 @@ func (q *Queries) InsertNsInvoiceDetail(ctx context.Context, arg InsertNsInvoice
 	return err
 }
 
+const listNsInvoiceDetail = `-- name: ListNsInvoiceDetail :many
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, sfdc_pricebook_id, customer_internal_id, product_internal_id, type, date, date_due, document_number, name, memo, item, qty, contract_quantity, unit_price, amount, start_date_line, end_date_line_level, account, shipping_address_city, shipping_address_state, shipping_address_country, source_file
+FROM ns_invoice_detail
+ORDER BY document_number, id
+`
+
+func (q *Queries) ListNsInvoiceDetail(ctx context.Context) ([]NsInvoiceDetail, error) {
+	rows, err := q.db.Query(ctx, listNsInvoiceDetail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NsInvoiceDetail{}
+	for rows.Next() {
+		var i NsInvoiceDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.SfdcOppID,
+			&i.SfdcOppLineID,
+			&i.SfdcPricebookID,
+			&i.CustomerInternalID,
+			&i.ProductInternalID,
+			&i.Type,
+			&i.Date,
+			&i.DateDue,
+			&i.DocumentNumber,
+			&i.Name,
+			&i.Memo,
+			&i.Item,
+			&i.Qty,
+			&i.ContractQuantity,
+			&i.UnitPrice,
+			&i.Amount,
+			&i.StartDateLine,
+			&i.EndDateLineLevel,
+			&i.Account,
+			&i.ShippingAddressCity,
+			&i.ShippingAddressState,
+			&i.ShippingAddressCountry,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNsInvoiceDetailWithArchive = `-- name: ListNsInvoiceDetailWithArchive :many
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, sfdc_pricebook_id, customer_internal_id, product_internal_id, type, date, date_due, document_number, name, memo, item, qty, contract_quantity, unit_price, amount, start_date_line, end_date_line_level, account, shipping_address_city, shipping_address_state, shipping_address_country, source_file
+FROM ns_invoice_detail
+UNION ALL
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, sfdc_pricebook_id, customer_internal_id, product_internal_id, type, date, date_due, document_number, name, memo, item, qty, contract_quantity, unit_price, amount, start_date_line, end_date_line_level, account, shipping_address_city, shipping_address_state, shipping_address_country, source_file
+FROM ns_invoice_detail_archive
+ORDER BY document_number, id
+`
+
+func (q *Queries) ListNsInvoiceDetailWithArchive(ctx context.Context) ([]NsInvoiceDetail, error) {
+	rows, err := q.db.Query(ctx, listNsInvoiceDetailWithArchive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NsInvoiceDetail{}
+	for rows.Next() {
+		var i NsInvoiceDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.SfdcOppID,
+			&i.SfdcOppLineID,
+			&i.SfdcPricebookID,
+			&i.CustomerInternalID,
+			&i.ProductInternalID,
+			&i.Type,
+			&i.Date,
+			&i.DateDue,
+			&i.DocumentNumber,
+			&i.Name,
+			&i.Memo,
+			&i.Item,
+			&i.Qty,
+			&i.ContractQuantity,
+			&i.UnitPrice,
+			&i.Amount,
+			&i.StartDateLine,
+			&i.EndDateLineLevel,
+			&i.Account,
+			&i.ShippingAddressCity,
+			&i.ShippingAddressState,
+			&i.ShippingAddressCountry,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetNsInvoiceDetail = `-- name: ResetNsInvoiceDetail :exec
 DELETE FROM ns_invoice_detail
 `
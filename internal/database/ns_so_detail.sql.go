@@ -77,6 +77,101 @@ func (q *Queries) InsertNsSoDetail(ctx context.Context, arg InsertNsSoDetailPara
 	return err
 }
 
+const listNsSoDetail = `-- name: ListNsSoDetail :many
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, customer_internal_id, product_internal_id, customer_project, so_number, document_date, start_date, end_date, item_name, item_display_name, line_start_date, line_end_date, quantity, unit_price, amount_gross, terms_days_till_net_due, source_file
+FROM ns_so_detail
+ORDER BY sfdc_opp_line_id, id
+`
+
+func (q *Queries) ListNsSoDetail(ctx context.Context) ([]NsSoDetail, error) {
+	rows, err := q.db.Query(ctx, listNsSoDetail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NsSoDetail{}
+	for rows.Next() {
+		var i NsSoDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.SfdcOppID,
+			&i.SfdcOppLineID,
+			&i.CustomerInternalID,
+			&i.ProductInternalID,
+			&i.CustomerProject,
+			&i.SoNumber,
+			&i.DocumentDate,
+			&i.StartDate,
+			&i.EndDate,
+			&i.ItemName,
+			&i.ItemDisplayName,
+			&i.LineStartDate,
+			&i.LineEndDate,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.AmountGross,
+			&i.TermsDaysTillNetDue,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNsSoDetailWithArchive = `-- name: ListNsSoDetailWithArchive :many
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, customer_internal_id, product_internal_id, customer_project, so_number, document_date, start_date, end_date, item_name, item_display_name, line_start_date, line_end_date, quantity, unit_price, amount_gross, terms_days_till_net_due, source_file
+FROM ns_so_detail
+UNION ALL
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, customer_internal_id, product_internal_id, customer_project, so_number, document_date, start_date, end_date, item_name, item_display_name, line_start_date, line_end_date, quantity, unit_price, amount_gross, terms_days_till_net_due, source_file
+FROM ns_so_detail_archive
+ORDER BY sfdc_opp_line_id, id
+`
+
+func (q *Queries) ListNsSoDetailWithArchive(ctx context.Context) ([]NsSoDetail, error) {
+	rows, err := q.db.Query(ctx, listNsSoDetailWithArchive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []NsSoDetail{}
+	for rows.Next() {
+		var i NsSoDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.SfdcOppID,
+			&i.SfdcOppLineID,
+			&i.CustomerInternalID,
+			&i.ProductInternalID,
+			&i.CustomerProject,
+			&i.SoNumber,
+			&i.DocumentDate,
+			&i.StartDate,
+			&i.EndDate,
+			&i.ItemName,
+			&i.ItemDisplayName,
+			&i.LineStartDate,
+			&i.LineEndDate,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.AmountGross,
+			&i.TermsDaysTillNetDue,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetNsSoDetail = `-- name: ResetNsSoDetail :exec
 DELETE FROM ns_so_detail
 `
@@ -19,6 +19,10 @@ INSERT INTO sfdc_customers (
     type
 )
 VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id_casesafe) DO UPDATE SET
+    account_name = EXCLUDED.account_name,
+    last_activity = EXCLUDED.last_activity,
+    type = EXCLUDED.type
 `
 
 type InsertSfdcCustomerParams struct {
@@ -38,6 +42,39 @@ func (q *Queries) InsertSfdcCustomer(ctx context.Context, arg InsertSfdcCustomer
 	return err
 }
 
+const listSfdcCustomers = `-- name: ListSfdcCustomers :many
+SELECT id, account_id_casesafe, account_name, last_activity, type, source_file
+FROM sfdc_customers
+ORDER BY account_id_casesafe, id
+`
+
+func (q *Queries) ListSfdcCustomers(ctx context.Context) ([]SfdcCustomer, error) {
+	rows, err := q.db.Query(ctx, listSfdcCustomers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SfdcCustomer{}
+	for rows.Next() {
+		var i SfdcCustomer
+		if err := rows.Scan(
+			&i.ID,
+			&i.AccountIDCasesafe,
+			&i.AccountName,
+			&i.LastActivity,
+			&i.Type,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetSfdcCustomers = `-- name: ResetSfdcCustomers :exec
 DELETE FROM sfdc_customers
 `
@@ -11,6 +11,48 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getSfdcOppDetailByKey = `-- name: GetSfdcOppDetailByKey :one
+SELECT id, opportunity_id, opportunity_product_casesafe_id, opportunity_name, account_name, close_date, booked_date, fiscal_period, payment_schedule, payment_due, contract_start_date, contract_end_date, term_in_months_deprecated, product_name, deployment_type, amount, quantity, list_price, sales_price, total_price, start_date, end_date, term_in_months, product_code, total_amount_due_customer, total_amount_due_partner, active_product, source_file
+FROM sfdc_opp_detail
+WHERE opportunity_product_casesafe_id = $1
+`
+
+func (q *Queries) GetSfdcOppDetailByKey(ctx context.Context, opportunityProductCasesafeID pgtype.Text) (SfdcOppDetail, error) {
+	row := q.db.QueryRow(ctx, getSfdcOppDetailByKey, opportunityProductCasesafeID)
+	var i SfdcOppDetail
+	err := row.Scan(
+		&i.ID,
+		&i.OpportunityID,
+		&i.OpportunityProductCasesafeID,
+		&i.OpportunityName,
+		&i.AccountName,
+		&i.CloseDate,
+		&i.BookedDate,
+		&i.FiscalPeriod,
+		&i.PaymentSchedule,
+		&i.PaymentDue,
+		&i.ContractStartDate,
+		&i.ContractEndDate,
+		&i.TermInMonthsDeprecated,
+		&i.ProductName,
+		&i.DeploymentType,
+		&i.Amount,
+		&i.Quantity,
+		&i.ListPrice,
+		&i.SalesPrice,
+		&i.TotalPrice,
+		&i.StartDate,
+		&i.EndDate,
+		&i.TermInMonths,
+		&i.ProductCode,
+		&i.TotalAmountDueCustomer,
+		&i.TotalAmountDuePartner,
+		&i.ActiveProduct,
+		&i.SourceFile,
+	)
+	return i, err
+}
+
 const insertSfdcOppDetail = `-- name: InsertSfdcOppDetail :exec
 INSERT INTO sfdc_opp_detail (
     opportunity_id,
@@ -104,6 +146,119 @@ func (q *Queries) InsertSfdcOppDetail(ctx context.Context, arg InsertSfdcOppDeta
 	return err
 }
 
+const listSfdcOppDetail = `-- name: ListSfdcOppDetail :many
+SELECT id, opportunity_id, opportunity_product_casesafe_id, opportunity_name, account_name, close_date, booked_date, fiscal_period, payment_schedule, payment_due, contract_start_date, contract_end_date, term_in_months_deprecated, product_name, deployment_type, amount, quantity, list_price, sales_price, total_price, start_date, end_date, term_in_months, product_code, total_amount_due_customer, total_amount_due_partner, active_product, source_file
+FROM sfdc_opp_detail
+ORDER BY opportunity_product_casesafe_id, id
+`
+
+func (q *Queries) ListSfdcOppDetail(ctx context.Context) ([]SfdcOppDetail, error) {
+	rows, err := q.db.Query(ctx, listSfdcOppDetail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SfdcOppDetail{}
+	for rows.Next() {
+		var i SfdcOppDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.OpportunityID,
+			&i.OpportunityProductCasesafeID,
+			&i.OpportunityName,
+			&i.AccountName,
+			&i.CloseDate,
+			&i.BookedDate,
+			&i.FiscalPeriod,
+			&i.PaymentSchedule,
+			&i.PaymentDue,
+			&i.ContractStartDate,
+			&i.ContractEndDate,
+			&i.TermInMonthsDeprecated,
+			&i.ProductName,
+			&i.DeploymentType,
+			&i.Amount,
+			&i.Quantity,
+			&i.ListPrice,
+			&i.SalesPrice,
+			&i.TotalPrice,
+			&i.StartDate,
+			&i.EndDate,
+			&i.TermInMonths,
+			&i.ProductCode,
+			&i.TotalAmountDueCustomer,
+			&i.TotalAmountDuePartner,
+			&i.ActiveProduct,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSfdcOppDetailWithArchive = `-- name: ListSfdcOppDetailWithArchive :many
+SELECT id, opportunity_id, opportunity_product_casesafe_id, opportunity_name, account_name, close_date, booked_date, fiscal_period, payment_schedule, payment_due, contract_start_date, contract_end_date, term_in_months_deprecated, product_name, deployment_type, amount, quantity, list_price, sales_price, total_price, start_date, end_date, term_in_months, product_code, total_amount_due_customer, total_amount_due_partner, active_product, source_file
+FROM sfdc_opp_detail
+UNION ALL
+SELECT id, opportunity_id, opportunity_product_casesafe_id, opportunity_name, account_name, close_date, booked_date, fiscal_period, payment_schedule, payment_due, contract_start_date, contract_end_date, term_in_months_deprecated, product_name, deployment_type, amount, quantity, list_price, sales_price, total_price, start_date, end_date, term_in_months, product_code, total_amount_due_customer, total_amount_due_partner, active_product, source_file
+FROM sfdc_opp_detail_archive
+ORDER BY opportunity_product_casesafe_id, id
+`
+
+func (q *Queries) ListSfdcOppDetailWithArchive(ctx context.Context) ([]SfdcOppDetail, error) {
+	rows, err := q.db.Query(ctx, listSfdcOppDetailWithArchive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SfdcOppDetail{}
+	for rows.Next() {
+		var i SfdcOppDetail
+		if err := rows.Scan(
+			&i.ID,
+			&i.OpportunityID,
+			&i.OpportunityProductCasesafeID,
+			&i.OpportunityName,
+			&i.AccountName,
+			&i.CloseDate,
+			&i.BookedDate,
+			&i.FiscalPeriod,
+			&i.PaymentSchedule,
+			&i.PaymentDue,
+			&i.ContractStartDate,
+			&i.ContractEndDate,
+			&i.TermInMonthsDeprecated,
+			&i.ProductName,
+			&i.DeploymentType,
+			&i.Amount,
+			&i.Quantity,
+			&i.ListPrice,
+			&i.SalesPrice,
+			&i.TotalPrice,
+			&i.StartDate,
+			&i.EndDate,
+			&i.TermInMonths,
+			&i.ProductCode,
+			&i.TotalAmountDueCustomer,
+			&i.TotalAmountDuePartner,
+			&i.ActiveProduct,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetSfdcOppDetail = `-- name: ResetSfdcOppDetail :exec
 DELETE FROM sfdc_opp_detail
 `
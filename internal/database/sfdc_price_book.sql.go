@@ -41,6 +41,40 @@ func (q *Queries) InsertSfdcPriceBook(ctx context.Context, arg InsertSfdcPriceBo
 	return err
 }
 
+const listSfdcPriceBook = `-- name: ListSfdcPriceBook :many
+SELECT id, price_book_name, list_price, product_name, product_code, product_id_casesafe, source_file
+FROM sfdc_price_book
+ORDER BY product_code, price_book_name, id
+`
+
+func (q *Queries) ListSfdcPriceBook(ctx context.Context) ([]SfdcPriceBook, error) {
+	rows, err := q.db.Query(ctx, listSfdcPriceBook)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SfdcPriceBook{}
+	for rows.Next() {
+		var i SfdcPriceBook
+		if err := rows.Scan(
+			&i.ID,
+			&i.PriceBookName,
+			&i.ListPrice,
+			&i.ProductName,
+			&i.ProductCode,
+			&i.ProductIDCasesafe,
+			&i.SourceFile,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const resetSfdcPriceBook = `-- name: ResetSfdcPriceBook :exec
 DELETE FROM sfdc_price_book
 `
@@ -35,12 +35,7 @@ func (a *AnrokUpload) InsertAnrokTransactions() tea.Cmd {
 	Build Param functions
 ---------------------------------------- */
 
-func (a *AnrokUpload) BuildAnrokTransactionParams(row []string, headerIdx HeaderIndex) (db.InsertAnrokTransactionParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.AnrokFieldSpecs)
-	if err != nil {
-		return db.InsertAnrokTransactionParams{}, err
-	}
-
+func (a *AnrokUpload) BuildAnrokTransactionParams(vrow ValidatedRow) (db.InsertAnrokTransactionParams, error) {
 	return db.InsertAnrokTransactionParams{
 		TransactionID:             ToPgText(vrow["Transaction ID"]),
 		CustomerID:                ToPgText(vrow["Customer ID"]),
@@ -75,7 +70,8 @@ func (a *AnrokUpload) BuildAnrokTransactionParams(row []string, headerIdx Header
 func (a *AnrokUpload) makeDirMap() map[string]CsvProps {
 	return map[string]CsvProps{
 		"Transactions": CsvHandler[db.InsertAnrokTransactionParams]{
-			specs:  schema.AnrokFieldSpecs,
+			table:  "anrok_transactions",
+			specs:  schema.Resolve(schema.AnrokFieldSpecs),
 			build:  a.BuildAnrokTransactionParams,
 			insert: a.insertAnrokTransaction(),
 		},
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	db "github.com/JonMunkholm/TUI/internal/database"
@@ -75,7 +77,7 @@ func (b *BaseUploader) RunUpload(dir string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), UploadTimeout)
 		defer cancel()
 
-		err := ProcessUpload(
+		results, err := ProcessUpload(
 			ctx,
 			b.Pool,
 			b.Root,
@@ -90,6 +92,32 @@ func (b *BaseUploader) RunUpload(dir string) tea.Cmd {
 			}
 			return ErrMsg{Err: err}
 		}
-		return DoneMsg("Upload Complete!")
+		return DoneMsg(UploadSummary(results))
 	}
 }
+
+// UploadSummary formats per-file import results for display.
+func UploadSummary(results []FileResult) string {
+	var sb strings.Builder
+	sb.WriteString("Upload Complete!")
+
+	for _, r := range results {
+		if r.Skipped {
+			fmt.Fprintf(&sb, "\n\n%s: already imported, skipped", r.File)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\n\n%s: %d imported, %d failed, %d warnings", r.File, r.Imported, r.Failed, r.WarningCount())
+
+		fields := make([]string, 0, len(r.Warnings))
+		for field := range r.Warnings {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			fmt.Fprintf(&sb, "\n  warning: %s (%d)", field, r.Warnings[field])
+		}
+	}
+
+	return sb.String()
+}
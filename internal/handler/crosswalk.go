@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/JonMunkholm/TUI/internal/crosswalk"
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+// rebuildCrosswalk refreshes the imported NS-to-SFDC customer links from the
+// current customers.
+func rebuildCrosswalk(ctx context.Context, queries *db.Queries) error {
+	return crosswalk.Rebuild(ctx, queries)
+}
@@ -3,6 +3,8 @@ package handler
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	db "github.com/JonMunkholm/TUI/internal/database"
 	"github.com/JonMunkholm/TUI/internal/schema"
@@ -18,21 +20,79 @@ type HeaderIndex map[string]int
 
 type CsvProps interface {
 	Header() []string
-	BuildParams(row []string, headerIdx HeaderIndex) (any, error)
+	Table() string
+	Specs() []schema.FieldSpec
+	ParamColumns() []string
+	BuildParams(row []string, headerIdx HeaderIndex) (any, []FieldWarning, error)
 	Insert(ctx context.Context, queries *db.Queries, arg any) (bool, error)
+	AfterImport(ctx context.Context, queries *db.Queries, file string) error
+	Rebuild(ctx context.Context, queries *db.Queries) error
 }
 
-type BuildParamsFn[T any] func([]string, HeaderIndex) (T, error)
+// BuildParamsFn converts a row that passed the handler's specs into insert params.
+type BuildParamsFn[T any] func(ValidatedRow) (T, error)
 type InsertFn[T any] func(context.Context, *db.Queries, T) (bool, error)
 
+// AfterImportFn runs once per file after every row is inserted, inside the
+// same transaction. file is the full path of the imported CSV.
+type AfterImportFn func(ctx context.Context, queries *db.Queries, file string) error
+
+// RebuildFn refreshes the tables derived from a data table's current rows,
+// inside the caller's transaction. It runs after every import, and after a
+// reset or duplicate removal changes the table.
+type RebuildFn func(ctx context.Context, queries *db.Queries) error
+
+// chainRebuild runs several rebuild steps in order, stopping at the first error.
+func chainRebuild(steps ...RebuildFn) RebuildFn {
+	return func(ctx context.Context, queries *db.Queries) error {
+		for _, step := range steps {
+			if err := step(ctx, queries); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 /* ----------------------------------------
 	CSV HANDLER WRAPPER
 ---------------------------------------- */
 
 type CsvHandler[T any] struct {
-	specs  []schema.FieldSpec
-	build  BuildParamsFn[T]
-	insert InsertFn[T]
+	table   string // Destination table in the database
+	specs   []schema.FieldSpec
+	build   BuildParamsFn[T]
+	insert  InsertFn[T]
+	after   AfterImportFn // Optional: steps tied to the imported file, such as snapshot history
+	rebuild RebuildFn     // Optional: derived tables, rebuilt after the after-import step
+}
+
+// Table returns the database table rows are inserted into.
+func (h CsvHandler[T]) Table() string {
+	return h.table
+}
+
+// Specs returns the field specs used to validate each row.
+func (h CsvHandler[T]) Specs() []schema.FieldSpec {
+	return h.specs
+}
+
+// ParamColumns returns the columns set by the sqlc insert params, read from
+// the json tags sqlc emits on the params struct.
+func (h CsvHandler[T]) ParamColumns() []string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	cols := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		tag := t.Field(i).Tag.Get("json")
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			cols = append(cols, name)
+		}
+	}
+	return cols
 }
 
 func (h CsvHandler[T]) Header() []string {
@@ -43,8 +103,20 @@ func (h CsvHandler[T]) Header() []string {
 	return headers
 }
 
-func (h CsvHandler[T]) BuildParams(row []string, headerIdx HeaderIndex) (any, error) {
-	return h.build(row, headerIdx)
+// BuildParams validates the row against the handler's specs and builds its
+// insert params. Warning-level findings from the same pass are returned with
+// the params; they are only meaningful when err is nil.
+func (h CsvHandler[T]) BuildParams(row []string, headerIdx HeaderIndex) (any, []FieldWarning, error) {
+	vrow, warnings, err := checkRow(row, headerIdx, h.specs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	params, err := h.build(vrow)
+	if err != nil {
+		return nil, nil, err
+	}
+	return params, warnings, nil
 }
 
 func (h CsvHandler[T]) Insert(ctx context.Context, queries *db.Queries, arg any) (bool, error) {
@@ -55,3 +127,23 @@ func (h CsvHandler[T]) Insert(ctx context.Context, queries *db.Queries, arg any)
 
 	return h.insert(ctx, queries, typed)
 }
+
+// AfterImport runs the handler's after-import hook, then rebuilds its
+// derived tables.
+func (h CsvHandler[T]) AfterImport(ctx context.Context, queries *db.Queries, file string) error {
+	if h.after != nil {
+		if err := h.after(ctx, queries, file); err != nil {
+			return err
+		}
+	}
+	return h.Rebuild(ctx, queries)
+}
+
+// Rebuild refreshes the tables derived from the handler's table, if any.
+// Unlike AfterImport it records nothing about a file.
+func (h CsvHandler[T]) Rebuild(ctx context.Context, queries *db.Queries) error {
+	if h.rebuild == nil {
+		return nil
+	}
+	return h.rebuild(ctx, queries)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	db "github.com/JonMunkholm/TUI/internal/database"
@@ -74,10 +75,10 @@ func TestCsvHandler_Header(t *testing.T) {
 }
 
 func TestCsvHandler_BuildParams_Positive(t *testing.T) {
-	buildFn := func(row []string, _ HeaderIndex) (testParams, error) {
+	buildFn := func(vrow ValidatedRow) (testParams, error) {
 		return testParams{
-			Field1: row[0],
-			Field2: len(row),
+			Field1: vrow["Column1"],
+			Field2: len(vrow),
 		}, nil
 	}
 
@@ -88,7 +89,7 @@ func TestCsvHandler_BuildParams_Positive(t *testing.T) {
 
 	row := []string{"value1", "value2"}
 	headerIdx := MakeHeaderIndex(handler.Header())
-	result, err := handler.BuildParams(row, headerIdx)
+	result, _, err := handler.BuildParams(row, headerIdx)
 
 	if err != nil {
 		t.Errorf("BuildParams() error = %v", err)
@@ -112,7 +113,7 @@ func TestCsvHandler_BuildParams_Positive(t *testing.T) {
 
 func TestCsvHandler_BuildParams_Negative(t *testing.T) {
 	expectedErr := errors.New("build error")
-	buildFn := func(row []string, _ HeaderIndex) (testParams, error) {
+	buildFn := func(ValidatedRow) (testParams, error) {
 		return testParams{}, expectedErr
 	}
 
@@ -121,7 +122,7 @@ func TestCsvHandler_BuildParams_Negative(t *testing.T) {
 	}
 
 	headerIdx := MakeHeaderIndex(handler.Header())
-	_, err := handler.BuildParams([]string{"value"}, headerIdx)
+	_, _, err := handler.BuildParams([]string{"value"}, headerIdx)
 
 	if err == nil {
 		t.Error("BuildParams() expected error, got nil")
@@ -133,6 +134,37 @@ func TestCsvHandler_BuildParams_Negative(t *testing.T) {
 	}
 }
 
+func TestCsvHandler_BuildParams_Warnings(t *testing.T) {
+	builds := 0
+	handler := CsvHandler[testParams]{
+		specs: []schema.FieldSpec{{
+			Name:        "id",
+			Type:        schema.FieldText,
+			Normalizers: []string{schema.NormDigits},
+			Checks:      []schema.Check{schema.WarnIfNormalized()},
+		}},
+		build: func(vrow ValidatedRow) (testParams, error) {
+			builds++
+			return testParams{Field1: vrow["id"]}, nil
+		},
+	}
+
+	headerIdx := MakeHeaderIndex(handler.Header())
+	result, warnings, err := handler.BuildParams([]string{"ID-42"}, headerIdx)
+	if err != nil {
+		t.Fatalf("BuildParams() error = %v", err)
+	}
+	if result.(testParams).Field1 != "42" {
+		t.Errorf("Field1 = %q, want the normalized value", result.(testParams).Field1)
+	}
+	if len(warnings) != 1 || warnings[0].Field != "id" {
+		t.Errorf("BuildParams() warnings = %v, want one for id", warnings)
+	}
+	if builds != 1 {
+		t.Errorf("build called %d times, want 1", builds)
+	}
+}
+
 func TestCsvHandler_Insert_Positive(t *testing.T) {
 	insertCalled := false
 	insertFn := func(ctx context.Context, queries *db.Queries, arg testParams) (bool, error) {
@@ -241,13 +273,13 @@ func TestCsvHandler_FullWorkflow(t *testing.T) {
 
 	handler := CsvHandler[mockDbParams]{
 		specs: specsFromHeaders([]string{"Name", "Value"}),
-		build: func(row []string, _ HeaderIndex) (mockDbParams, error) {
+		build: func(vrow ValidatedRow) (mockDbParams, error) {
 			buildCount++
-			if len(row) < 2 {
+			if len(vrow) < 2 {
 				return mockDbParams{}, errors.New("row too short")
 			}
 			return mockDbParams{
-				Name:  row[0],
+				Name:  vrow["Name"],
 				Value: 100.0,
 			}, nil
 		},
@@ -276,7 +308,7 @@ func TestCsvHandler_FullWorkflow(t *testing.T) {
 	headerIdx := MakeHeaderIndex(handler.Header()) // Pre-compute once
 
 	for _, row := range testRows {
-		arg, err := handler.BuildParams(row, headerIdx)
+		arg, _, err := handler.BuildParams(row, headerIdx)
 		if err != nil {
 			t.Errorf("BuildParams failed: %v", err)
 			continue
@@ -327,21 +359,21 @@ func TestCsvHandler_NilFunctions(t *testing.T) {
 	}()
 
 	headerIdx := MakeHeaderIndex(handler.Header())
-	_, _ = handler.BuildParams([]string{"test"}, headerIdx)
+	_, _, _ = handler.BuildParams([]string{"test"}, headerIdx)
 }
 
 func TestCsvHandler_EmptyRow(t *testing.T) {
 	handler := CsvHandler[testParams]{
-		build: func(row []string, _ HeaderIndex) (testParams, error) {
-			if len(row) == 0 {
+		build: func(vrow ValidatedRow) (testParams, error) {
+			if len(vrow) == 0 {
 				return testParams{}, errors.New("empty row")
 			}
-			return testParams{Field1: row[0]}, nil
+			return testParams{Field1: vrow["Column1"]}, nil
 		},
 	}
 
 	headerIdx := MakeHeaderIndex(handler.Header())
-	_, err := handler.BuildParams([]string{}, headerIdx)
+	_, _, err := handler.BuildParams([]string{}, headerIdx)
 
 	if err == nil {
 		t.Error("BuildParams should return error for empty row")
@@ -394,3 +426,67 @@ func TestCsvHandler_TypeSafety(t *testing.T) {
 		t.Error("handler2 should reject params1")
 	}
 }
+
+func TestCsvHandler_AfterImportNil(t *testing.T) {
+	h := CsvHandler[testParams]{}
+
+	if err := h.AfterImport(context.Background(), nil, "file.csv"); err != nil {
+		t.Errorf("AfterImport() without hook = %v, expected nil", err)
+	}
+}
+
+func TestChainRebuild(t *testing.T) {
+	var ran []string
+	step := func(name string, err error) RebuildFn {
+		return func(ctx context.Context, queries *db.Queries) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+
+	if err := chainRebuild(step("a", nil), step("b", nil))(context.Background(), nil); err != nil {
+		t.Fatalf("chainRebuild() error = %v", err)
+	}
+	if strings.Join(ran, ",") != "a,b" {
+		t.Errorf("ran = %v, expected a,b", ran)
+	}
+
+	ran = nil
+	failure := errors.New("schedule failed")
+	err := chainRebuild(step("a", failure), step("b", nil))(context.Background(), nil)
+	if !errors.Is(err, failure) {
+		t.Errorf("chainRebuild() error = %v, expected %v", err, failure)
+	}
+	if strings.Join(ran, ",") != "a" {
+		t.Errorf("ran = %v, expected to stop after a", ran)
+	}
+}
+
+func TestCsvHandler_AfterImportRebuilds(t *testing.T) {
+	var ran []string
+	h := CsvHandler[testParams]{
+		after: func(ctx context.Context, queries *db.Queries, file string) error {
+			ran = append(ran, "after "+file)
+			return nil
+		},
+		rebuild: func(ctx context.Context, queries *db.Queries) error {
+			ran = append(ran, "rebuild")
+			return nil
+		},
+	}
+
+	if err := h.AfterImport(context.Background(), nil, "f.csv"); err != nil {
+		t.Fatalf("AfterImport() error = %v", err)
+	}
+	if strings.Join(ran, ",") != "after f.csv,rebuild" {
+		t.Errorf("AfterImport() ran %v, expected the hook then the rebuild", ran)
+	}
+
+	ran = nil
+	if err := h.Rebuild(context.Background(), nil); err != nil {
+		t.Fatalf("Rebuild() error = %v", err)
+	}
+	if strings.Join(ran, ",") != "rebuild" {
+		t.Errorf("Rebuild() ran %v, expected only the rebuild", ran)
+	}
+}
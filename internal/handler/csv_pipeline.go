@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,7 @@ import (
 	"github.com/JonMunkholm/TUI/internal/csv"
 	db "github.com/JonMunkholm/TUI/internal/database"
 	"github.com/JonMunkholm/TUI/internal/schema"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -31,6 +33,11 @@ var TwoDigitYearPivot = 20
 // with performance. 100 rows is typically sub-millisecond of processing.
 var ContextCheckInterval = 100
 
+// WriteWarningsFile controls whether row warnings are also written to a
+// "<file> - warnings.csv" next to the failed-records file. Warnings are
+// always stored in the import_warnings table.
+var WriteWarningsFile = true
+
 // Date layouts split by year format for proper 2-digit year handling
 var (
 	// 2-digit year layouts - require pivot year adjustment
@@ -54,8 +61,44 @@ type ErrMsg struct{ Err error }
 type CsvCheck func(ctx context.Context, file string) ([]db.CsvUpload, error)
 type ActType func(ctx context.Context, fileName string) error
 
+// FileResult summarizes the import of a single CSV file.
+type FileResult struct {
+	File     string
+	Skipped  bool           // File was already imported
+	Imported int            // Rows committed
+	Failed   int            // Rows written to the failed-records file
+	Warnings map[string]int // Warning count per field
+}
+
+// WarningCount returns the total number of warnings across all fields.
+func (r FileResult) WarningCount() int {
+	n := 0
+	for _, c := range r.Warnings {
+		n += c
+	}
+	return n
+}
+
 
 
+// pgUniqueViolation is the SQLSTATE for unique constraint violations.
+const pgUniqueViolation = "23505"
+
+// insertErrorMessage describes a failed row insert. Unique violations on a
+// table's business key are reported as "duplicate key" with the key value.
+func insertErrorMessage(err error) string {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgUniqueViolation {
+		return "db error: " + err.Error()
+	}
+
+	// Detail reads: Key (transaction_id)=(T-100) already exists.
+	if key := strings.TrimSuffix(strings.TrimPrefix(pgErr.Detail, "Key "), "."); key != "" {
+		return "duplicate key: " + key
+	}
+	return "duplicate key: violates " + pgErr.ConstraintName
+}
+
 func getUploadsRoot() (string, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -80,27 +123,29 @@ func ProcessUpload(
 	dirMap map[string]CsvProps,
 	csvCheck CsvCheck,
 	actType ActType,
-) error {
+) ([]FileResult, error) {
 
 	// Top level (NS) dir
 
 	handler := dirMap[dir]
 	if handler == nil {
-		return fmt.Errorf("no CsvProps handler found for dir: %s", dir)
+		return nil, fmt.Errorf("no CsvProps handler found for dir: %s", dir)
 	}
 
 	full := filepath.Join(root, dir)
 
 	if len(handler.Header()) == 0 {
-		return fmt.Errorf("directory Headers not defined: %s", dir)
+		return nil, fmt.Errorf("directory Headers not defined: %s", dir)
 	}
 
 	entries, err := os.ReadDir(full)
 
 	if err != nil {
-		return fmt.Errorf("reading directory %s: %w", dir, err)
+		return nil, fmt.Errorf("reading directory %s: %w", dir, err)
 	}
 
+	var results []FileResult
+
 	// DB dir level - dir containing upload file
 
 	for _, entry := range entries {
@@ -112,13 +157,15 @@ func ProcessUpload(
 			continue
 		}
 
-		if err := processUploadFile(
+		result, err := processUploadFile(
 			ctx, pool, full, entry.Name(), handler, csvCheck, actType,
-		); err != nil {
-			return err
+		)
+		if err != nil {
+			return results, err
 		}
+		results = append(results, result)
 	}
-	return nil
+	return results, nil
 }
 
 
@@ -134,59 +181,66 @@ func processUploadFile(
 	handler CsvProps,
 	csvCheck CsvCheck,
 	actType ActType,
-) error {
+) (FileResult, error) {
 
 	path := filepath.Join(dir, file)
+	result := FileResult{File: file, Warnings: map[string]int{}}
 
 	// Check context before starting
 	if err := ctx.Err(); err != nil {
-		return fmt.Errorf("operation cancelled: %w", err)
+		return result, fmt.Errorf("operation cancelled: %w", err)
 	}
 
 	// 1. Skip already-uploaded files
 	existing, err := csvCheck(ctx, path)
 	if err != nil {
-		return fmt.Errorf("csv check failed: %w", err)
+		return result, fmt.Errorf("csv check failed: %w", err)
 	}
 
 	if len(existing) != 0 {
 		// File already processed - verify it still exists before removing
 		if _, statErr := os.Stat(path); statErr == nil {
 			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
-				return fmt.Errorf("failed to remove already-uploaded file %s: %w", filepath.Base(path), err)
+				return result, fmt.Errorf("failed to remove already-uploaded file %s: %w", filepath.Base(path), err)
 			}
 		}
-		return nil
+		result.Skipped = true
+		return result, nil
 	}
 
 	// 2. Header
 	headerIdx, err := csv.FindHeaderRow(path, handler.Header())
 	if err != nil {
-		return fmt.Errorf("header match failed for %s: %w", file, err)
+		return result, fmt.Errorf("header match failed for %s: %w", file, err)
 	}
 
 	// 3. Read rows
 	rows, err := csv.Read(path)
 	if err != nil {
-		return fmt.Errorf("error reading csv %s: %w", file, err)
+		return result, fmt.Errorf("error reading csv %s: %w", file, err)
 	}
 
 	if headerIdx < 0 || headerIdx >= len(rows) {
-		return fmt.Errorf("csv file %s has fewer rows (%d) than header index (%d)", file, len(rows), headerIdx)
+		return result, fmt.Errorf("csv file %s has fewer rows (%d) than header index (%d)", file, len(rows), headerIdx)
 	}
 
 	dataRows := rows[headerIdx+1:]
 	if len(dataRows) == 0 {
-		return fmt.Errorf("no rows found after header in csv %s", file)
+		return result, fmt.Errorf("no rows found after header in csv %s", file)
 	}
 
 	// 4. BEGIN TRANSACTION - all row inserts are atomic per file
 	tx, err := pool.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx) // No-op if already committed
 
+	// Rows record the file they came from through the source_file column default
+	if _, err := tx.Exec(ctx, "SELECT set_config('tui.source_file', $1, true)", path); err != nil {
+		return result, fmt.Errorf("failed to set source file: %w", err)
+	}
+
 	txQueries := db.New(tx) // Transaction-bound queries
 
 	// 5. Build + Act on rows
@@ -194,6 +248,7 @@ func processUploadFile(
 	csvHeaderIdx := MakeHeaderIndex(headerRow) // Pre-compute once for all rows
 	header := append([]string{"Status"}, headerRow...)
 	failedRecords := [][]string{header}
+	warningRecords := [][]string{append([]string{"Warning"}, headerRow...)}
 	expectedCols := len(handler.Header())
 
 	for i, row := range dataRows {
@@ -203,7 +258,7 @@ func processUploadFile(
 		// Check context periodically to allow cancellation
 		if i%ContextCheckInterval == 0 {
 			if err := ctx.Err(); err != nil {
-				return fmt.Errorf("operation cancelled at line %d: %w", csvLineNum, err)
+				return result, fmt.Errorf("operation cancelled at line %d: %w", csvLineNum, err)
 			}
 		}
 
@@ -227,7 +282,7 @@ func processUploadFile(
 			continue
 		}
 
-		arg, err := handler.BuildParams(row, csvHeaderIdx)
+		arg, warnings, err := handler.BuildParams(row, csvHeaderIdx)
 		if err != nil {
 			failedRecords = append(failedRecords, rowFailed(
 				fmt.Sprintf("line %d: %s", csvLineNum, err.Error()),
@@ -238,23 +293,23 @@ func processUploadFile(
 
 		// Check context before each insert to allow prompt cancellation
 		if err := ctx.Err(); err != nil {
-			return fmt.Errorf("operation cancelled at line %d: %w", csvLineNum, err)
+			return result, fmt.Errorf("operation cancelled at line %d: %w", csvLineNum, err)
 		}
 
 		// Use savepoint to isolate each insert - PostgreSQL aborts entire transaction on any error
 		savepointName := fmt.Sprintf("sp_%d", i)
 		if _, err := tx.Exec(ctx, fmt.Sprintf("SAVEPOINT %s", savepointName)); err != nil {
-			return fmt.Errorf("failed to create savepoint at line %d: %w", csvLineNum, err)
+			return result, fmt.Errorf("failed to create savepoint at line %d: %w", csvLineNum, err)
 		}
 
 		ok, err := handler.Insert(ctx, txQueries, arg)
 		if err != nil {
 			// Rollback to savepoint to recover transaction state
 			if _, rbErr := tx.Exec(ctx, fmt.Sprintf("ROLLBACK TO SAVEPOINT %s", savepointName)); rbErr != nil {
-				return fmt.Errorf("failed to rollback savepoint at line %d: %w", csvLineNum, rbErr)
+				return result, fmt.Errorf("failed to rollback savepoint at line %d: %w", csvLineNum, rbErr)
 			}
 			failedRecords = append(failedRecords, rowFailed(
-				fmt.Sprintf("line %d: db error: %s", csvLineNum, err.Error()),
+				fmt.Sprintf("line %d: %s", csvLineNum, insertErrorMessage(err)),
 				row,
 			))
 			continue
@@ -262,7 +317,7 @@ func processUploadFile(
 
 		// Release savepoint on success to free resources
 		if _, err := tx.Exec(ctx, fmt.Sprintf("RELEASE SAVEPOINT %s", savepointName)); err != nil {
-			return fmt.Errorf("failed to release savepoint at line %d: %w", csvLineNum, err)
+			return result, fmt.Errorf("failed to release savepoint at line %d: %w", csvLineNum, err)
 		}
 
 		if !ok {
@@ -272,49 +327,86 @@ func processUploadFile(
 			))
 			continue
 		}
+
+		result.Imported++
+
+		// Record warnings for the imported row alongside it in the same transaction
+		for _, w := range warnings {
+			if err := txQueries.InsertImportWarning(ctx, db.InsertImportWarningParams{
+				Batch:   path,
+				Line:    int32(csvLineNum),
+				Field:   w.Field,
+				Value:   ToPgText(w.Value),
+				Message: w.Message,
+			}); err != nil {
+				return result, fmt.Errorf("failed to record warning at line %d: %w", csvLineNum, err)
+			}
+			result.Warnings[w.Field]++
+			warningRecords = append(warningRecords, rowFailed(
+				fmt.Sprintf("line %d: %s: %s", csvLineNum, w.Field, w.Message),
+				row,
+			))
+		}
+	}
+
+	result.Failed = len(failedRecords) - 1
+
+	// 6. After-import hook (e.g. customer history) in the same transaction
+	if err := handler.AfterImport(ctx, txQueries, path); err != nil {
+		return result, fmt.Errorf("after-import step failed: %w", err)
 	}
 
-	// 6. COMMIT TRANSACTION
+	// 7. COMMIT TRANSACTION
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return result, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// 7. Log upload (after successful commit)
+	// 8. Log upload (after successful commit)
 	if err = actType(ctx, path); err != nil {
-		return err
+		return result, err
 	}
 
-	// 8. Sanitize filename to prevent path traversal attacks
+	// 9. Sanitize filename to prevent path traversal attacks
 	safeFile := filepath.Base(file)
 	if safeFile != file || strings.Contains(file, "..") {
-		return fmt.Errorf("invalid filename: %q", file)
+		return result, fmt.Errorf("invalid filename: %q", file)
 	}
 
-	// 9. Write failed records file (if applicable)
+	// 10. Write failed records file (if applicable)
 	if len(failedRecords) > 1 {
 		top := filepath.Dir(dir)
 		fileName := fmt.Sprintf("%s - failed.csv", strings.TrimSuffix(safeFile, ".csv"))
 		failedRecordsPath := filepath.Join(top, fileName)
 
 		if err = csv.Write(failedRecordsPath, failedRecords); err != nil {
-			return fmt.Errorf("failed writing failure file: %w", err)
+			return result, fmt.Errorf("failed writing failure file: %w", err)
+		}
+	}
+
+	// 11. Write warnings file (if applicable)
+	if WriteWarningsFile && len(warningRecords) > 1 {
+		top := filepath.Dir(dir)
+		fileName := fmt.Sprintf("%s - warnings.csv", strings.TrimSuffix(safeFile, ".csv"))
+
+		if err = csv.Write(filepath.Join(top, fileName), warningRecords); err != nil {
+			return result, fmt.Errorf("failed writing warnings file: %w", err)
 		}
 	}
 
-	// 10. Move to Uploaded directory
+	// 12. Move to Uploaded directory
 
 	// Ensure Uploaded directory exists
 	uploadedDir := filepath.Join(dir, "Uploaded")
 	if err := os.MkdirAll(uploadedDir, 0755); err != nil {
-		return fmt.Errorf("failed to create Uploaded directory: %w", err)
+		return result, fmt.Errorf("failed to create Uploaded directory: %w", err)
 	}
 
 	dest := filepath.Join(uploadedDir, safeFile)
 	if err := os.Rename(path, dest); err != nil {
-		return fmt.Errorf("failed moving file %s: %w", safeFile, err)
+		return result, fmt.Errorf("failed moving file %s: %w", safeFile, err)
 	}
 
-	return nil
+	return result, nil
 
 }
 
@@ -341,14 +433,29 @@ func MakeHeaderIndex(header []string) HeaderIndex {
 	return idx
 }
 
+// FieldWarning describes a check that flagged a field without rejecting the row.
+type FieldWarning struct {
+	Field   string
+	Value   string
+	Message string
+}
+
 func validateRow(row []string, headerIdx HeaderIndex, specs []schema.FieldSpec) (ValidatedRow, error) {
+	out, _, err := checkRow(row, headerIdx, specs)
+	return out, err
+}
+
+// checkRow validates a row against its specs and collects warnings from
+// checks with SeverityWarning. Checks with SeverityError fail the row.
+func checkRow(row []string, headerIdx HeaderIndex, specs []schema.FieldSpec) (ValidatedRow, []FieldWarning, error) {
 	out := make(ValidatedRow, len(specs))
+	var warnings []FieldWarning
 
 	for _, spec := range specs {
 		pos, ok := headerIdx[strings.ToLower(spec.Name)]
 		if !ok || pos >= len(row) {
 			if spec.Required {
-				return nil, fmt.Errorf("missing required column %q", spec.Name)
+				return nil, nil, fmt.Errorf("missing required column %q", spec.Name)
 			}
 			continue
 		}
@@ -356,15 +463,22 @@ func validateRow(row []string, headerIdx HeaderIndex, specs []schema.FieldSpec)
 		// If Required but not AllowEmpty, reject empty values
 		// If Required and AllowEmpty, accept empty (will become NULL in DB)
 		if raw == "" && spec.Required && !spec.AllowEmpty {
-			return nil, fmt.Errorf("empty required field %q", spec.Name)
+			return nil, nil, fmt.Errorf("empty required field %q", spec.Name)
 		}
 
-		if spec.Normalizer != nil {
-			raw = spec.Normalizer(raw)
+		original := raw
+		raw, err := spec.Normalize(raw)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		// Skip type validation for empty values when AllowEmpty is true
 		if raw == "" && spec.AllowEmpty {
+			found, err := runChecks(spec, original, raw)
+			if err != nil {
+				return nil, nil, err
+			}
+			warnings = append(warnings, found...)
 			out[spec.Name] = raw
 			continue
 		}
@@ -379,29 +493,80 @@ func validateRow(row []string, headerIdx HeaderIndex, specs []schema.FieldSpec)
 					break
 				}
 			}
-			if !valid && spec.Required {
-				return nil, fmt.Errorf("invalid enum for %q: %q", spec.Name, raw)
+			if !valid {
+				if spec.Required {
+					return nil, nil, fmt.Errorf("invalid enum for %q: %q", spec.Name, raw)
+				}
+				warnings = append(warnings, FieldWarning{
+					Field:   spec.Name,
+					Value:   original,
+					Message: fmt.Sprintf("unknown enum value %q", raw),
+				})
 			}
 		case schema.FieldDate:
 			if spec.Required && !ToPgDate(raw).Valid {
-				return nil, fmt.Errorf("invalid date for %q: %q", spec.Name, raw)
+				return nil, nil, fmt.Errorf("invalid date for %q: %q", spec.Name, raw)
 			}
 		case schema.FieldNumeric:
 			if spec.Required && !ToPgNumeric(raw).Valid {
-				return nil, fmt.Errorf("invalid numeric for %q: %q", spec.Name, raw)
+				return nil, nil, fmt.Errorf("invalid numeric for %q: %q", spec.Name, raw)
 			}
 		case schema.FieldBool:
 			if spec.Required && !ToPgBool(raw).Valid {
-				return nil, fmt.Errorf("invalid bool for %q: %q", spec.Name, raw)
+				return nil, nil, fmt.Errorf("invalid bool for %q: %q", spec.Name, raw)
 			}
 		case schema.FieldText:
 			// no-op
 		}
 
+		found, err := runChecks(spec, original, raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		warnings = append(warnings, found...)
+
 		out[spec.Name] = raw
 	}
 
-	return out, nil
+	return out, warnings, nil
+}
+
+// runChecks evaluates the spec's additional checks. The first failing check
+// with SeverityError is returned as an error; warnings are collected.
+func runChecks(spec schema.FieldSpec, original, value string) ([]FieldWarning, error) {
+	var warnings []FieldWarning
+	for _, check := range spec.Checks {
+		msg := evalCheck(check, original, value)
+		if msg == "" {
+			continue
+		}
+		if check.Severity == schema.SeverityError {
+			return nil, fmt.Errorf("%q: %s", spec.Name, msg)
+		}
+		warnings = append(warnings, FieldWarning{Field: spec.Name, Value: original, Message: msg})
+	}
+	return warnings, nil
+}
+
+// evalCheck returns a description of the violation, or "" if the check passes.
+func evalCheck(check schema.Check, original, value string) string {
+	switch check.Kind {
+	case schema.CheckNormalized:
+		if value != original {
+			return fmt.Sprintf("normalized %q to %q", original, value)
+		}
+	case schema.CheckEmptied:
+		if original != "" && value == "" {
+			return fmt.Sprintf("%q has no valid value after normalizing", original)
+		}
+	case schema.CheckFutureDate:
+		d := ToPgDate(value)
+		limit := time.Now().AddDate(0, 0, check.MaxDays)
+		if d.Valid && d.Time.After(limit) {
+			return fmt.Sprintf("date %s is more than %d days in the future", d.Time.Format("2006-01-02"), check.MaxDays)
+		}
+	}
+	return ""
 }
 
 
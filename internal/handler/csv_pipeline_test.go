@@ -1,13 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/JonMunkholm/TUI/internal/csv"
 	"github.com/JonMunkholm/TUI/internal/schema"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 /* ========================================
@@ -1578,3 +1582,163 @@ TXN003,Customer C,$300.00,2024-01-03`
 	}
 }
 
+
+/* ========================================
+	checkRow Warning Tests
+======================================== */
+
+func TestCheckRow_Warnings(t *testing.T) {
+	future := time.Now().AddDate(2, 0, 0).Format("2006-01-02")
+
+	specs := []schema.FieldSpec{
+		{Name: "status", Type: schema.FieldEnum, EnumValues: []string{"Open", "Closed"}, AllowEmpty: true},
+		{Name: "internal_id", Type: schema.FieldText, AllowEmpty: true,
+			Normalizers: []string{schema.NormDigits}, Checks: []schema.Check{schema.WarnIfNormalized()}},
+		{Name: "document_date", Type: schema.FieldDate, AllowEmpty: true,
+			Checks: []schema.Check{schema.WarnIfFutureDate(365)}},
+	}
+	headerIdx := MakeHeaderIndex([]string{"status", "internal_id", "document_date"})
+
+	tests := []struct {
+		name   string
+		row    []string
+		fields []string
+	}{
+		{"clean row", []string{"open", "123", "2024-01-15"}, nil},
+		{"unknown enum", []string{"Pending", "123", "2024-01-15"}, []string{"status"}},
+		{"normalized id", []string{"Closed", "ID-123", "2024-01-15"}, []string{"internal_id"}},
+		{"normalized to empty", []string{"Closed", "N/A", ""}, []string{"internal_id"}},
+		{"future date", []string{"Closed", "123", future}, []string{"document_date"}},
+		{"all three", []string{"Pending", "ID-123", future}, []string{"status", "internal_id", "document_date"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vrow, warnings, err := checkRow(tt.row, headerIdx, specs)
+			if err != nil {
+				t.Fatalf("checkRow() error = %v, expected warnings only", err)
+			}
+			if vrow == nil {
+				t.Fatal("checkRow() returned nil row")
+			}
+			if len(warnings) != len(tt.fields) {
+				t.Fatalf("checkRow() returned %d warnings %v, expected %d", len(warnings), warnings, len(tt.fields))
+			}
+			for i, w := range warnings {
+				if w.Field != tt.fields[i] {
+					t.Errorf("warning[%d].Field = %q, expected %q", i, w.Field, tt.fields[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckRow_ErrorSeverity(t *testing.T) {
+	specs := []schema.FieldSpec{
+		{Name: "internal_id", Type: schema.FieldText, AllowEmpty: true,
+			Normalizers: []string{schema.NormDigits},
+			Checks:      []schema.Check{{Kind: schema.CheckNormalized, Severity: schema.SeverityError}}},
+	}
+	headerIdx := MakeHeaderIndex([]string{"internal_id"})
+
+	if _, _, err := checkRow([]string{"ID-1"}, headerIdx, specs); err == nil {
+		t.Error("checkRow() expected error for SeverityError check, got nil")
+	}
+
+	if _, err := validateRow([]string{"1"}, headerIdx, specs); err != nil {
+		t.Errorf("validateRow() unexpected error for unchanged value: %v", err)
+	}
+}
+
+func TestCheckRow_RejectIfEmptied(t *testing.T) {
+	specs := schema.Resolve([]schema.FieldSpec{
+		{Name: "internal_id", Type: schema.FieldText, AllowEmpty: true,
+			Normalizers: []string{schema.NormDigits},
+			Checks:      []schema.Check{schema.WarnIfNormalized(), schema.RejectIfEmptied()}},
+	})
+	headerIdx := MakeHeaderIndex([]string{"internal_id"})
+
+	if _, _, err := checkRow([]string{"N/A"}, headerIdx, specs); err == nil {
+		t.Error("checkRow() expected error for an id with no digits, got nil")
+	}
+	if _, warnings, err := checkRow([]string{"ID-7"}, headerIdx, specs); err != nil || len(warnings) != 1 {
+		t.Errorf("checkRow(ID-7) = %v, %v, expected one warning", warnings, err)
+	}
+	if _, warnings, err := checkRow([]string{""}, headerIdx, specs); err != nil || len(warnings) != 0 {
+		t.Errorf("checkRow(empty) = %v, %v, expected no warnings", warnings, err)
+	}
+}
+
+func TestCheckRow_CustomerKeyRequired(t *testing.T) {
+	tests := []struct {
+		name  string
+		specs []schema.FieldSpec
+		key   string
+	}{
+		{"ns_customers", schema.NsCustomerFieldSpecs, "internal_id"},
+		{"sfdc_customers", schema.SfdcCustomerFieldSpecs, "account_id_casesafe"},
+	}
+
+	for _, tt := range tests {
+		specs := schema.Resolve(tt.specs)
+		headerIdx := MakeHeaderIndex([]string{tt.key})
+		if _, _, err := checkRow([]string{""}, headerIdx, specs); err == nil || !strings.Contains(err.Error(), tt.key) {
+			t.Errorf("%s: checkRow(blank key) error = %v, expected the row rejected", tt.name, err)
+		}
+	}
+}
+
+func TestUploadSummary(t *testing.T) {
+	summary := UploadSummary([]FileResult{
+		{File: "a.csv", Skipped: true},
+		{File: "b.csv", Imported: 10, Failed: 2, Warnings: map[string]int{"date": 3, "internal_id": 1}},
+	})
+
+	for _, want := range []string{
+		"a.csv: already imported, skipped",
+		"b.csv: 10 imported, 2 failed, 4 warnings",
+		"warning: date (3)",
+		"warning: internal_id (1)",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("UploadSummary() missing %q in:\n%s", want, summary)
+		}
+	}
+}
+
+func TestInsertErrorMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			"unique violation",
+			&pgconn.PgError{Code: "23505", Detail: "Key (transaction_id)=(T-100) already exists.", ConstraintName: "anrok_transactions_transaction_id_key"},
+			"duplicate key: (transaction_id)=(T-100) already exists",
+		},
+		{
+			"wrapped unique violation without detail",
+			fmt.Errorf("insert: %w", &pgconn.PgError{Code: "23505", ConstraintName: "ns_customers_internal_id_key"}),
+			"duplicate key: violates ns_customers_internal_id_key",
+		},
+		{
+			"other pg error",
+			&pgconn.PgError{Severity: "ERROR", Code: "22001", Message: "value too long"},
+			"db error: ERROR: value too long (SQLSTATE 22001)",
+		},
+		{
+			"plain error",
+			errors.New("conn closed"),
+			"db error: conn closed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := insertErrorMessage(tt.err); got != tt.expected {
+				t.Errorf("insertErrorMessage(%v) = %q, expected %q", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// snapshotDateRegex finds an export date in a file name: 2025-03-31 or 20250331.
+var snapshotDateRegex = regexp.MustCompile(`(?:^|\D)(\d{4})-?(\d{2})-?(\d{2})(?:\D|$)`)
+
+// SnapshotTime returns the time a customer snapshot was taken: the date in
+// the file name (midnight local time) when it has one, otherwise now.
+func SnapshotTime(file string, now time.Time) time.Time {
+	m := snapshotDateRegex.FindStringSubmatch(filepath.Base(file))
+	if m == nil {
+		return now
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", m[1]+"-"+m[2]+"-"+m[3], time.Local)
+	if err != nil {
+		return now
+	}
+	return t
+}
+
+// checkSnapshotOrder rejects a snapshot that is not newer than the latest
+// version in a history table. An older or same-date file would overwrite the
+// live table without recording a version, leaving history out of step.
+func checkSnapshotOrder(table string, at, latest pgtype.Timestamp) error {
+	if latest.Valid && !at.Time.After(latest.Time) {
+		return fmt.Errorf("%s snapshot dated %s is not newer than the latest version (%s); import snapshots in date order",
+			table, at.Time.Format("2006-01-02 15:04"), latest.Time.Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+// nsCustomerHistory closes changed versions in ns_customers_history and opens
+// new ones from the customers just upserted.
+func nsCustomerHistory(ctx context.Context, queries *db.Queries, file string) error {
+	at := pgtype.Timestamp{Time: SnapshotTime(file, time.Now()), Valid: true}
+
+	latest, err := queries.LatestNsCustomerSnapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("reading ns customer history: %w", err)
+	}
+	if err := checkSnapshotOrder("ns_customers", at, latest); err != nil {
+		return err
+	}
+
+	if err := queries.CloseNsCustomerHistory(ctx, at); err != nil {
+		return fmt.Errorf("closing ns customer history: %w", err)
+	}
+	if err := queries.OpenNsCustomerHistory(ctx, at); err != nil {
+		return fmt.Errorf("opening ns customer history: %w", err)
+	}
+	return nil
+}
+
+// sfdcCustomerHistory is nsCustomerHistory for sfdc_customers_history.
+func sfdcCustomerHistory(ctx context.Context, queries *db.Queries, file string) error {
+	at := pgtype.Timestamp{Time: SnapshotTime(file, time.Now()), Valid: true}
+
+	latest, err := queries.LatestSfdcCustomerSnapshot(ctx)
+	if err != nil {
+		return fmt.Errorf("reading sfdc customer history: %w", err)
+	}
+	if err := checkSnapshotOrder("sfdc_customers", at, latest); err != nil {
+		return err
+	}
+
+	if err := queries.CloseSfdcCustomerHistory(ctx, at); err != nil {
+		return fmt.Errorf("closing sfdc customer history: %w", err)
+	}
+	if err := queries.OpenSfdcCustomerHistory(ctx, at); err != nil {
+		return fmt.Errorf("opening sfdc customer history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	SnapshotTime Tests
+======================================== */
+
+func TestSnapshotTime(t *testing.T) {
+	now := time.Date(2026, 5, 6, 7, 8, 9, 0, time.Local)
+
+	tests := []struct {
+		file     string
+		expected time.Time
+	}{
+		{"/x/NS/Customers/Customers 2025-03-31.csv", time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local)},
+		{"CustomerList20241231.csv", time.Date(2024, 12, 31, 0, 0, 0, 0, time.Local)},
+		{"/x/2025-01-01/Customers.csv", now}, // Only the file name is considered
+		{"Customers.csv", now},
+		{"Customers 2025-13-45.csv", now},
+		{"Customers 123456789.csv", now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := SnapshotTime(tt.file, now); !got.Equal(tt.expected) {
+				t.Errorf("SnapshotTime(%q) = %v, expected %v", tt.file, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckSnapshotOrder(t *testing.T) {
+	ts := func(day int) pgtype.Timestamp {
+		return pgtype.Timestamp{Time: time.Date(2025, 3, day, 0, 0, 0, 0, time.Local), Valid: true}
+	}
+
+	tests := []struct {
+		name    string
+		at      pgtype.Timestamp
+		latest  pgtype.Timestamp
+		wantErr bool
+	}{
+		{"empty history", ts(1), pgtype.Timestamp{}, false},
+		{"newer", ts(31), ts(1), false},
+		{"same date", ts(31), ts(31), true},
+		{"older", ts(1), ts(31), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSnapshotOrder("ns_customers", tt.at, tt.latest)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkSnapshotOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package handler
+
+import (
+	"context"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/metrics"
+)
+
+// rebuildMetrics recomputes metrics_monthly from the current opportunity lines.
+func rebuildMetrics(ctx context.Context, queries *db.Queries) error {
+	_, err := metrics.Rebuild(ctx, queries)
+	return err
+}
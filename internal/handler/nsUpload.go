@@ -4,6 +4,7 @@ import (
 	"context"
 
 	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/revrec"
 	"github.com/JonMunkholm/TUI/internal/schema"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -43,12 +44,7 @@ func (n *NsUpload) InsertNsInvoiceDetail() tea.Cmd {
 	Build Param functions
 ---------------------------------------- */
 
-func (n *NsUpload) BuildNsCustomerParams(row []string, headerIdx HeaderIndex) (db.InsertNsCustomerParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.NsCustomerFieldSpecs)
-	if err != nil {
-		return db.InsertNsCustomerParams{}, err
-	}
-
+func (n *NsUpload) BuildNsCustomerParams(vrow ValidatedRow) (db.InsertNsCustomerParams, error) {
 	return db.InsertNsCustomerParams{
 		SalesforceIDIo: ToPgText(vrow["salesforce_id_io"]),
 		InternalID:     ToPgText(vrow["internal_id"]),
@@ -62,12 +58,7 @@ func (n *NsUpload) BuildNsCustomerParams(row []string, headerIdx HeaderIndex) (d
 	}, nil
 }
 
-func (n *NsUpload) BuildNsSoDetailParams(row []string, headerIdx HeaderIndex) (db.InsertNsSoDetailParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.NsSoDetailFieldSpecs)
-	if err != nil {
-		return db.InsertNsSoDetailParams{}, err
-	}
-
+func (n *NsUpload) BuildNsSoDetailParams(vrow ValidatedRow) (db.InsertNsSoDetailParams, error) {
 	return db.InsertNsSoDetailParams{
 		SfdcOppID:           ToPgText(vrow["sfdc_opp_id"]),
 		SfdcOppLineID:       ToPgText(vrow["sfdc_opp_line_id"]),
@@ -89,12 +80,7 @@ func (n *NsUpload) BuildNsSoDetailParams(row []string, headerIdx HeaderIndex) (d
 	}, nil
 }
 
-func (n *NsUpload) BuildNsInvoiceDetailParams(row []string, headerIdx HeaderIndex) (db.InsertNsInvoiceDetailParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.NsInvoiceDetailFieldSpecs)
-	if err != nil {
-		return db.InsertNsInvoiceDetailParams{}, err
-	}
-
+func (n *NsUpload) BuildNsInvoiceDetailParams(vrow ValidatedRow) (db.InsertNsInvoiceDetailParams, error) {
 	return db.InsertNsInvoiceDetailParams{
 		SfdcOppID:              ToPgText(vrow["sfdc_opp_id"]),
 		SfdcOppLineID:          ToPgText(vrow["sfdc_opp_line_id"]),
@@ -128,17 +114,23 @@ func (n *NsUpload) BuildNsInvoiceDetailParams(row []string, headerIdx HeaderInde
 func (n *NsUpload) makeDirMap() map[string]CsvProps {
 	return map[string]CsvProps{
 		"Customers": CsvHandler[db.InsertNsCustomerParams]{
-			specs:  schema.NsCustomerFieldSpecs,
-			build:  n.BuildNsCustomerParams,
-			insert: n.insertNsCustomer(),
+			table:   "ns_customers",
+			specs:   schema.Resolve(schema.NsCustomerFieldSpecs),
+			build:   n.BuildNsCustomerParams,
+			insert:  n.insertNsCustomer(),
+			after:   nsCustomerHistory,
+			rebuild: rebuildCrosswalk,
 		},
 		"SoDetail": CsvHandler[db.InsertNsSoDetailParams]{
-			specs:  schema.NsSoDetailFieldSpecs,
-			build:  n.BuildNsSoDetailParams,
-			insert: n.insertNsSoDetail(),
+			table:   "ns_so_detail",
+			specs:   schema.Resolve(schema.NsSoDetailFieldSpecs),
+			build:   n.BuildNsSoDetailParams,
+			insert:  n.insertNsSoDetail(),
+			rebuild: regenerateSchedule(revrec.SourceNS),
 		},
 		"InvoiceDetail": CsvHandler[db.InsertNsInvoiceDetailParams]{
-			specs:  schema.NsInvoiceDetailFieldSpecs,
+			table:  "ns_invoice_detail",
+			specs:  schema.Resolve(schema.NsInvoiceDetailFieldSpecs),
 			build:  n.BuildNsInvoiceDetailParams,
 			insert: n.insertNsInvoiceDetail(),
 		},
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Registration identifies one upload type: the source directory its files are
+// read from and the handler that validates and inserts their rows.
+type Registration struct {
+	Source string   // Top-level upload directory (NS, SFDC, Anrok)
+	Dir    string   // Subdirectory within Source
+	Props  CsvProps // Handler for files in Source/Dir
+}
+
+// Path returns the directory, relative to the uploads root, that files are read from.
+func (r Registration) Path() string {
+	return filepath.Join(r.Source, r.Dir)
+}
+
+// Registrations returns every registered upload type, ordered by source then directory.
+// Admin tools use this to discover tables without duplicating the handler wiring.
+func Registrations(pool *pgxpool.Pool) []Registration {
+	sources := []struct {
+		name    string
+		dirMaps func() map[string]CsvProps
+	}{
+		{"NS", NewNsUpload(pool).makeDirMap},
+		{"SFDC", NewSfdcUpload(pool).makeDirMap},
+		{"Anrok", NewAnrokUpload(pool).makeDirMap},
+	}
+
+	var regs []Registration
+	for _, src := range sources {
+		dirMap := src.dirMaps()
+
+		dirs := make([]string, 0, len(dirMap))
+		for dir := range dirMap {
+			dirs = append(dirs, dir)
+		}
+		sort.Strings(dirs)
+
+		for _, dir := range dirs {
+			regs = append(regs, Registration{Source: src.name, Dir: dir, Props: dirMap[dir]})
+		}
+	}
+	return regs
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"sort"
+	"testing"
+)
+
+/* ========================================
+	Registrations Tests
+======================================== */
+
+func TestRegistrations_UniqueTables(t *testing.T) {
+	seen := make(map[string]string)
+	for _, reg := range Registrations(nil) {
+		table := reg.Props.Table()
+		if table == "" {
+			t.Errorf("%s has no table", reg.Path())
+			continue
+		}
+		if other, ok := seen[table]; ok {
+			t.Errorf("%s and %s both write to %s", other, reg.Path(), table)
+		}
+		seen[table] = reg.Path()
+	}
+}
+
+// FieldSpec columns and sqlc insert params must name the same columns,
+// otherwise BuildParams silently drops or never fills a value.
+func TestRegistrations_SpecsMatchParams(t *testing.T) {
+	for _, reg := range Registrations(nil) {
+		t.Run(reg.Path(), func(t *testing.T) {
+			var specCols []string
+			for _, spec := range reg.Props.Specs() {
+				specCols = append(specCols, spec.ColumnName())
+			}
+			params := reg.Props.ParamColumns()
+
+			sort.Strings(specCols)
+			sort.Strings(params)
+
+			if len(specCols) != len(params) {
+				t.Fatalf("spec columns %v != param columns %v", specCols, params)
+			}
+			for i := range specCols {
+				if specCols[i] != params[i] {
+					t.Errorf("spec column %q != param column %q", specCols[i], params[i])
+				}
+			}
+		})
+	}
+}
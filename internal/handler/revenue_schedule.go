@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"context"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/revrec"
+)
+
+// regenerateSchedule rebuilds the revenue schedule rows of source from its
+// current lines.
+func regenerateSchedule(source string) RebuildFn {
+	return func(ctx context.Context, queries *db.Queries) error {
+		_, err := revrec.Regenerate(ctx, queries, source)
+		return err
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/revrec"
 	"github.com/JonMunkholm/TUI/internal/schema"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -43,12 +44,7 @@ func (s *SfdcUpload) InsertSfdcOppDetail() tea.Cmd {
 	Build Param functions
 ---------------------------------------- */
 
-func (s *SfdcUpload) BuildSfdcCustomerParams(row []string, headerIdx HeaderIndex) (db.InsertSfdcCustomerParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.SfdcCustomerFieldSpecs)
-	if err != nil {
-		return db.InsertSfdcCustomerParams{}, err
-	}
-
+func (s *SfdcUpload) BuildSfdcCustomerParams(vrow ValidatedRow) (db.InsertSfdcCustomerParams, error) {
 	return db.InsertSfdcCustomerParams{
 		AccountIDCasesafe: ToPgText(vrow["account_id_casesafe"]),
 		AccountName:       ToPgText(vrow["account_name"]),
@@ -57,12 +53,7 @@ func (s *SfdcUpload) BuildSfdcCustomerParams(row []string, headerIdx HeaderIndex
 	}, nil
 }
 
-func (s *SfdcUpload) BuildSfdcPriceBookParams(row []string, headerIdx HeaderIndex) (db.InsertSfdcPriceBookParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.SfdcPriceBookFieldSpecs)
-	if err != nil {
-		return db.InsertSfdcPriceBookParams{}, err
-	}
-
+func (s *SfdcUpload) BuildSfdcPriceBookParams(vrow ValidatedRow) (db.InsertSfdcPriceBookParams, error) {
 	return db.InsertSfdcPriceBookParams{
 		PriceBookName:     ToPgText(vrow["price_book_name"]),
 		ListPrice:         ToPgNumeric(vrow["list_price"]),
@@ -72,12 +63,7 @@ func (s *SfdcUpload) BuildSfdcPriceBookParams(row []string, headerIdx HeaderInde
 	}, nil
 }
 
-func (s *SfdcUpload) BuildSfdcOppDetailParams(row []string, headerIdx HeaderIndex) (db.InsertSfdcOppDetailParams, error) {
-	vrow, err := validateRow(row, headerIdx, schema.SfdcOppDetailFieldSpecs)
-	if err != nil {
-		return db.InsertSfdcOppDetailParams{}, err
-	}
-
+func (s *SfdcUpload) BuildSfdcOppDetailParams(vrow ValidatedRow) (db.InsertSfdcOppDetailParams, error) {
 	return db.InsertSfdcOppDetailParams{
 		OpportunityID:                ToPgText(vrow["opportunity_id"]),
 		OpportunityProductCasesafeID: ToPgText(vrow["opportunity_product_casesafe_id"]),
@@ -115,19 +101,25 @@ func (s *SfdcUpload) BuildSfdcOppDetailParams(row []string, headerIdx HeaderInde
 func (s *SfdcUpload) makeDirMap() map[string]CsvProps {
 	return map[string]CsvProps{
 		"Customers": CsvHandler[db.InsertSfdcCustomerParams]{
-			specs:  schema.SfdcCustomerFieldSpecs,
-			build:  s.BuildSfdcCustomerParams,
-			insert: s.insertSfdcCustomer(),
+			table:   "sfdc_customers",
+			specs:   schema.Resolve(schema.SfdcCustomerFieldSpecs),
+			build:   s.BuildSfdcCustomerParams,
+			insert:  s.insertSfdcCustomer(),
+			after:   sfdcCustomerHistory,
+			rebuild: rebuildCrosswalk,
 		},
 		"PriceBook": CsvHandler[db.InsertSfdcPriceBookParams]{
-			specs:  schema.SfdcPriceBookFieldSpecs,
+			table:  "sfdc_price_book",
+			specs:  schema.Resolve(schema.SfdcPriceBookFieldSpecs),
 			build:  s.BuildSfdcPriceBookParams,
 			insert: s.insertSfdcPriceBook(),
 		},
 		"OppDetail": CsvHandler[db.InsertSfdcOppDetailParams]{
-			specs:  schema.SfdcOppDetailFieldSpecs,
-			build:  s.BuildSfdcOppDetailParams,
-			insert: s.insertSfdcOppDetail(),
+			table:   "sfdc_opp_detail",
+			specs:   schema.Resolve(schema.SfdcOppDetailFieldSpecs),
+			build:   s.BuildSfdcOppDetailParams,
+			insert:  s.insertSfdcOppDetail(),
+			rebuild: chainRebuild(regenerateSchedule(revrec.SourceSFDC), rebuildMetrics),
 		},
 	}
 }
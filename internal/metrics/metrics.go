@@ -0,0 +1,232 @@
+// Package metrics derives monthly subscription metrics (MRR, ARR, new,
+// expansion, contraction, churn and bookings) from sfdc_opp_detail and
+// stores them in metrics_monthly.
+//
+// Each opportunity line contributes total_price / term_in_months of MRR to
+// every month it is active at month end. Movements are measured per customer
+// (account name) between consecutive months: a customer going from no MRR to
+// some is new, from some to none (or zero) is churn, and a change in between is
+// expansion or contraction. Lines without an account name cannot be followed
+// between months, so they add to bookings but not to MRR. Amounts are whole
+// cents, rounded like the revenue schedule.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/revrec"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// daysPerMonth converts a service period to months when term_in_months is blank.
+const daysPerMonth = 365.25 / 12
+
+// Contract is one opportunity line's recurring revenue.
+type Contract struct {
+	Customer string
+	Start    time.Time
+	End      time.Time // inclusive
+	MRR      int64     // cents
+}
+
+// Month is the metrics for one calendar month. Amounts are in cents.
+type Month struct {
+	Period      time.Time // first day of the month
+	MRR         int64
+	New         int64
+	Expansion   int64
+	Contraction int64 // positive amount lost
+	Churned     int64 // positive amount lost
+	Bookings    int64
+	Customers   int // customers with MRR at month end
+}
+
+// ARR is the month's MRR annualized.
+func (m Month) ARR() int64 { return m.MRR * 12 }
+
+// Lines counts the opportunity lines Contracts could not turn into contracts.
+type Lines struct {
+	Skipped    int // without a start date, or with neither a term nor an end date
+	Unassigned int // without an account name
+}
+
+// Contracts turns opportunity lines into contracts and sums bookings by the
+// month of booked_date (close_date when blank).
+func Contracts(rows []db.SfdcOppDetail) (contracts []Contract, bookings map[time.Time]int64, lines Lines) {
+	bookings = make(map[time.Time]int64)
+	for _, r := range rows {
+		total, ok := revrec.Cents(r.TotalPrice)
+		if !ok {
+			total, _ = revrec.Cents(r.Amount)
+		}
+
+		if booked := revrec.FirstDate(r.BookedDate, r.CloseDate); !booked.IsZero() {
+			bookings[monthOf(booked)] += total
+		}
+
+		if strings.TrimSpace(r.AccountName.String) == "" {
+			lines.Unassigned++
+			continue
+		}
+		c, ok := contract(r, total)
+		if !ok {
+			lines.Skipped++
+			continue
+		}
+		contracts = append(contracts, c)
+	}
+	return contracts, bookings, lines
+}
+
+func contract(r db.SfdcOppDetail, total int64) (Contract, bool) {
+	start := revrec.FirstDate(r.StartDate, r.ContractStartDate)
+	end := revrec.FirstDate(r.EndDate, r.ContractEndDate)
+	if start.IsZero() {
+		return Contract{}, false
+	}
+
+	term, _ := number(r.TermInMonths)
+	switch {
+	case term <= 0 && end.IsZero():
+		return Contract{}, false
+	case term <= 0:
+		term = math.Max(1, math.Round((end.Sub(start).Hours()/24+1)/daysPerMonth))
+	case end.IsZero():
+		end = start.AddDate(0, int(math.Round(term)), -1)
+	}
+	if end.Before(start) {
+		return Contract{}, false
+	}
+
+	return Contract{
+		Customer: strings.ToUpper(strings.TrimSpace(r.AccountName.String)),
+		Start:    start,
+		End:      end,
+		MRR:      int64(math.Round(float64(total) / term)),
+	}, true
+}
+
+// Compute builds one Month per calendar month from the first start or
+// booking through the month after the last contract ends, so final churn
+// shows up.
+func Compute(contracts []Contract, bookings map[time.Time]int64) []Month {
+	var first, last time.Time
+	extend := func(t time.Time) {
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if last.IsZero() || t.After(last) {
+			last = t
+		}
+	}
+	for _, c := range contracts {
+		extend(monthOf(c.Start))
+		extend(monthOf(c.End).AddDate(0, 1, 0))
+	}
+	for period := range bookings {
+		extend(period)
+	}
+	if first.IsZero() {
+		return nil
+	}
+
+	var months []Month
+	prev := map[string]int64{}
+	for period := first; !period.After(last); period = period.AddDate(0, 1, 0) {
+		monthEnd := period.AddDate(0, 1, -1)
+		cur := map[string]int64{}
+		for _, c := range contracts {
+			if !c.Start.After(monthEnd) && !c.End.Before(monthEnd) {
+				cur[c.Customer] += c.MRR
+			}
+		}
+
+		m := Month{Period: period, Bookings: bookings[period]}
+		for customer, mrr := range cur {
+			m.MRR += mrr
+			if mrr > 0 {
+				m.Customers++
+			}
+			before := prev[customer]
+			switch {
+			case mrr <= 0:
+				if before > 0 {
+					m.Churned += before
+				}
+			case before <= 0:
+				m.New += mrr
+			case mrr > before:
+				m.Expansion += mrr - before
+			case mrr < before:
+				m.Contraction += before - mrr
+			}
+		}
+		for customer, before := range prev {
+			if _, ok := cur[customer]; !ok && before > 0 {
+				m.Churned += before
+			}
+		}
+
+		months = append(months, m)
+		prev = cur
+	}
+	return months
+}
+
+// Result summarizes a rebuild.
+type Result struct {
+	Months    int
+	Contracts int
+	Lines
+}
+
+// Rebuild recomputes metrics_monthly from sfdc_opp_detail, live and
+// archived, so archiving a period keeps its history.
+func Rebuild(ctx context.Context, queries *db.Queries) (Result, error) {
+	rows, err := queries.ListSfdcOppDetailWithArchive(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("loading sfdc_opp_detail: %w", err)
+	}
+
+	contracts, bookings, lines := Contracts(rows)
+	months := Compute(contracts, bookings)
+
+	if err := queries.DeleteMonthlyMetrics(ctx); err != nil {
+		return Result{}, fmt.Errorf("clearing metrics_monthly: %w", err)
+	}
+	for _, m := range months {
+		err := queries.InsertMonthlyMetric(ctx, db.InsertMonthlyMetricParams{
+			Period:         pgtype.Date{Time: m.Period, Valid: true},
+			Mrr:            revrec.Amount(m.MRR),
+			Arr:            revrec.Amount(m.ARR()),
+			NewMrr:         revrec.Amount(m.New),
+			ExpansionMrr:   revrec.Amount(m.Expansion),
+			ContractionMrr: revrec.Amount(m.Contraction),
+			ChurnedMrr:     revrec.Amount(m.Churned),
+			Bookings:       revrec.Amount(m.Bookings),
+			Customers:      int32(m.Customers),
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("saving metrics for %s: %w", m.Period.Format("2006-01"), err)
+		}
+	}
+
+	return Result{Months: len(months), Contracts: len(contracts), Lines: lines}, nil
+}
+
+func monthOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func number(n pgtype.Numeric) (float64, bool) {
+	f, err := n.Float64Value()
+	if err != nil || !f.Valid {
+		return 0, false
+	}
+	return f.Float64, true
+}
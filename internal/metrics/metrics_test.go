@@ -0,0 +1,135 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func ymd(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func pgNum(f float64) pgtype.Numeric {
+	var n pgtype.Numeric
+	if err := n.Scan(fmt.Sprintf("%v", f)); err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func pgDate(t time.Time) pgtype.Date {
+	return pgtype.Date{Time: t, Valid: !t.IsZero()}
+}
+
+/* ========================================
+	Contracts Tests
+======================================== */
+
+func TestContracts(t *testing.T) {
+	rows := []db.SfdcOppDetail{
+		{ // term given
+			AccountName: pgtype.Text{String: " acme ", Valid: true},
+			TotalPrice:  pgNum(1200), TermInMonths: pgNum(12),
+			StartDate: pgDate(ymd(2025, 1, 1)), BookedDate: pgDate(ymd(2024, 12, 20)),
+		},
+		{ // term from dates, booked falls back to close date
+			AccountName: pgtype.Text{String: "Globex", Valid: true},
+			TotalPrice:  pgNum(600),
+			StartDate:   pgDate(ymd(2025, 1, 1)), EndDate: pgDate(ymd(2025, 6, 30)),
+			CloseDate: pgDate(ymd(2024, 12, 5)),
+		},
+		{ // no end and no term
+			AccountName: pgtype.Text{String: "Initech", Valid: true},
+			TotalPrice:  pgNum(100), StartDate: pgDate(ymd(2025, 1, 1)),
+		},
+		{ // no account: booked, but no MRR
+			TotalPrice: pgNum(300), TermInMonths: pgNum(12),
+			StartDate: pgDate(ymd(2025, 1, 1)), BookedDate: pgDate(ymd(2024, 12, 1)),
+		},
+		{ // rounds to the cent like the revenue schedule
+			AccountName: pgtype.Text{String: "Umbrella", Valid: true},
+			TotalPrice:  pgNum(100), TermInMonths: pgNum(3),
+			StartDate: pgDate(ymd(2025, 1, 1)),
+		},
+	}
+
+	contracts, bookings, lines := Contracts(rows)
+
+	if lines.Skipped != 1 || lines.Unassigned != 1 || len(contracts) != 3 {
+		t.Fatalf("Contracts() = %d contracts, %+v; expected 3, 1 skipped and 1 unassigned", len(contracts), lines)
+	}
+	if c := contracts[0]; c.Customer != "ACME" || c.MRR != 10000 || !c.End.Equal(ymd(2025, 12, 31)) {
+		t.Errorf("contracts[0] = %+v, expected ACME at 10000 cents/month through 2025-12-31", c)
+	}
+	if c := contracts[1]; c.MRR != 10000 {
+		t.Errorf("contracts[1].MRR = %v, expected 10000 cents over 6 months", c.MRR)
+	}
+	if c := contracts[2]; c.MRR != 3333 {
+		t.Errorf("contracts[2].MRR = %v, expected 3333 cents", c.MRR)
+	}
+	if got := bookings[ymd(2024, 12, 1)]; got != 210000 {
+		t.Errorf("December bookings = %v, expected 210000 cents", got)
+	}
+}
+
+/* ========================================
+	Compute Tests
+======================================== */
+
+func TestCompute_Movements(t *testing.T) {
+	contracts := []Contract{
+		{Customer: "A", Start: ymd(2025, 1, 1), End: ymd(2025, 3, 31), MRR: 100},
+		{Customer: "A", Start: ymd(2025, 2, 1), End: ymd(2025, 2, 28), MRR: 50},  // expansion in Feb, contraction in Mar
+		{Customer: "B", Start: ymd(2025, 2, 10), End: ymd(2025, 2, 28), MRR: 30}, // new in Feb, churned in Mar
+	}
+
+	months := Compute(contracts, map[time.Time]int64{ymd(2025, 1, 1): 500})
+
+	expected := []Month{
+		{Period: ymd(2025, 1, 1), MRR: 100, New: 100, Bookings: 500, Customers: 1},
+		{Period: ymd(2025, 2, 1), MRR: 180, New: 30, Expansion: 50, Customers: 2},
+		{Period: ymd(2025, 3, 1), MRR: 100, Contraction: 50, Churned: 30, Customers: 1},
+		{Period: ymd(2025, 4, 1), MRR: 0, Churned: 100},
+	}
+	if len(months) != len(expected) {
+		t.Fatalf("len(months) = %d, expected %d", len(months), len(expected))
+	}
+	for i, want := range expected {
+		got := months[i]
+		if got != want {
+			t.Errorf("months[%d] = %+v, expected %+v", i, got, want)
+		}
+	}
+	if arr := months[1].ARR(); arr != 2160 {
+		t.Errorf("February ARR = %v, expected 2160", arr)
+	}
+}
+
+func TestCompute_ZeroMRRIsChurn(t *testing.T) {
+	contracts := []Contract{
+		{Customer: "A", Start: ymd(2025, 1, 1), End: ymd(2025, 1, 31), MRR: 100},
+		{Customer: "A", Start: ymd(2025, 2, 1), End: ymd(2025, 2, 28), MRR: 0}, // still present, nothing billed
+	}
+
+	months := Compute(contracts, nil)
+
+	if len(months) != 3 {
+		t.Fatalf("len(months) = %d, expected 3", len(months))
+	}
+	if got := months[1]; got.Churned != 100 || got.Contraction != 0 || got.Customers != 0 {
+		t.Errorf("months[1] = %+v, expected 100 churned and no contraction", got)
+	}
+	if got := months[2]; got.Churned != 0 {
+		t.Errorf("months[2].Churned = %v, expected 0 after the customer already churned", got.Churned)
+	}
+}
+
+func TestCompute_Empty(t *testing.T) {
+	if months := Compute(nil, nil); months != nil {
+		t.Errorf("Compute(nil, nil) = %v, expected nil", months)
+	}
+}
@@ -0,0 +1,353 @@
+// Package migrate applies goose-annotated migrations from an embedded filesystem.
+// It reads and writes goose's goose_db_version table, so databases migrated with
+// the goose CLI and databases migrated by the app stay interchangeable.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// VersionTable is the goose version table name.
+const VersionTable = "goose_db_version"
+
+// lockID serializes migration runs across app instances (pg_advisory_xact_lock).
+const lockID = 0x7475695f6d6967 // "tui_mig"
+
+// Migration is one parsed NNN_name.sql file.
+type Migration struct {
+	Version int64
+	File    string
+	Up      string
+	Down    string
+}
+
+// Status pairs a migration with whether it has been applied.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Load reads and parses every NNN_name.sql file in dir, ordered by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []Migration
+	seen := make(map[int64]string)
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != ".sql" {
+			continue
+		}
+		version, ok := ParseVersion(e.Name())
+		if !ok {
+			continue
+		}
+		if prev, dup := seen[version]; dup {
+			return nil, fmt.Errorf("duplicate migration version %d: %s and %s", version, prev, e.Name())
+		}
+		seen[version] = e.Name()
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		m, err := Parse(e.Name(), string(content))
+		if err != nil {
+			return nil, err
+		}
+		m.Version = version
+		migrations = append(migrations, m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// ParseVersion extracts the numeric prefix of a migration file name ("009_x.sql" -> 9).
+func ParseVersion(name string) (int64, bool) {
+	prefix, _, ok := strings.Cut(name, "_")
+	if !ok {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(prefix, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// Parse splits a goose file into its Up and Down sections. StatementBegin/End
+// markers are dropped: each section runs as a single multi-statement exec.
+func Parse(file, content string) (Migration, error) {
+	m := Migration{File: file}
+
+	var up, down strings.Builder
+	var section *strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if annotation, ok := strings.CutPrefix(trimmed, "-- +goose "); ok {
+			switch strings.TrimSpace(annotation) {
+			case "Up":
+				section = &up
+			case "Down":
+				section = &down
+			case "StatementBegin", "StatementEnd":
+			default:
+				return m, fmt.Errorf("%s: unsupported goose annotation %q", file, trimmed)
+			}
+			continue
+		}
+		if section != nil {
+			section.WriteString(line)
+			section.WriteByte('\n')
+		}
+	}
+
+	m.Up = strings.TrimSpace(up.String())
+	m.Down = strings.TrimSpace(down.String())
+	if m.Up == "" {
+		return m, fmt.Errorf("%s: missing -- +goose Up section", file)
+	}
+	return m, nil
+}
+
+// Runner applies migrations to a database.
+type Runner struct {
+	Pool       *pgxpool.Pool
+	Migrations []Migration
+}
+
+// New loads migrations from fsys/dir and returns a runner for pool.
+func New(pool *pgxpool.Pool, fsys fs.FS, dir string) (*Runner, error) {
+	migrations, err := Load(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading migrations: %w", err)
+	}
+	return &Runner{Pool: pool, Migrations: migrations}, nil
+}
+
+// Status reports every known migration with its applied state.
+func (r *Runner) Status(ctx context.Context) ([]Status, error) {
+	applied, err := r.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return statuses(r.Migrations, applied), nil
+}
+
+// Pending returns migrations that have not been applied, in order.
+func (r *Runner) Pending(ctx context.Context) ([]Migration, error) {
+	status, err := r.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pending(status), nil
+}
+
+// Up applies every pending migration, each in its own transaction.
+// It stops at the first failure and returns the migrations applied before it.
+func (r *Runner) Up(ctx context.Context) ([]Migration, error) {
+	if err := r.ensureVersionTable(ctx); err != nil {
+		return nil, err
+	}
+
+	todo, err := r.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, m := range todo {
+		err := r.inTx(ctx, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, m.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO goose_db_version (version_id, is_applied) VALUES ($1, true)`, m.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("applying %s: %w", m.File, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// Down rolls back the most recently applied migration. It returns false when
+// nothing is applied.
+func (r *Runner) Down(ctx context.Context) (Migration, bool, error) {
+	status, err := r.Status(ctx)
+	if err != nil {
+		return Migration{}, false, err
+	}
+
+	m, ok := latestApplied(status)
+	if !ok {
+		return Migration{}, false, nil
+	}
+	if m.Down == "" {
+		return m, false, fmt.Errorf("%s has no -- +goose Down section", m.File)
+	}
+
+	err = r.inTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, m.Down); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM goose_db_version WHERE version_id = $1`, m.Version)
+		return err
+	})
+	if err != nil {
+		return m, false, fmt.Errorf("rolling back %s: %w", m.File, err)
+	}
+	return m, true, nil
+}
+
+// inTx runs fn in a transaction holding the migration advisory lock.
+func (r *Runner) inTx(ctx context.Context, fn func(pgx.Tx) error) error {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(lockID)); err != nil {
+		return fmt.Errorf("acquiring migration lock: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ensureVersionTable creates goose_db_version the way goose does, including
+// its version 0 row.
+func (r *Runner) ensureVersionTable(ctx context.Context) error {
+	exists, err := r.versionTableExists(ctx)
+	if err != nil || exists {
+		return err
+	}
+
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `
+			CREATE TABLE IF NOT EXISTS goose_db_version (
+				id SERIAL PRIMARY KEY,
+				version_id BIGINT NOT NULL,
+				is_applied BOOLEAN NOT NULL,
+				tstamp TIMESTAMP DEFAULT NOW()
+			)`); err != nil {
+			return fmt.Errorf("creating %s: %w", VersionTable, err)
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO goose_db_version (version_id, is_applied)
+			SELECT 0, true WHERE NOT EXISTS (SELECT 1 FROM goose_db_version)`)
+		return err
+	})
+}
+
+func (r *Runner) versionTableExists(ctx context.Context) (bool, error) {
+	var exists bool
+	if err := r.Pool.QueryRow(ctx, `SELECT to_regclass('goose_db_version') IS NOT NULL`).Scan(&exists); err != nil {
+		return false, fmt.Errorf("checking %s: %w", VersionTable, err)
+	}
+	return exists, nil
+}
+
+// applied returns version -> time applied. The most recent row for a version
+// decides its state, matching goose.
+func (r *Runner) applied(ctx context.Context) (map[int64]time.Time, error) {
+	exists, err := r.versionTableExists(ctx)
+	if err != nil || !exists {
+		return map[int64]time.Time{}, err
+	}
+
+	rows, err := r.Pool.Query(ctx, `SELECT version_id, is_applied, tstamp FROM goose_db_version ORDER BY id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", VersionTable, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]time.Time)
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		var isApplied bool
+		var tstamp *time.Time
+		if err := rows.Scan(&version, &isApplied, &tstamp); err != nil {
+			return nil, err
+		}
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+		if isApplied {
+			var at time.Time
+			if tstamp != nil {
+				at = *tstamp
+			}
+			applied[version] = at
+		}
+	}
+	return applied, rows.Err()
+}
+
+/* ----------------------------------------
+	Pure helpers
+---------------------------------------- */
+
+func statuses(migrations []Migration, applied map[int64]time.Time) []Status {
+	out := make([]Status, 0, len(migrations))
+	for _, m := range migrations {
+		at, ok := applied[m.Version]
+		out = append(out, Status{Migration: m, Applied: ok, AppliedAt: at})
+	}
+	return out
+}
+
+func pending(status []Status) []Migration {
+	var out []Migration
+	for _, s := range status {
+		if !s.Applied {
+			out = append(out, s.Migration)
+		}
+	}
+	return out
+}
+
+func latestApplied(status []Status) (Migration, bool) {
+	for i := len(status) - 1; i >= 0; i-- {
+		if status[i].Applied {
+			return status[i].Migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// ErrSchemaBehind is returned by guards when migrations are pending.
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// Behind returns an error wrapping ErrSchemaBehind describing pending migrations.
+func Behind(pending []Migration) error {
+	if len(pending) == 0 {
+		return nil
+	}
+	files := make([]string, len(pending))
+	for i, m := range pending {
+		files[i] = m.File
+	}
+	return fmt.Errorf("%w: %d pending migration(s) (%s); apply them from Admin -> Migrations",
+		ErrSchemaBehind, len(pending), strings.Join(files, ", "))
+}
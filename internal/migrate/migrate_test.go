@@ -0,0 +1,164 @@
+package migrate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	sqlfiles "github.com/JonMunkholm/TUI/sql"
+)
+
+/* ========================================
+	Parse Tests
+======================================== */
+
+func TestParse(t *testing.T) {
+	content := `-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE t (id INT);
+-- +goose StatementEnd
+CREATE INDEX t_idx ON t (id);
+
+-- +goose Down
+DROP TABLE IF EXISTS t;
+`
+	m, err := Parse("001_t.sql", content)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if m.Up != "CREATE TABLE t (id INT);\nCREATE INDEX t_idx ON t (id);" {
+		t.Errorf("Up = %q", m.Up)
+	}
+	if m.Down != "DROP TABLE IF EXISTS t;" {
+		t.Errorf("Down = %q", m.Down)
+	}
+}
+
+func TestParse_Negative(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"no up section", "CREATE TABLE t (id INT);"},
+		{"empty up section", "-- +goose Up\n-- +goose Down\nDROP TABLE t;"},
+		{"unsupported annotation", "-- +goose Up\n-- +goose NO TRANSACTION\nSELECT 1;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse("001_t.sql", tt.content); err == nil {
+				t.Error("Parse() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected int64
+		ok       bool
+	}{
+		{"009_import_warnings.sql", 9, true},
+		{"20240101120000_big.sql", 20240101120000, true},
+		{"notes.sql", 0, false},
+		{"abc_def.sql", 0, false},
+		{"000_zero.sql", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseVersion(tt.name)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("ParseVersion(%q) = (%d, %v), expected (%d, %v)", tt.name, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+/* ========================================
+	Load Tests
+======================================== */
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schema/010_ten.sql":    {Data: []byte("-- +goose Up\nSELECT 10;")},
+		"schema/002_two.sql":    {Data: []byte("-- +goose Up\nSELECT 2;")},
+		"schema/notes.txt":      {Data: []byte("ignored")},
+		"schema/no_version.sql": {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys, "schema")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 2 || migrations[1].Version != 10 {
+		t.Errorf("Load() = %v, expected versions [2 10]", migrations)
+	}
+}
+
+func TestLoad_DuplicateVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schema/002_a.sql": {Data: []byte("-- +goose Up\nSELECT 1;")},
+		"schema/002_b.sql": {Data: []byte("-- +goose Up\nSELECT 2;")},
+	}
+
+	if _, err := Load(fsys, "schema"); err == nil {
+		t.Error("Load() expected duplicate version error, got nil")
+	}
+}
+
+// Every shipped migration must parse and be reversible.
+func TestLoad_EmbeddedMigrations(t *testing.T) {
+	migrations, err := Load(sqlfiles.Migrations, sqlfiles.MigrationsDir)
+	if err != nil {
+		t.Fatalf("Load(embedded) error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations found")
+	}
+	for _, m := range migrations {
+		if m.Down == "" {
+			t.Errorf("%s has no Down section", m.File)
+		}
+	}
+}
+
+/* ========================================
+	Status Tests
+======================================== */
+
+func TestStatuses(t *testing.T) {
+	migrations := []Migration{{Version: 1, File: "001_a.sql"}, {Version: 2, File: "002_b.sql"}, {Version: 3, File: "003_c.sql"}}
+	at := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	status := statuses(migrations, map[int64]time.Time{1: at, 2: at})
+
+	if p := pending(status); len(p) != 1 || p[0].Version != 3 {
+		t.Errorf("pending() = %v, expected [3]", p)
+	}
+	if m, ok := latestApplied(status); !ok || m.Version != 2 {
+		t.Errorf("latestApplied() = (%v, %v), expected version 2", m, ok)
+	}
+
+	if _, ok := latestApplied(statuses(migrations, nil)); ok {
+		t.Error("latestApplied() with nothing applied expected false")
+	}
+}
+
+func TestBehind(t *testing.T) {
+	if err := Behind(nil); err != nil {
+		t.Errorf("Behind(nil) = %v, expected nil", err)
+	}
+
+	err := Behind([]Migration{{File: "010_a.sql"}, {File: "011_b.sql"}})
+	if !errors.Is(err, ErrSchemaBehind) {
+		t.Errorf("Behind() = %v, expected ErrSchemaBehind", err)
+	}
+	if !strings.Contains(err.Error(), "2 pending migration(s) (010_a.sql, 011_b.sql)") {
+		t.Errorf("Behind() = %q", err)
+	}
+}
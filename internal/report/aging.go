@@ -0,0 +1,314 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ----------------------------------------
+	ACCOUNTS RECEIVABLE AGING
+---------------------------------------- */
+
+// AgingBuckets are the aging columns, by days past due.
+var AgingBuckets = []string{"Current", "1-30", "31-60", "61-90", "90+"}
+
+// agingBucket returns the AgingBuckets index for a number of days past due.
+func agingBucket(daysPastDue int) int {
+	switch {
+	case daysPastDue <= 0:
+		return 0
+	case daysPastDue <= 30:
+		return 1
+	case daysPastDue <= 60:
+		return 2
+	case daysPastDue <= 90:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// CustomerBalance is NetSuite's own view of a customer's receivable.
+type CustomerBalance struct {
+	InternalID  string
+	Name        string
+	Balance     float64
+	Overdue     float64
+	DaysOverdue float64
+}
+
+// BalancesFromHistory reads balances from ns_customers_history versions.
+func BalancesFromHistory(rows []db.NsCustomersHistory) []CustomerBalance {
+	balances := make([]CustomerBalance, len(rows))
+	for i, c := range rows {
+		balances[i] = CustomerBalance{
+			InternalID: strings.TrimSpace(c.InternalID), Name: text(c.Name),
+			Balance: num(c.Balance), Overdue: num(c.OverdueBalance), DaysOverdue: num(c.DaysOverdue),
+		}
+	}
+	return balances
+}
+
+// BalancesFromCustomers reads balances from the current ns_customers snapshot.
+func BalancesFromCustomers(rows []db.NsCustomer) []CustomerBalance {
+	var balances []CustomerBalance
+	for _, c := range rows {
+		if id := text(c.InternalID); id != "" {
+			balances = append(balances, CustomerBalance{
+				InternalID: id, Name: text(c.Name),
+				Balance: num(c.Balance), Overdue: num(c.OverdueBalance), DaysOverdue: num(c.DaysOverdue),
+			})
+		}
+	}
+	return balances
+}
+
+// AgingRow is one customer's open invoices bucketed by days past due.
+type AgingRow struct {
+	Customer CustomerBalance
+	Buckets  []float64 // one per AgingBuckets entry
+	Invoices int       // open invoices
+	Known    bool      // the customer is in the balance snapshot
+}
+
+// Total is the sum of all buckets.
+func (r AgingRow) Total() float64 {
+	total := 0.0
+	for _, b := range r.Buckets {
+		total += b
+	}
+	return total
+}
+
+// PastDue is the sum of every bucket except Current.
+func (r AgingRow) PastDue() float64 { return r.Total() - r.Buckets[0] }
+
+// isPayment reports whether the line is a customer payment or deposit.
+func isPayment(line db.NsInvoiceDetail) bool {
+	t := strings.ToLower(text(line.Type))
+	return strings.Contains(t, "payment") || strings.Contains(t, "deposit")
+}
+
+// AgeReceivables sums open invoices per customer into aging buckets as of
+// asOf. Lines are grouped into documents by type and document number,
+// including tax lines, since NetSuite numbers each transaction type on its
+// own. Credit memos and payments are applied to the customer's invoices
+// oldest due first; fully paid invoices drop out and unapplied credit
+// subtracts from Current. ns_invoice_detail does not record which invoice a
+// payment settled, so this matches NetSuite only when the export includes
+// the payments, and invoices paid outside it still count as open.
+//
+// Days past due count from date_due, or the invoice date when it has none.
+// Documents dated after asOf are left out. Every customer with a balance
+// appears, even without invoices.
+func AgeReceivables(lines []db.NsInvoiceDetail, balances []CustomerBalance, asOf time.Time) []AgingRow {
+	type document struct {
+		customer, name string
+		due            time.Time
+		amount         float64
+		credit         bool
+	}
+	type docKey struct{ kind, number string }
+	docs := make(map[docKey]*document)
+	names := make(map[string]string)
+	var order []docKey
+	for _, line := range lines {
+		doc := docKey{strings.ToLower(text(line.Type)), text(line.DocumentNumber)}
+		if doc.number == "" {
+			continue
+		}
+		invDate := date(line.Date)
+		if !invDate.IsZero() && invDate.After(asOf) {
+			continue
+		}
+
+		d, ok := docs[doc]
+		if !ok {
+			d = &document{customer: text(line.CustomerInternalID), name: text(line.Name), due: date(line.DateDue),
+				credit: isCreditMemo(line) || isPayment(line)}
+			if d.due.IsZero() {
+				d.due = invDate
+			}
+			if _, ok := names[d.customer]; !ok {
+				names[d.customer] = d.name
+			}
+			docs[doc] = d
+			order = append(order, doc)
+		}
+
+		amount := num(line.Amount)
+		if d.credit {
+			amount = -math.Abs(amount)
+		}
+		d.amount += amount
+	}
+
+	// Apply each customer's credits to its oldest invoices.
+	credits := make(map[string]float64)
+	var open []*document
+	for _, doc := range order {
+		d := docs[doc]
+		if d.amount < 0 || d.credit {
+			credits[d.customer] -= d.amount
+			continue
+		}
+		open = append(open, d)
+	}
+	sort.SliceStable(open, func(i, j int) bool { return open[i].due.Before(open[j].due) })
+	for _, d := range open {
+		applied := math.Min(credits[d.customer], d.amount)
+		d.amount -= applied
+		credits[d.customer] -= applied
+	}
+
+	rows := make(map[string]*AgingRow)
+	newRow := func(c CustomerBalance, known bool) *AgingRow {
+		r := &AgingRow{Customer: c, Buckets: make([]float64, len(AgingBuckets)), Known: known}
+		rows[c.InternalID] = r
+		return r
+	}
+	for _, c := range balances {
+		newRow(c, true)
+	}
+
+	row := func(customer string) *AgingRow {
+		if r, ok := rows[customer]; ok {
+			return r
+		}
+		return newRow(CustomerBalance{InternalID: customer, Name: names[customer]}, false)
+	}
+	for _, d := range open {
+		if d.amount < 0.005 {
+			continue
+		}
+		r := row(d.customer)
+		days := 0
+		if !d.due.IsZero() {
+			days = int(math.Floor(asOf.Sub(d.due).Hours() / 24))
+		}
+		r.Buckets[agingBucket(days)] += d.amount
+		r.Invoices++
+	}
+	for customer, credit := range credits {
+		if credit >= 0.005 {
+			row(customer).Buckets[0] -= credit
+		}
+	}
+
+	result := make([]AgingRow, 0, len(rows))
+	for _, r := range rows {
+		if r.Invoices == 0 && r.Total() == 0 && r.Customer.Balance == 0 && r.Customer.Overdue == 0 {
+			continue
+		}
+		result = append(result, *r)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Total() != b.Total() {
+			return a.Total() > b.Total()
+		}
+		return a.Customer.InternalID < b.Customer.InternalID
+	})
+	return result
+}
+
+// agingIssues compares the bucketed totals with NetSuite's balances.
+func agingIssues(r AgingRow, tol Tolerance) []string {
+	if !r.Known {
+		return []string{"not in ns_customers"}
+	}
+	var issues []string
+	if math.Abs(r.Total()-r.Customer.Balance) > tol.Price {
+		issues = append(issues, fmt.Sprintf("aged %s vs balance %s", money(r.Total()), money(r.Customer.Balance)))
+	}
+	if math.Abs(r.PastDue()-r.Customer.Overdue) > tol.Price {
+		issues = append(issues, fmt.Sprintf("past due %s vs overdue %s", money(r.PastDue()), money(r.Customer.Overdue)))
+	}
+	return issues
+}
+
+// AgingReport renders the aging per customer. Customers whose aged total or
+// past-due amount disagrees with NetSuite's balance or overdue balance are
+// flagged. source describes where the balances came from.
+func AgingReport(rows []AgingRow, tol Tolerance, asOf time.Time, source string) Report {
+	out := make([]Row, len(rows))
+	totals := make([]float64, len(AgingBuckets))
+	flagged := 0
+	var balance float64
+	for i, r := range rows {
+		issues := agingIssues(r, tol)
+		if len(issues) > 0 {
+			flagged++
+		}
+		balance += r.Customer.Balance
+
+		cells := []string{orNone(r.Customer.Name), r.Customer.InternalID}
+		for b, amount := range r.Buckets {
+			totals[b] += amount
+			cells = append(cells, money(amount))
+		}
+		cells = append(cells, money(r.Total()), money(r.Customer.Balance), money(r.Total()-r.Customer.Balance),
+			quantity(r.Customer.DaysOverdue), strings.Join(issues, "; "))
+		out[i] = Row{Cells: cells, Flagged: len(issues) > 0}
+	}
+
+	columns := []Column{{Title: "Customer", Width: 28}, {Title: "Internal ID", Width: 12}}
+	for _, b := range AgingBuckets {
+		columns = append(columns, Column{Title: b, Width: 14})
+	}
+	columns = append(columns,
+		Column{Title: "Total", Width: 14},
+		Column{Title: "NS Balance", Width: 14},
+		Column{Title: "Difference", Width: 14},
+		Column{Title: "Days Overdue", Width: 12},
+		Column{Title: "Issues", Width: 50},
+	)
+
+	var bucketSummary []string
+	grand := 0.0
+	for b, name := range AgingBuckets {
+		bucketSummary = append(bucketSummary, name+" "+money(totals[b]))
+		grand += totals[b]
+	}
+
+	return Report{
+		Title:   "AR Aging as of " + formatDate(asOf),
+		Columns: columns,
+		Rows:    out,
+		Summary: fmt.Sprintf("%d customers, aged %s (%s) vs NetSuite balance %s from %s; %d customers disagree. "+
+			"Credits and payments are applied oldest invoice first; invoices paid outside ns_invoice_detail still count as open.",
+			len(rows), money(grand), strings.Join(bucketSummary, ", "), money(balance), source, flagged),
+	}
+}
+
+// LoadAging ages invoices as of asOf. Balances come from the customer
+// history version in effect on asOf, or from the current ns_customers
+// snapshot when there is no history for that date.
+func LoadAging(ctx context.Context, q *db.Queries, asOf time.Time, tol Tolerance) (Report, error) {
+	lines, err := q.ListNsInvoiceDetail(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading ns_invoice_detail: %w", err)
+	}
+
+	history, err := q.ListNsCustomersAsOf(ctx, pgtype.Date{Time: asOf, Valid: true})
+	if err != nil {
+		return Report{}, fmt.Errorf("loading ns_customers_history: %w", err)
+	}
+	balances, source := BalancesFromHistory(history), "customer history"
+	if len(history) == 0 {
+		customers, err := q.ListNsCustomers(ctx)
+		if err != nil {
+			return Report{}, fmt.Errorf("loading ns_customers: %w", err)
+		}
+		balances, source = BalancesFromCustomers(customers), "the current customer snapshot"
+	}
+
+	return AgingReport(AgeReceivables(lines, balances, asOf), tol, asOf, source), nil
+}
@@ -0,0 +1,153 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ========================================
+	AgeReceivables Tests
+======================================== */
+
+func agedLine(customer, doc, typ, invDate, due string, amount float64) db.NsInvoiceDetail {
+	line := db.NsInvoiceDetail{
+		CustomerInternalID: pgText(customer),
+		Name:               pgText("Customer " + customer),
+		DocumentNumber:     pgText(doc),
+		Type:               pgText(typ),
+		Date:               pgDate(invDate),
+		Amount:             pgNum(amount),
+	}
+	if due != "" {
+		line.DateDue = pgDate(due)
+	}
+	return line
+}
+
+func TestAgingBucket(t *testing.T) {
+	tests := []struct {
+		days     int
+		expected string
+	}{
+		{-10, "Current"},
+		{0, "Current"},
+		{1, "1-30"},
+		{30, "1-30"},
+		{31, "31-60"},
+		{60, "31-60"},
+		{61, "61-90"},
+		{90, "61-90"},
+		{91, "90+"},
+	}
+
+	for _, tt := range tests {
+		if got := AgingBuckets[agingBucket(tt.days)]; got != tt.expected {
+			t.Errorf("agingBucket(%d) = %q, expected %q", tt.days, got, tt.expected)
+		}
+	}
+}
+
+func TestAgeReceivables(t *testing.T) {
+	asOf := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	lines := []db.NsInvoiceDetail{
+		agedLine("100", "INV-1", "Invoice", "2025-03-01", "2025-04-01", 1000), // current
+		agedLine("100", "INV-1", "Invoice", "2025-03-01", "2025-04-01", 80),   // tax line, same invoice
+		agedLine("100", "INV-2", "Invoice", "2025-01-01", "2025-01-31", 500),  // 59 days
+		agedLine("100", "CM-1", "Credit Memo", "2025-03-15", "", 200),         // credit, applied to INV-2 first
+		agedLine("100", "PMT-1", "Payment", "2025-03-20", "", -500),           // pays off INV-2, rest to INV-1
+		agedLine("100", "INV-9", "Invoice", "2025-04-02", "2025-05-02", 999),  // after asOf
+		agedLine("200", "INV-3", "Invoice", "2024-11-01", "2024-12-01", 300),  // 120 days
+		agedLine("300", "INV-4", "Invoice", "2025-03-10", "2025-03-20", 50),   // not in ns_customers
+		agedLine("600", "CM-2", "Credit Memo", "2025-02-01", "", 40),          // unapplied credit
+		agedLine("700", "1001", "Invoice", "2025-03-01", "2025-04-01", 100),   // current
+		agedLine("700", "1001", "Credit Memo", "2025-03-05", "", 40),          // same number, separate document
+	}
+	balances := []CustomerBalance{
+		{InternalID: "100", Name: "Acme", Balance: 880},
+		{InternalID: "200", Name: "Globex", Balance: 250, Overdue: 300},
+		{InternalID: "400", Name: "Initech", Balance: 75, Overdue: 75},
+		{InternalID: "500", Name: "Paid Up"},
+		{InternalID: "600", Name: "Hooli", Balance: -40},
+		{InternalID: "700", Name: "Soylent", Balance: 60},
+	}
+
+	rows := AgeReceivables(lines, balances, asOf)
+	if len(rows) != 6 {
+		t.Fatalf("expected 6 customers, got %d: %+v", len(rows), rows)
+	}
+	byID := make(map[string]AgingRow)
+	for _, r := range rows {
+		byID[r.Customer.InternalID] = r
+	}
+
+	acme := byID["100"]
+	if acme.Invoices != 1 || acme.Buckets[0] != 880 || acme.Total() != 880 || acme.PastDue() != 0 {
+		t.Errorf("Acme = %+v", acme)
+	}
+	if issues := agingIssues(acme, DefaultTolerance); len(issues) != 0 {
+		t.Errorf("Acme issues = %v, expected none", issues)
+	}
+
+	globex := byID["200"]
+	if globex.Buckets[4] != 300 {
+		t.Errorf("Globex 90+ = %v, expected 300", globex.Buckets[4])
+	}
+	if issues := agingIssues(globex, DefaultTolerance); len(issues) != 1 || !strings.Contains(issues[0], "balance") {
+		t.Errorf("Globex issues = %v", issues)
+	}
+
+	initech := byID["400"]
+	if initech.Invoices != 0 || initech.Total() != 0 {
+		t.Errorf("Initech = %+v", initech)
+	}
+	if issues := agingIssues(initech, DefaultTolerance); len(issues) != 2 {
+		t.Errorf("Initech issues = %v, expected balance and overdue", issues)
+	}
+
+	hooli := byID["600"]
+	if hooli.Invoices != 0 || hooli.Buckets[0] != -40 {
+		t.Errorf("Hooli = %+v, expected the unapplied credit in Current", hooli)
+	}
+	if issues := agingIssues(hooli, DefaultTolerance); len(issues) != 0 {
+		t.Errorf("Hooli issues = %v, expected none", issues)
+	}
+
+	soylent := byID["700"]
+	if soylent.Invoices != 1 || soylent.Buckets[0] != 60 {
+		t.Errorf("Soylent = %+v, expected the credit memo applied to the invoice with the same number", soylent)
+	}
+
+	unknown := byID["300"]
+	if unknown.Known || unknown.Customer.Name != "Customer 300" || unknown.Buckets[1] != 50 {
+		t.Errorf("unknown customer = %+v", unknown)
+	}
+
+	if rows[0].Customer.InternalID != "100" {
+		t.Errorf("expected largest total first, got %s", rows[0].Customer.InternalID)
+	}
+}
+
+func TestAgingReport(t *testing.T) {
+	asOf := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	rows := []AgingRow{
+		{Customer: CustomerBalance{InternalID: "100", Name: "Acme", Balance: 100}, Buckets: []float64{100, 0, 0, 0, 0}, Invoices: 1, Known: true},
+		{Customer: CustomerBalance{InternalID: "200", Name: "Globex", Balance: 10}, Buckets: []float64{0, 0, 0, 0, 40}, Invoices: 1, Known: true},
+	}
+
+	r := AgingReport(rows, DefaultTolerance, asOf, "customer history")
+	if r.Title != "AR Aging as of 2025-03-31" {
+		t.Errorf("Title = %q", r.Title)
+	}
+	if len(r.Columns) != len(r.Rows[0].Cells) {
+		t.Fatalf("%d columns but %d cells", len(r.Columns), len(r.Rows[0].Cells))
+	}
+	if r.Rows[0].Flagged || !r.Rows[1].Flagged {
+		t.Errorf("flags = %v, %v; expected only Globex flagged", r.Rows[0].Flagged, r.Rows[1].Flagged)
+	}
+	if !strings.Contains(r.Summary, "1 customers disagree") || !strings.Contains(r.Summary, "customer history") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
@@ -0,0 +1,304 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/revrec"
+	"github.com/JonMunkholm/TUI/internal/sfid"
+)
+
+/* ----------------------------------------
+	BILLED VS CONTRACTED
+---------------------------------------- */
+
+// BillingLine is one contracted SO line (or the SO lines sharing an SFDC
+// line ID) with what has been invoiced and recognized against it.
+type BillingLine struct {
+	LineID     string // SFDC opportunity line ID; "" when the SO line has none
+	SoNumbers  []string
+	Customer   string
+	Item       string
+	Start      time.Time
+	End        time.Time
+	Contracted float64 // Sum of amount_gross
+	Billed     float64 // Sum of non-tax invoice lines; credit memos subtract
+	Recognized float64 // NetSuite revenue schedule through the as-of month
+	Invoices   []string
+	Early      []string // Invoices dated before the service start
+	Late       []string // Invoices dated after the service end
+	NoSO       bool     // Invoiced against a line ID no SO line has
+}
+
+// Unbilled is the contracted amount not yet invoiced.
+func (b BillingLine) Unbilled() float64 { return b.Contracted - b.Billed }
+
+// Deferred is invoiced but not yet recognized revenue. A negative value is
+// revenue recognized ahead of billing.
+func (b BillingLine) Deferred() float64 { return b.Billed - b.Recognized }
+
+// OverBilled reports whether more was invoiced than contracted.
+func (b BillingLine) OverBilled(tol Tolerance) bool { return b.Billed-b.Contracted > tol.Price }
+
+// issues lists what needs attention on the line.
+func (b BillingLine) issues(tol Tolerance) []string {
+	var issues []string
+	if b.NoSO {
+		issues = append(issues, "billed without an SO line")
+	} else if b.OverBilled(tol) {
+		issues = append(issues, "over-billed by "+money(b.Billed-b.Contracted))
+	}
+	if len(b.Early) > 0 {
+		issues = append(issues, "billed before "+formatDate(b.Start)+": "+strings.Join(b.Early, ", "))
+	}
+	if len(b.Late) > 0 {
+		issues = append(issues, "billed after "+formatDate(b.End)+": "+strings.Join(b.Late, ", "))
+	}
+	return issues
+}
+
+// isCreditMemo reports whether an invoice detail line is a credit memo,
+// which reduces the amount billed.
+func isCreditMemo(line db.NsInvoiceDetail) bool {
+	return strings.Contains(strings.ToLower(text(line.Type)), "credit")
+}
+
+// AnalyzeBilling matches invoice lines to SO lines on the SFDC line ID,
+// comparing 15- and 18-character IDs as equal, and adds the NetSuite revenue
+// schedule through the month of asOf. Tax lines and invoices dated after asOf
+// are ignored. SO lines without a line ID cannot be matched and show no
+// billing.
+func AnalyzeBilling(sos []db.NsSoDetail, invoices []db.NsInvoiceDetail, schedule []db.RevenueSchedule, asOf time.Time, tax config.TaxLines) []BillingLine {
+	var lines []*BillingLine
+	byKey := make(map[string]*BillingLine)
+	byRow := make(map[[16]byte]*BillingLine)
+
+	for _, so := range sos {
+		id := text(so.SfdcOppLineID)
+		key := sfid.Key(id)
+
+		b, ok := byKey[key]
+		if !ok || id == "" {
+			b = &BillingLine{
+				LineID:   id,
+				Customer: strings.TrimSpace(so.CustomerProject),
+				Item:     text(so.ItemName),
+				Start:    date(so.LineStartDate),
+				End:      date(so.LineEndDate),
+			}
+			if b.Start.IsZero() {
+				b.Start = date(so.StartDate)
+			}
+			if b.End.IsZero() {
+				b.End = date(so.EndDate)
+			}
+			lines = append(lines, b)
+			if id != "" {
+				byKey[key] = b
+			}
+		}
+		b.Contracted += num(so.AmountGross)
+		if n := text(so.SoNumber); n != "" && !contains(b.SoNumbers, n) {
+			b.SoNumbers = append(b.SoNumbers, n)
+		}
+		byRow[so.ID.Bytes] = b
+	}
+
+	for _, inv := range invoices {
+		id := text(inv.SfdcOppLineID)
+		if id == "" || isTaxLine(inv, tax) || date(inv.Date).After(asOf) {
+			continue
+		}
+
+		b, ok := byKey[sfid.Key(id)]
+		if !ok {
+			b = &BillingLine{LineID: id, Customer: text(inv.Name), Item: text(inv.Item), NoSO: true}
+			byKey[sfid.Key(id)] = b
+			lines = append(lines, b)
+		}
+
+		amount := num(inv.Amount)
+		if isCreditMemo(inv) {
+			amount = -math.Abs(amount)
+		}
+		b.Billed += amount
+
+		doc := text(inv.DocumentNumber)
+		if !contains(b.Invoices, doc) {
+			b.Invoices = append(b.Invoices, doc)
+		}
+		if isCreditMemo(inv) || b.NoSO {
+			continue
+		}
+		invDate := date(inv.Date)
+		switch {
+		case invDate.IsZero():
+		case !b.Start.IsZero() && invDate.Before(b.Start) && !contains(b.Early, doc):
+			b.Early = append(b.Early, doc)
+		case !b.End.IsZero() && invDate.After(b.End) && !contains(b.Late, doc):
+			b.Late = append(b.Late, doc)
+		}
+	}
+
+	through := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, s := range schedule {
+		if s.Source != revrec.SourceNS || date(s.Period).After(through) {
+			continue
+		}
+		if b, ok := byRow[s.LineID.Bytes]; ok {
+			b.Recognized += num(s.Amount)
+		}
+	}
+
+	result := make([]BillingLine, len(lines))
+	for i, b := range lines {
+		result[i] = *b
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Customer != result[j].Customer {
+			return result[i].Customer < result[j].Customer
+		}
+		return result[i].LineID < result[j].LineID
+	})
+	return result
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// BillingLinesReport lists each SO line's contracted, billed, unbilled,
+// recognized and deferred amounts. Over-billed lines, lines billed outside
+// their service dates, and billing with no SO line are flagged.
+func BillingLinesReport(lines []BillingLine, tol Tolerance, asOf time.Time) Report {
+	rows := make([]Row, len(lines))
+	flagged := 0
+	var contracted, billed, recognized float64
+	for i, b := range lines {
+		issues := b.issues(tol)
+		if len(issues) > 0 {
+			flagged++
+		}
+		contracted += b.Contracted
+		billed += b.Billed
+		recognized += b.Recognized
+
+		rows[i] = Row{
+			Cells: []string{
+				orNone(b.Customer), strings.Join(b.SoNumbers, ", "), orNone(b.LineID), b.Item,
+				formatDate(b.Start), formatDate(b.End),
+				money(b.Contracted), money(b.Billed), money(b.Unbilled()), money(b.Recognized), money(b.Deferred()),
+				strings.Join(issues, "; "),
+			},
+			Flagged: len(issues) > 0,
+		}
+	}
+
+	return Report{
+		Title: "Billed vs Contracted by SO Line",
+		Columns: []Column{
+			{Title: "Customer", Width: 28},
+			{Title: "SO", Width: 12},
+			{Title: "SFDC Line", Width: 18},
+			{Title: "Item", Width: 20},
+			{Title: "Start", Width: 10},
+			{Title: "End", Width: 10},
+			{Title: "Contracted", Width: 14},
+			{Title: "Billed", Width: 14},
+			{Title: "Unbilled", Width: 14},
+			{Title: "Recognized", Width: 14},
+			{Title: "Deferred", Width: 14},
+			{Title: "Issues", Width: 60},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d lines as of %s: contracted %s, billed %s, unbilled %s, deferred %s; %d lines need attention",
+			len(lines), formatDate(asOf), money(contracted), money(billed), money(contracted-billed), money(billed-recognized), flagged),
+	}
+}
+
+// BillingByCustomerReport sums the SO lines per customer. Customers with an
+// over-billed or out-of-period line are flagged.
+func BillingByCustomerReport(lines []BillingLine, tol Tolerance, asOf time.Time) Report {
+	type group struct {
+		lines, issues                  int
+		contracted, billed, recognized float64
+	}
+	groups := make(map[string]*group)
+	for _, b := range lines {
+		g, ok := groups[b.Customer]
+		if !ok {
+			g = &group{}
+			groups[b.Customer] = g
+		}
+		g.lines++
+		g.contracted += b.Contracted
+		g.billed += b.Billed
+		g.recognized += b.Recognized
+		if len(b.issues(tol)) > 0 {
+			g.issues++
+		}
+	}
+
+	keys := sortedKeys(groups)
+	rows := make([]Row, len(keys))
+	flagged := 0
+	for i, k := range keys {
+		g := groups[k]
+		if g.issues > 0 {
+			flagged++
+		}
+		rows[i] = Row{
+			Cells: []string{
+				orNone(k), fmt.Sprint(g.lines), money(g.contracted), money(g.billed), money(g.contracted - g.billed),
+				money(g.recognized), money(g.billed - g.recognized), fmt.Sprint(g.issues),
+			},
+			Flagged: g.issues > 0,
+		}
+	}
+
+	return Report{
+		Title: "Billed vs Contracted by Customer",
+		Columns: []Column{
+			{Title: "Customer", Width: 30},
+			{Title: "Lines", Width: 6},
+			{Title: "Contracted", Width: 16},
+			{Title: "Billed", Width: 16},
+			{Title: "Unbilled", Width: 16},
+			{Title: "Recognized", Width: 16},
+			{Title: "Deferred", Width: 16},
+			{Title: "Issues", Width: 6},
+		},
+		Rows:    rows,
+		Summary: fmt.Sprintf("%d customers as of %s, %d with lines that need attention", len(keys), formatDate(asOf), flagged),
+	}
+}
+
+// LoadBilling loads SO lines, invoice lines and the NetSuite revenue
+// schedule and matches them as of asOf. Archived lines are included, since
+// an SO line and its invoices may fall in different periods.
+func LoadBilling(ctx context.Context, q *db.Queries, asOf time.Time, tax config.TaxLines) ([]BillingLine, error) {
+	sos, err := q.ListNsSoDetailWithArchive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading ns_so_detail: %w", err)
+	}
+	invoices, err := q.ListNsInvoiceDetailWithArchive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading ns_invoice_detail: %w", err)
+	}
+	schedule, err := LoadRevenueSchedule(ctx, q, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return AnalyzeBilling(sos, invoices, schedule, asOf, tax), nil
+}
@@ -0,0 +1,123 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	AnalyzeBilling Tests
+======================================== */
+
+func contractedLine(row byte, lineID, so, customer string, amount float64, start, end string) db.NsSoDetail {
+	return db.NsSoDetail{
+		ID:              pgtype.UUID{Bytes: [16]byte{row}, Valid: true},
+		SfdcOppLineID:   pgText(lineID),
+		SoNumber:        pgText(so),
+		CustomerProject: customer,
+		ItemName:        pgText("PLAT"),
+		AmountGross:     pgNum(amount),
+		LineStartDate:   pgDate(start),
+		LineEndDate:     pgDate(end),
+	}
+}
+
+func billedLine(lineID, doc, typ, item, invDate string, amount float64) db.NsInvoiceDetail {
+	return db.NsInvoiceDetail{
+		SfdcOppLineID:  pgText(lineID),
+		DocumentNumber: pgText(doc),
+		Type:           pgText(typ),
+		Name:           pgText("Invoice Customer"),
+		Item:           pgText(item),
+		Date:           pgDate(invDate),
+		Amount:         pgNum(amount),
+	}
+}
+
+func TestAnalyzeBilling(t *testing.T) {
+	sos := []db.NsSoDetail{
+		contractedLine(1, "00kA0000001AbCd", "SO-1", "Acme", 1200, "2025-01-01", "2025-12-31"),
+		contractedLine(2, "00kA0000002AbCd", "SO-1", "Acme", 100, "2025-01-01", "2025-03-31"),
+		contractedLine(3, "", "SO-2", "Globex", 500, "2025-01-01", "2025-12-31"),
+	}
+	invoices := []db.NsInvoiceDetail{
+		billedLine("00kA0000001AbCdIAK", "INV-1", "Invoice", "PLAT", "2025-01-05", 600),  // 18-char form matches
+		billedLine("00kA0000001AbCd", "INV-1", "Invoice", "Sales Tax", "2025-01-05", 50), // tax ignored
+		billedLine("00kA0000002AbCd", "INV-2", "Invoice", "PLAT", "2024-12-15", 150),     // early, over-billed
+		billedLine("00kA0000002AbCd", "CM-1", "Credit Memo", "PLAT", "2025-05-01", 20),   // credit reduces
+		billedLine("00kA0000002AbCd", "INV-3", "Invoice", "PLAT", "2025-04-10", 10),      // late
+		billedLine("00kZZZ", "INV-4", "Invoice", "PLAT", "2025-01-05", 75),               // no SO line
+		billedLine("00kA0000001AbCd", "INV-5", "Invoice", "PLAT", "2025-07-01", 600),     // after asOf
+	}
+	schedule := []db.RevenueSchedule{
+		{Source: "ns", LineID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, Period: pgDate("2025-01-01"), Amount: pgNum(100)},
+		{Source: "ns", LineID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, Period: pgDate("2025-02-01"), Amount: pgNum(100)},
+		{Source: "ns", LineID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, Period: pgDate("2025-07-01"), Amount: pgNum(100)}, // after asOf
+		{Source: "sfdc", LineID: pgtype.UUID{Bytes: [16]byte{1}, Valid: true}, Period: pgDate("2025-01-01"), Amount: pgNum(999)},
+	}
+	asOf := time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC)
+
+	lines := AnalyzeBilling(sos, invoices, schedule, asOf, config.DefaultTaxLines)
+	if len(lines) != 4 {
+		t.Fatalf("len(lines) = %d, expected 4", len(lines))
+	}
+
+	byID := make(map[string]BillingLine)
+	for _, b := range lines {
+		byID[b.LineID+"|"+b.Customer] = b
+	}
+
+	annual := byID["00kA0000001AbCd|Acme"]
+	if annual.Billed != 600 || annual.Unbilled() != 600 || annual.Recognized != 200 || annual.Deferred() != 400 {
+		t.Errorf("annual line = %+v, expected billed 600, unbilled 600, recognized 200, deferred 400", annual)
+	}
+	if len(annual.issues(DefaultTolerance)) != 0 {
+		t.Errorf("annual line issues = %v, expected none", annual.issues(DefaultTolerance))
+	}
+
+	short := byID["00kA0000002AbCd|Acme"]
+	if short.Billed != 140 || !short.OverBilled(DefaultTolerance) {
+		t.Errorf("short line billed = %v (over-billed %v), expected 140 and over-billed", short.Billed, short.OverBilled(DefaultTolerance))
+	}
+	issues := strings.Join(short.issues(DefaultTolerance), "; ")
+	for _, want := range []string{"over-billed by 40.00", "billed before 2025-01-01: INV-2", "billed after 2025-03-31: INV-3"} {
+		if !strings.Contains(issues, want) {
+			t.Errorf("issues = %q, expected %q", issues, want)
+		}
+	}
+
+	if noID := byID["|Globex"]; noID.Contracted != 500 || noID.Billed != 0 {
+		t.Errorf("line without ID = %+v, expected contracted 500 and nothing billed", noID)
+	}
+	if orphan := byID["00kZZZ|Invoice Customer"]; !orphan.NoSO || orphan.Billed != 75 {
+		t.Errorf("orphan billing = %+v, expected NoSO with 75 billed", orphan)
+	}
+}
+
+func TestBillingByCustomerReport(t *testing.T) {
+	lines := []BillingLine{
+		{Customer: "Acme", Contracted: 1200, Billed: 600, Recognized: 200},
+		{Customer: "Acme", Contracted: 100, Billed: 140, Recognized: 100},
+		{Customer: "Globex", Contracted: 500},
+	}
+
+	r := BillingByCustomerReport(lines, DefaultTolerance, time.Date(2025, 2, 14, 0, 0, 0, 0, time.UTC))
+
+	expected := []string{
+		"Acme|2|1,300.00|740.00|560.00|300.00|440.00|1",
+		"Globex|1|500.00|0.00|500.00|0.00|0.00|0",
+	}
+	for i, want := range expected {
+		if got := strings.Join(r.Rows[i].Cells, "|"); got != want {
+			t.Errorf("Rows[%d] = %q, expected %q", i, got, want)
+		}
+	}
+	if !r.Rows[0].Flagged || r.Rows[1].Flagged {
+		t.Error("only the customer with an over-billed line should be flagged")
+	}
+}
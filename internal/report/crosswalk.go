@@ -0,0 +1,195 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/JonMunkholm/TUI/internal/crosswalk"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/sfid"
+)
+
+/* ----------------------------------------
+	CUSTOMER CROSSWALK
+---------------------------------------- */
+
+// Crosswalk statuses.
+const (
+	StatusNoSFDCAccount      = "No SFDC account"
+	StatusUnknownSFDCAccount = "SFDC account not found"
+	StatusNoNSCustomer       = "No NS customer"
+	StatusManyToOne          = "Many-to-one"
+)
+
+// CustomerLink is one NS customer and the SFDC account it resolves to.
+type CustomerLink struct {
+	NsInternalID  string
+	NsName        string
+	SfdcAccountID string // 18-character, "" when unlinked
+	SfdcName      string // "" when the account is not in sfdc_customers
+	Source        string // "ns", "manual", or "" when not yet in the crosswalk
+	Note          string
+}
+
+// CustomerFinding is one crosswalk problem.
+type CustomerFinding struct {
+	Status string
+	Link   CustomerLink
+	Detail string
+}
+
+// CustomerResult is the outcome of checking the crosswalk.
+type CustomerResult struct {
+	NsCustomers  int
+	SfdcAccounts int
+	Linked       int // NS customers linked one-to-one to a known account
+	Findings     []CustomerFinding
+}
+
+// ReconcileCustomers resolves each NS customer through the crosswalk, falling
+// back to its salesforce_id_io when the crosswalk has no row for it yet, and
+// reports unlinked customers, links to unknown accounts, accounts no NS
+// customer links to, and accounts linked from several NS customers. A manual
+// override with no account confirms the customer has none and is not reported.
+func ReconcileCustomers(ns []db.NsCustomer, sfdc []db.SfdcCustomer, links []db.CustomerCrosswalk) CustomerResult {
+	result := CustomerResult{NsCustomers: len(ns), SfdcAccounts: len(sfdc)}
+
+	accounts := make(map[string]db.SfdcCustomer)
+	for _, a := range sfdc {
+		accounts[sfid.Key(text(a.AccountIDCasesafe))] = a
+	}
+	rows := make(map[string]db.CustomerCrosswalk)
+	for _, c := range links {
+		rows[c.NsInternalID] = c
+	}
+
+	var resolved []CustomerLink
+	byAccount := make(map[string][]int)
+	for _, c := range ns {
+		id := text(c.InternalID)
+		if id == "" {
+			continue
+		}
+
+		link := CustomerLink{NsInternalID: id, NsName: text(c.Name), SfdcAccountID: sfid.To18(text(c.SalesforceIDIo))}
+		if row, ok := rows[id]; ok {
+			link.SfdcAccountID = text(row.SfdcAccountID)
+			link.Source = row.Source
+			link.Note = text(row.Note)
+		}
+		if a, ok := accounts[sfid.Key(link.SfdcAccountID)]; ok && link.SfdcAccountID != "" {
+			link.SfdcName = text(a.AccountName)
+		}
+
+		resolved = append(resolved, link)
+		if link.SfdcAccountID != "" {
+			key := sfid.Key(link.SfdcAccountID)
+			byAccount[key] = append(byAccount[key], len(resolved)-1)
+		}
+	}
+
+	for _, link := range resolved {
+		switch {
+		case link.SfdcAccountID == "":
+			if link.Source != crosswalk.SourceManual {
+				result.Findings = append(result.Findings, CustomerFinding{Status: StatusNoSFDCAccount, Link: link})
+			}
+		case !accountExists(accounts, link.SfdcAccountID):
+			result.Findings = append(result.Findings, CustomerFinding{
+				Status: StatusUnknownSFDCAccount, Link: link,
+				Detail: "not in sfdc_customers; merged or deleted?",
+			})
+		case len(byAccount[sfid.Key(link.SfdcAccountID)]) > 1:
+			result.Findings = append(result.Findings, CustomerFinding{
+				Status: StatusManyToOne, Link: link,
+				Detail: fmt.Sprintf("%d NS customers share this account", len(byAccount[sfid.Key(link.SfdcAccountID)])),
+			})
+		default:
+			result.Linked++
+		}
+	}
+
+	for key, a := range accounts {
+		if len(byAccount[key]) == 0 {
+			result.Findings = append(result.Findings, CustomerFinding{
+				Status: StatusNoNSCustomer,
+				Link:   CustomerLink{SfdcAccountID: text(a.AccountIDCasesafe), SfdcName: text(a.AccountName)},
+			})
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		if a.Link.SfdcAccountID != b.Link.SfdcAccountID {
+			return a.Link.SfdcAccountID < b.Link.SfdcAccountID
+		}
+		return a.Link.NsInternalID < b.Link.NsInternalID
+	})
+	return result
+}
+
+func accountExists(accounts map[string]db.SfdcCustomer, id string) bool {
+	_, ok := accounts[sfid.Key(id)]
+	return ok
+}
+
+// CustomerReport renders the crosswalk check.
+func CustomerReport(r CustomerResult) Report {
+	counts := make(map[string]int)
+	rows := make([]Row, len(r.Findings))
+	for i, f := range r.Findings {
+		counts[f.Status]++
+		detail := f.Detail
+		if f.Link.Note != "" {
+			detail = joinNonEmpty(detail, "note: "+f.Link.Note)
+		}
+		rows[i] = Row{
+			Cells:   []string{f.Status, f.Link.NsInternalID, f.Link.NsName, f.Link.SfdcAccountID, f.Link.SfdcName, f.Link.Source, detail},
+			Flagged: f.Status == StatusManyToOne || f.Status == StatusUnknownSFDCAccount,
+		}
+	}
+
+	return Report{
+		Title: "Customer Crosswalk",
+		Columns: []Column{
+			{Title: "Status", Width: 22},
+			{Title: "NS ID", Width: 12},
+			{Title: "NS Customer", Width: 30},
+			{Title: "SFDC Account ID", Width: 18},
+			{Title: "SFDC Account", Width: 30},
+			{Title: "Source", Width: 6},
+			{Title: "Detail", Width: 50},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d NS customers, %d SFDC accounts: %d linked one-to-one, %d with no SFDC account, %d linked to unknown accounts, %d many-to-one, %d SFDC accounts with no NS customer",
+			r.NsCustomers, r.SfdcAccounts, r.Linked, counts[StatusNoSFDCAccount], counts[StatusUnknownSFDCAccount], counts[StatusManyToOne], counts[StatusNoNSCustomer]),
+	}
+}
+
+func joinNonEmpty(a, b string) string {
+	if a == "" {
+		return b
+	}
+	return a + "; " + b
+}
+
+// LoadCustomers loads customers from both systems and the crosswalk and builds the report.
+func LoadCustomers(ctx context.Context, q *db.Queries) (Report, error) {
+	ns, err := q.ListNsCustomers(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading ns_customers: %w", err)
+	}
+	sfdc, err := q.ListSfdcCustomers(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading sfdc_customers: %w", err)
+	}
+	links, err := q.ListCustomerCrosswalk(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading customer_crosswalk: %w", err)
+	}
+	return CustomerReport(ReconcileCustomers(ns, sfdc, links)), nil
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ========================================
+	ReconcileCustomers Tests
+======================================== */
+
+func nsCustomer(id, name, sfdcID string) db.NsCustomer {
+	return db.NsCustomer{InternalID: pgText(id), Name: pgText(name), SalesforceIDIo: pgText(sfdcID)}
+}
+
+func sfdcAccount(id, name string) db.SfdcCustomer {
+	return db.SfdcCustomer{AccountIDCasesafe: pgText(id), AccountName: pgText(name)}
+}
+
+func TestReconcileCustomers(t *testing.T) {
+	ns := []db.NsCustomer{
+		nsCustomer("1", "Acme", "001A0000006Vm9r"),       // 15-char link to Acme
+		nsCustomer("2", "Globex", ""),                    // no link
+		nsCustomer("3", "Initech", "001A0000009ZZZZZZZ"), // merged account
+		nsCustomer("4", "Umbrella", "001A0000007Aaaa"),   // shares an account with 5
+		nsCustomer("5", "Umbrella EU", "001A0000007AaaaIAC"),
+		nsCustomer("6", "Hooli", ""),                     // manual: no account
+		nsCustomer("7", "Soylent", "001A0000009ZZZZZZZ"), // fixed by a manual override
+	}
+	sfdc := []db.SfdcCustomer{
+		sfdcAccount("001A0000006Vm9rIAC", "Acme"),
+		sfdcAccount("001A0000007AaaaIAC", "Umbrella"),
+		sfdcAccount("001A0000008BbbbIAA", "Stark"), // no NS customer
+		sfdcAccount("001A0000009CccCIAA", "Soylent"),
+	}
+	links := []db.CustomerCrosswalk{
+		{NsInternalID: "6", Source: "manual"},
+		{NsInternalID: "7", SfdcAccountID: pgText("001A0000009CccCIAA"), Source: "manual", Note: pgText("merged")},
+	}
+
+	got := ReconcileCustomers(ns, sfdc, links)
+
+	if got.Linked != 2 {
+		t.Errorf("Linked = %d, expected 2 (Acme, Soylent)", got.Linked)
+	}
+
+	byStatus := make(map[string][]string)
+	for _, f := range got.Findings {
+		id := f.Link.NsInternalID
+		if id == "" {
+			id = f.Link.SfdcName
+		}
+		byStatus[f.Status] = append(byStatus[f.Status], id)
+	}
+
+	expected := map[string]string{
+		StatusNoSFDCAccount:      "2",
+		StatusUnknownSFDCAccount: "3",
+		StatusManyToOne:          "4,5",
+		StatusNoNSCustomer:       "Stark",
+	}
+	for status, want := range expected {
+		if got := strings.Join(byStatus[status], ","); got != want {
+			t.Errorf("%s = %q, expected %q", status, got, want)
+		}
+	}
+	if len(byStatus) != len(expected) {
+		t.Errorf("statuses = %v", byStatus)
+	}
+}
+
+func TestCustomerReport(t *testing.T) {
+	r := CustomerReport(CustomerResult{Findings: []CustomerFinding{
+		{Status: StatusManyToOne, Link: CustomerLink{NsInternalID: "4", Note: "check"}, Detail: "2 NS customers share this account"},
+		{Status: StatusNoSFDCAccount, Link: CustomerLink{NsInternalID: "2"}},
+	}})
+
+	if !r.Rows[0].Flagged || r.Rows[1].Flagged {
+		t.Error("only many-to-one and unknown-account rows should be flagged")
+	}
+	if detail := r.Rows[0].Cells[len(r.Columns)-1]; detail != "2 NS customers share this account; note: check" {
+		t.Errorf("Detail = %q", detail)
+	}
+}
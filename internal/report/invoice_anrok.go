@@ -0,0 +1,250 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ----------------------------------------
+	NS INVOICES <-> ANROK TRANSACTIONS
+---------------------------------------- */
+
+// Invoice reconciliation statuses.
+const (
+	StatusMissingAnrok = "Missing in Anrok"
+	StatusVoidedAnrok  = "Voided in Anrok, open in NetSuite"
+)
+
+// isTaxLine reports whether an invoice line carries sales tax rather than a sale.
+func isTaxLine(line db.NsInvoiceDetail, tax config.TaxLines) bool {
+	return tax.IsTax(text(line.Item), text(line.Account))
+}
+
+// NsInvoice is one NetSuite invoice or credit memo, summed from its detail
+// lines. Credit memo amounts are negative.
+type NsInvoice struct {
+	DocumentNumber string
+	Customer       string
+	Date           time.Time
+	Sales          float64 // Sum of non-tax line amounts
+	Tax            float64 // Sum of tax line amounts
+	TaxLines       int
+	Lines          int
+}
+
+// Total returns the invoice total including tax.
+func (i NsInvoice) Total() float64 { return i.Sales + i.Tax }
+
+// InvoiceFinding is one invoice or Anrok transaction that failed to reconcile.
+type InvoiceFinding struct {
+	Status         string
+	DocumentNumber string
+	Invoice        *NsInvoice           // nil when missing in NetSuite
+	Anrok          *db.AnrokTransaction // nil when missing in Anrok
+	Diffs          []string
+}
+
+// InvoiceAnrokResult is the outcome of matching invoices to Anrok transactions.
+type InvoiceAnrokResult struct {
+	Invoices     int
+	Transactions int
+	Matched      int
+	Findings     []InvoiceFinding
+}
+
+// GroupInvoices sums invoice detail lines per document number, splitting
+// tax lines from sales. Credit memo lines count as negative amounts, so a
+// credit memo reconciles against Anrok's refund. Lines without a document
+// number are skipped.
+func GroupInvoices(lines []db.NsInvoiceDetail, tax config.TaxLines) []NsInvoice {
+	byNumber := make(map[string]*NsInvoice)
+	var order []string
+
+	for _, line := range lines {
+		number := text(line.DocumentNumber)
+		if number == "" {
+			continue
+		}
+
+		inv, ok := byNumber[number]
+		if !ok {
+			inv = &NsInvoice{DocumentNumber: number, Customer: text(line.Name), Date: date(line.Date)}
+			byNumber[number] = inv
+			order = append(order, number)
+		}
+
+		amount := num(line.Amount)
+		if isCreditMemo(line) {
+			amount = -math.Abs(amount)
+		}
+
+		inv.Lines++
+		if isTaxLine(line, tax) {
+			inv.Tax += amount
+			inv.TaxLines++
+		} else {
+			inv.Sales += amount
+		}
+	}
+
+	invoices := make([]NsInvoice, len(order))
+	for i, number := range order {
+		invoices[i] = *byNumber[number]
+	}
+	return invoices
+}
+
+// ReconcileInvoiceAnrok matches NetSuite invoices to Anrok transactions on
+// document_number = transaction_id. Sales are always compared; tax and
+// total only when the invoice has tax lines, since exports without them
+// cannot be compared against Anrok's tax.
+func ReconcileInvoiceAnrok(invoices []NsInvoice, txns []db.AnrokTransaction, tol Tolerance) InvoiceAnrokResult {
+	result := InvoiceAnrokResult{Invoices: len(invoices), Transactions: len(txns)}
+
+	byID := make(map[string]*db.AnrokTransaction)
+	for i := range txns {
+		id := text(txns[i].TransactionID)
+		if id == "" {
+			result.Findings = append(result.Findings, InvoiceFinding{Status: StatusMissingNS, Anrok: &txns[i]})
+			continue
+		}
+		byID[id] = &txns[i]
+	}
+
+	seen := make(map[string]bool)
+	for i := range invoices {
+		inv := &invoices[i]
+		txn, ok := byID[inv.DocumentNumber]
+		if !ok {
+			result.Findings = append(result.Findings, InvoiceFinding{Status: StatusMissingAnrok, DocumentNumber: inv.DocumentNumber, Invoice: inv})
+			continue
+		}
+		seen[inv.DocumentNumber] = true
+
+		if txn.Void.Valid && txn.Void.Bool {
+			if math.Abs(inv.Total()) > tol.Price {
+				result.Findings = append(result.Findings, InvoiceFinding{
+					Status: StatusVoidedAnrok, DocumentNumber: inv.DocumentNumber, Invoice: inv, Anrok: txn,
+					Diffs: []string{"NetSuite total " + money(inv.Total())},
+				})
+			} else {
+				result.Matched++
+			}
+			continue
+		}
+
+		if diffs := compareInvoiceAnrok(*inv, *txn, tol); len(diffs) > 0 {
+			result.Findings = append(result.Findings, InvoiceFinding{Status: StatusMismatch, DocumentNumber: inv.DocumentNumber, Invoice: inv, Anrok: txn, Diffs: diffs})
+		} else {
+			result.Matched++
+		}
+	}
+
+	for id, txn := range byID {
+		if !seen[id] && !(txn.Void.Valid && txn.Void.Bool) {
+			result.Findings = append(result.Findings, InvoiceFinding{Status: StatusMissingNS, DocumentNumber: id, Anrok: txn})
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		return a.DocumentNumber < b.DocumentNumber
+	})
+	return result
+}
+
+// compareInvoiceAnrok lists the differences between a matched invoice and
+// Anrok transaction. A tax date in a different month than the invoice date
+// moves the tax to another filing period, so that is reported too.
+func compareInvoiceAnrok(inv NsInvoice, txn db.AnrokTransaction, tol Tolerance) []string {
+	var diffs []string
+
+	if d := dateDiff("invoice date", inv.Date, date(txn.InvoiceDate), tol.Days); d != "" {
+		diffs = append(diffs, d)
+	}
+	if taxDate := date(txn.TaxDate); !taxDate.IsZero() && !inv.Date.IsZero() &&
+		(taxDate.Year() != inv.Date.Year() || taxDate.Month() != inv.Date.Month()) {
+		diffs = append(diffs, fmt.Sprintf("tax date %s in another period than %s", formatDate(taxDate), formatDate(inv.Date)))
+	}
+
+	if a := num(txn.SalesAmount); math.Abs(inv.Sales-a) > tol.Price {
+		diffs = append(diffs, fmt.Sprintf("sales %s vs %s", money(inv.Sales), money(a)))
+	}
+	if inv.TaxLines > 0 {
+		if a := num(txn.TaxAmount); math.Abs(inv.Tax-a) > tol.Price {
+			diffs = append(diffs, fmt.Sprintf("tax %s vs %s", money(inv.Tax), money(a)))
+		}
+		if a := num(txn.InvoiceAmount); math.Abs(inv.Total()-a) > tol.Price {
+			diffs = append(diffs, fmt.Sprintf("total %s vs %s", money(inv.Total()), money(a)))
+		}
+	}
+	return diffs
+}
+
+// InvoiceAnrokReport renders the reconciliation result.
+func InvoiceAnrokReport(r InvoiceAnrokResult) Report {
+	counts := make(map[string]int)
+	rows := make([]Row, len(r.Findings))
+	for i, f := range r.Findings {
+		counts[f.Status]++
+
+		var customer, nsDate, nsTotal, anrokDate, anrokTotal string
+		if f.Invoice != nil {
+			customer = f.Invoice.Customer
+			nsDate = formatDate(f.Invoice.Date)
+			nsTotal = money(f.Invoice.Total())
+		}
+		if f.Anrok != nil {
+			if customer == "" {
+				customer = text(f.Anrok.CustomerName)
+			}
+			anrokDate = formatDate(date(f.Anrok.InvoiceDate))
+			anrokTotal = money(num(f.Anrok.InvoiceAmount))
+		}
+
+		rows[i] = Row{
+			Cells:   []string{f.Status, orNone(f.DocumentNumber), customer, nsDate, nsTotal, anrokDate, anrokTotal, strings.Join(f.Diffs, "; ")},
+			Flagged: f.Status == StatusVoidedAnrok,
+		}
+	}
+
+	return Report{
+		Title: "NetSuite Invoices vs Anrok",
+		Columns: []Column{
+			{Title: "Status", Width: 34},
+			{Title: "Document", Width: 16},
+			{Title: "Customer", Width: 28},
+			{Title: "NS Date", Width: 10},
+			{Title: "NS Total", Width: 14},
+			{Title: "Anrok Date", Width: 10},
+			{Title: "Anrok Total", Width: 14},
+			{Title: "Differences", Width: 60},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d invoices, %d Anrok transactions: %d matched, %d mismatched, %d missing in Anrok, %d missing in NetSuite, %d voided in Anrok but open in NetSuite",
+			r.Invoices, r.Transactions, r.Matched, counts[StatusMismatch], counts[StatusMissingAnrok], counts[StatusMissingNS], counts[StatusVoidedAnrok]),
+	}
+}
+
+// LoadInvoiceAnrok loads both sides and builds the reconciliation report.
+func LoadInvoiceAnrok(ctx context.Context, q *db.Queries, tol Tolerance, tax config.TaxLines) (Report, error) {
+	lines, err := q.ListNsInvoiceDetail(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading ns_invoice_detail: %w", err)
+	}
+	txns, err := q.ListAnrokTransactions(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading anrok_transactions: %w", err)
+	}
+	return InvoiceAnrokReport(ReconcileInvoiceAnrok(GroupInvoices(lines, tax), txns, tol)), nil
+}
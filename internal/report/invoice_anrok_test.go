@@ -0,0 +1,160 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	GroupInvoices Tests
+======================================== */
+
+func invoiceLine(number, item, day string, amount float64) db.NsInvoiceDetail {
+	return db.NsInvoiceDetail{
+		DocumentNumber: pgText(number),
+		Name:           pgText("Acme"),
+		Item:           pgText(item),
+		Date:           pgDate(day),
+		Amount:         pgNum(amount),
+	}
+}
+
+func TestGroupInvoices(t *testing.T) {
+	credit := invoiceLine("CM-1", "Platform", "2025-03-03", 40)
+	credit.Type = pgText("Credit Memo")
+	creditTax := invoiceLine("CM-1", "Sales Tax", "2025-03-03", 3)
+	creditTax.Type = pgText("Credit Memo")
+	accountTax := invoiceLine("INV-2", "Anrok Tax", "2025-03-02", 1)
+	accountTax.Account = pgText("2200 Sales Tax Payable")
+
+	tax := config.TaxLines{Items: []string{"Sales Tax"}, Accounts: []string{"2200"}}
+	got := GroupInvoices([]db.NsInvoiceDetail{
+		invoiceLine("INV-1", "Platform", "2025-03-01", 100),
+		invoiceLine("INV-1", "Support", "2025-03-01", 50),
+		invoiceLine("INV-1", "Sales Tax", "2025-03-01", 12),
+		invoiceLine("INV-2", "Platform", "2025-03-02", 10),
+		invoiceLine("INV-2", "Taxonomy add-on", "2025-03-02", 5),
+		accountTax,
+		credit,
+		creditTax,
+		invoiceLine("", "Platform", "2025-03-02", 999),
+	}, tax)
+
+	if len(got) != 3 {
+		t.Fatalf("GroupInvoices() returned %d invoices, expected 3", len(got))
+	}
+	inv := got[0]
+	if inv.DocumentNumber != "INV-1" || inv.Sales != 150 || inv.Tax != 12 || inv.TaxLines != 1 || inv.Lines != 3 {
+		t.Errorf("INV-1 = %+v", inv)
+	}
+	if inv.Total() != 162 {
+		t.Errorf("Total() = %v, expected 162", inv.Total())
+	}
+	if inv := got[1]; inv.Sales != 15 || inv.Tax != 1 || inv.TaxLines != 1 {
+		t.Errorf("INV-2 = %+v, expected the add-on as a sale and the 2200 line as tax", inv)
+	}
+	if cm := got[2]; cm.Sales != -40 || cm.Tax != -3 {
+		t.Errorf("CM-1 = %+v, expected negative sales and tax", cm)
+	}
+}
+
+/* ========================================
+	ReconcileInvoiceAnrok Tests
+======================================== */
+
+func anrokTxn(id, day string, sales, tax float64, void bool) db.AnrokTransaction {
+	return db.AnrokTransaction{
+		TransactionID: pgText(id),
+		CustomerName:  pgText("Acme"),
+		InvoiceDate:   pgDate(day),
+		TaxDate:       pgDate(day),
+		SalesAmount:   pgNum(sales),
+		TaxAmount:     pgNum(tax),
+		InvoiceAmount: pgNum(sales + tax),
+		Void:          pgtype.Bool{Bool: void, Valid: true},
+	}
+}
+
+func TestReconcileInvoiceAnrok(t *testing.T) {
+	invoices := []NsInvoice{
+		{DocumentNumber: "INV-1", Date: pgDate("2025-03-01").Time, Sales: 150, Tax: 12, TaxLines: 1}, // matches
+		{DocumentNumber: "INV-2", Date: pgDate("2025-03-01").Time, Sales: 100},                       // sales and date differ
+		{DocumentNumber: "INV-3", Date: pgDate("2025-03-01").Time, Sales: 100},                       // not in Anrok
+		{DocumentNumber: "INV-4", Date: pgDate("2025-03-01").Time, Sales: 100},                       // voided in Anrok
+		{DocumentNumber: "INV-5", Date: pgDate("2025-03-01").Time},                                   // voided in both
+	}
+	txns := []db.AnrokTransaction{
+		anrokTxn("INV-1", "2025-03-01", 150, 12, false),
+		anrokTxn("INV-2", "2025-03-05", 90, 5, false),
+		anrokTxn("INV-4", "2025-03-01", 100, 0, true),
+		anrokTxn("INV-5", "2025-03-01", 100, 0, true),
+		anrokTxn("INV-9", "2025-03-01", 10, 0, false), // not in NetSuite
+		anrokTxn("INV-8", "2025-03-01", 10, 0, true),  // voided, not in NetSuite: fine
+	}
+
+	got := ReconcileInvoiceAnrok(invoices, txns, DefaultTolerance)
+
+	if got.Matched != 2 {
+		t.Errorf("Matched = %d, expected 2", got.Matched)
+	}
+
+	byStatus := make(map[string]string)
+	for _, f := range got.Findings {
+		byStatus[f.Status] += f.DocumentNumber
+	}
+	expected := map[string]string{
+		StatusMismatch:     "INV-2",
+		StatusMissingAnrok: "INV-3",
+		StatusVoidedAnrok:  "INV-4",
+		StatusMissingNS:    "INV-9",
+	}
+	for status, want := range expected {
+		if byStatus[status] != want {
+			t.Errorf("%s = %q, expected %q", status, byStatus[status], want)
+		}
+	}
+	if len(got.Findings) != len(expected) {
+		t.Errorf("Findings = %+v, expected %d", got.Findings, len(expected))
+	}
+
+	for _, f := range got.Findings {
+		if f.Status == StatusMismatch {
+			// No tax lines on INV-2, so tax and total are not compared
+			if diffs := strings.Join(f.Diffs, "; "); diffs != "invoice date 2025-03-01 vs 2025-03-05; sales 100.00 vs 90.00" {
+				t.Errorf("Diffs = %q", diffs)
+			}
+		}
+	}
+}
+
+func TestCompareInvoiceAnrok_TaxPeriod(t *testing.T) {
+	inv := NsInvoice{DocumentNumber: "INV-1", Date: pgDate("2025-03-31").Time, Sales: 100, Tax: 8, TaxLines: 1}
+	txn := anrokTxn("INV-1", "2025-03-31", 100, 10, false)
+	txn.TaxDate = pgDate("2025-04-02")
+
+	diffs := strings.Join(compareInvoiceAnrok(inv, txn, DefaultTolerance), "; ")
+
+	for _, want := range []string{"tax date 2025-04-02 in another period", "tax 8.00 vs 10.00", "total 108.00 vs 110.00"} {
+		if !strings.Contains(diffs, want) {
+			t.Errorf("Diffs = %q, missing %q", diffs, want)
+		}
+	}
+}
+
+func TestInvoiceAnrokReport_FlagsVoided(t *testing.T) {
+	r := InvoiceAnrokReport(InvoiceAnrokResult{Findings: []InvoiceFinding{
+		{Status: StatusVoidedAnrok, DocumentNumber: "INV-4", Invoice: &NsInvoice{Sales: 100}},
+		{Status: StatusMissingAnrok, DocumentNumber: "INV-3", Invoice: &NsInvoice{Sales: 100}},
+	}})
+
+	if !r.Rows[0].Flagged || r.Rows[1].Flagged {
+		t.Error("only voided-but-open invoices should be flagged")
+	}
+	if len(r.Rows[0].Cells) != len(r.Columns) {
+		t.Errorf("row has %d cells, expected %d", len(r.Rows[0].Cells), len(r.Columns))
+	}
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ----------------------------------------
+	ARR / MRR METRICS
+---------------------------------------- */
+
+// MetricsReport lists metrics_monthly with month-over-month changes. Months
+// where MRR fell are flagged.
+func MetricsReport(months []db.MetricsMonthly) Report {
+	rows := make([]Row, len(months))
+	declines := 0
+	for i, m := range months {
+		mrr := num(m.Mrr)
+		delta, pct, customers := "", "", ""
+		fell := false
+		if i > 0 {
+			prev := months[i-1]
+			change := mrr - num(prev.Mrr)
+			delta = money(change)
+			if p := num(prev.Mrr); p != 0 {
+				pct = percent(change / p)
+			}
+			customers = fmt.Sprintf("%+d", m.Customers-prev.Customers)
+			fell = change < 0
+		}
+		if fell {
+			declines++
+		}
+
+		rows[i] = Row{
+			Cells: []string{
+				date(m.Period).Format("2006-01"), money(mrr), delta, pct, money(num(m.Arr)),
+				money(num(m.NewMrr)), money(num(m.ExpansionMrr)), money(-num(m.ContractionMrr)), money(-num(m.ChurnedMrr)),
+				money(num(m.Bookings)), fmt.Sprint(m.Customers), customers,
+			},
+			Flagged: fell,
+		}
+	}
+
+	summary := "No metrics yet; import opportunity detail or recompute."
+	if n := len(months); n > 0 {
+		last := months[n-1]
+		summary = fmt.Sprintf("%d months; latest %s: MRR %s, ARR %s, %d customers; %d months with falling MRR",
+			n, date(last.Period).Format("2006-01"), money(num(last.Mrr)), money(num(last.Arr)), last.Customers, declines)
+	}
+
+	return Report{
+		Title: "ARR / MRR Metrics",
+		Columns: []Column{
+			{Title: "Period", Width: 8},
+			{Title: "MRR", Width: 14},
+			{Title: "MRR Change", Width: 14},
+			{Title: "Change %", Width: 8},
+			{Title: "ARR", Width: 16},
+			{Title: "New", Width: 12},
+			{Title: "Expansion", Width: 12},
+			{Title: "Contraction", Width: 12},
+			{Title: "Churn", Width: 12},
+			{Title: "Bookings", Width: 14},
+			{Title: "Customers", Width: 9},
+			{Title: "Cust. Change", Width: 12},
+		},
+		Rows:    rows,
+		Summary: summary,
+	}
+}
+
+// LoadMetrics loads metrics_monthly and renders it.
+func LoadMetrics(ctx context.Context, q *db.Queries) (Report, error) {
+	months, err := q.ListMonthlyMetrics(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading metrics_monthly: %w", err)
+	}
+	return MetricsReport(months), nil
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ========================================
+	MetricsReport Tests
+======================================== */
+
+func TestMetricsReport(t *testing.T) {
+	months := []db.MetricsMonthly{
+		{Period: pgDate("2025-01-01"), Mrr: pgNum(100), Arr: pgNum(1200), NewMrr: pgNum(100), ExpansionMrr: pgNum(0), ContractionMrr: pgNum(0), ChurnedMrr: pgNum(0), Bookings: pgNum(1200), Customers: 1},
+		{Period: pgDate("2025-02-01"), Mrr: pgNum(150), Arr: pgNum(1800), NewMrr: pgNum(50), ExpansionMrr: pgNum(0), ContractionMrr: pgNum(0), ChurnedMrr: pgNum(0), Bookings: pgNum(0), Customers: 2},
+		{Period: pgDate("2025-03-01"), Mrr: pgNum(120), Arr: pgNum(1440), NewMrr: pgNum(0), ExpansionMrr: pgNum(0), ContractionMrr: pgNum(0), ChurnedMrr: pgNum(30), Bookings: pgNum(0), Customers: 1},
+	}
+
+	r := MetricsReport(months)
+
+	tests := []struct {
+		row      int
+		expected string
+		flagged  bool
+	}{
+		{0, "2025-01|100.00|||1,200.00|100.00|0.00|0.00|0.00|1,200.00|1|", false},
+		{1, "2025-02|150.00|50.00|50.0%|1,800.00|50.00|0.00|0.00|0.00|0.00|2|+1", false},
+		{2, "2025-03|120.00|-30.00|-20.0%|1,440.00|0.00|0.00|0.00|-30.00|0.00|1|-1", true},
+	}
+	for _, tt := range tests {
+		got := strings.Join(r.Rows[tt.row].Cells, "|")
+		if got != tt.expected || r.Rows[tt.row].Flagged != tt.flagged {
+			t.Errorf("Rows[%d] = %q (flagged %v), expected %q (flagged %v)", tt.row, got, r.Rows[tt.row].Flagged, tt.expected, tt.flagged)
+		}
+	}
+	if !strings.Contains(r.Summary, "latest 2025-03: MRR 120.00") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
@@ -0,0 +1,204 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/sfid"
+)
+
+/* ----------------------------------------
+	SFDC OPPORTUNITY LINES <-> NS SALES ORDER LINES
+---------------------------------------- */
+
+// Tolerance bounds how far a matched line's values may differ before it is
+// reported. Quantity and Price are absolute; Days applies to service dates.
+type Tolerance struct {
+	Quantity float64
+	Price    float64
+	Days     int
+}
+
+// DefaultTolerance ignores rounding noise but reports any real difference.
+var DefaultTolerance = Tolerance{Quantity: 0.0001, Price: 0.01, Days: 0}
+
+// Reconciliation statuses.
+const (
+	StatusMismatch    = "Mismatch"
+	StatusMissingNS   = "Missing in NetSuite"
+	StatusMissingSFDC = "Missing in Salesforce"
+	StatusMultipleNS  = "Multiple NetSuite lines"
+)
+
+// OppSoFinding is one SFDC or SO line that failed to reconcile.
+type OppSoFinding struct {
+	Status string
+	LineID string
+	Opp    *db.SfdcOppDetail // nil when missing in Salesforce
+	So     []db.NsSoDetail   // empty when missing in NetSuite
+	Diffs  []string
+}
+
+// OppSoResult is the outcome of matching SFDC lines to SO lines.
+type OppSoResult struct {
+	OppLines int
+	SoLines  int
+	Matched  int // Matched lines with no differences
+	Findings []OppSoFinding
+}
+
+// ReconcileOppSo matches opportunity lines to sales order lines on the SFDC
+// line ID, comparing 15- and 18-character IDs as equal, and reports unmatched
+// lines and matched lines whose quantity, price or service dates differ by
+// more than tol.
+func ReconcileOppSo(opps []db.SfdcOppDetail, sos []db.NsSoDetail, tol Tolerance) OppSoResult {
+	result := OppSoResult{OppLines: len(opps), SoLines: len(sos)}
+
+	soByID := make(map[string][]db.NsSoDetail)
+	for _, so := range sos {
+		id := text(so.SfdcOppLineID)
+		if id == "" {
+			result.Findings = append(result.Findings, OppSoFinding{Status: StatusMissingSFDC, So: []db.NsSoDetail{so}})
+			continue
+		}
+		key := sfid.Key(id)
+		soByID[key] = append(soByID[key], so)
+	}
+
+	seen := make(map[string]bool)
+	for i := range opps {
+		opp := &opps[i]
+		id := text(opp.OpportunityProductCasesafeID)
+		key := sfid.Key(id)
+		seen[key] = true
+
+		lines := soByID[key]
+		switch {
+		case id == "" || len(lines) == 0:
+			result.Findings = append(result.Findings, OppSoFinding{Status: StatusMissingNS, LineID: id, Opp: opp})
+		case len(lines) > 1:
+			result.Findings = append(result.Findings, OppSoFinding{
+				Status: StatusMultipleNS, LineID: id, Opp: opp, So: lines,
+				Diffs: []string{fmt.Sprintf("%d SO lines share this line ID", len(lines))},
+			})
+		default:
+			if diffs := compareOppSo(*opp, lines[0], tol); len(diffs) > 0 {
+				result.Findings = append(result.Findings, OppSoFinding{Status: StatusMismatch, LineID: id, Opp: opp, So: lines, Diffs: diffs})
+			} else {
+				result.Matched++
+			}
+		}
+	}
+
+	for key, lines := range soByID {
+		if seen[key] {
+			continue
+		}
+		for _, so := range lines {
+			result.Findings = append(result.Findings, OppSoFinding{Status: StatusMissingSFDC, LineID: text(so.SfdcOppLineID), So: []db.NsSoDetail{so}})
+		}
+	}
+
+	sort.SliceStable(result.Findings, func(i, j int) bool {
+		a, b := result.Findings[i], result.Findings[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		return a.LineID < b.LineID
+	})
+	return result
+}
+
+// compareOppSo lists the differences between a matched SFDC line and SO line.
+func compareOppSo(opp db.SfdcOppDetail, so db.NsSoDetail, tol Tolerance) []string {
+	var diffs []string
+
+	if q1, q2 := num(opp.Quantity), num(so.Quantity); math.Abs(q1-q2) > tol.Quantity {
+		diffs = append(diffs, fmt.Sprintf("quantity %s vs %s", quantity(q1), quantity(q2)))
+	}
+	if p1, p2 := num(opp.SalesPrice), num(so.UnitPrice); math.Abs(p1-p2) > tol.Price {
+		diffs = append(diffs, fmt.Sprintf("price %s vs %s", money(p1), money(p2)))
+	}
+	if d := dateDiff("start", date(opp.StartDate), date(so.LineStartDate), tol.Days); d != "" {
+		diffs = append(diffs, d)
+	}
+	if d := dateDiff("end", date(opp.EndDate), date(so.LineEndDate), tol.Days); d != "" {
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// dateDiff describes a date difference beyond days, including one side missing.
+func dateDiff(label string, a, b time.Time, days int) string {
+	if a.IsZero() && b.IsZero() {
+		return ""
+	}
+	if a.IsZero() || b.IsZero() || daysApart(a, b) > days {
+		return fmt.Sprintf("%s %s vs %s", label, orNone(formatDate(a)), orNone(formatDate(b)))
+	}
+	return ""
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+// OppSoReport renders the reconciliation result.
+func OppSoReport(r OppSoResult) Report {
+	counts := make(map[string]int)
+	rows := make([]Row, len(r.Findings))
+	for i, f := range r.Findings {
+		counts[f.Status]++
+
+		var opp, account, product, so string
+		if f.Opp != nil {
+			opp = text(f.Opp.OpportunityName)
+			account = text(f.Opp.AccountName)
+			product = text(f.Opp.ProductCode)
+		}
+		if len(f.So) > 0 {
+			so = text(f.So[0].SoNumber)
+			if product == "" {
+				product = text(f.So[0].ItemName)
+			}
+		}
+		rows[i] = Row{Cells: []string{f.Status, orNone(f.LineID), opp, account, so, product, strings.Join(f.Diffs, "; ")}}
+	}
+
+	return Report{
+		Title: "SFDC vs NetSuite SO Lines",
+		Columns: []Column{
+			{Title: "Status", Width: 24},
+			{Title: "Line ID", Width: 18},
+			{Title: "Opportunity", Width: 30},
+			{Title: "Account", Width: 24},
+			{Title: "SO #", Width: 12},
+			{Title: "Product", Width: 16},
+			{Title: "Differences", Width: 60},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d SFDC lines, %d SO lines: %d matched, %d mismatched, %d missing in NetSuite, %d missing in Salesforce, %d with multiple SO lines",
+			r.OppLines, r.SoLines, r.Matched, counts[StatusMismatch], counts[StatusMissingNS], counts[StatusMissingSFDC], counts[StatusMultipleNS]),
+	}
+}
+
+// LoadOppSo loads both sides and builds the reconciliation report.
+func LoadOppSo(ctx context.Context, q *db.Queries, tol Tolerance) (Report, error) {
+	opps, err := q.ListSfdcOppDetail(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading sfdc_opp_detail: %w", err)
+	}
+	sos, err := q.ListNsSoDetail(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading ns_so_detail: %w", err)
+	}
+	return OppSoReport(ReconcileOppSo(opps, sos, tol)), nil
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ========================================
+	ReconcileOppSo Tests
+======================================== */
+
+func oppLine(id string, qty, price float64, start, end string) db.SfdcOppDetail {
+	return db.SfdcOppDetail{
+		OpportunityProductCasesafeID: pgText(id),
+		OpportunityName:              pgText("Opp " + id),
+		Quantity:                     pgNum(qty),
+		SalesPrice:                   pgNum(price),
+		StartDate:                    pgDate(start),
+		EndDate:                      pgDate(end),
+	}
+}
+
+func soLine(id string, qty, price float64, start, end string) db.NsSoDetail {
+	return db.NsSoDetail{
+		SfdcOppLineID: pgText(id),
+		SoNumber:      pgText("SO-" + id),
+		Quantity:      pgNum(qty),
+		UnitPrice:     pgNum(price),
+		LineStartDate: pgDate(start),
+		LineEndDate:   pgDate(end),
+	}
+}
+
+func TestReconcileOppSo(t *testing.T) {
+	opps := []db.SfdcOppDetail{
+		oppLine("00kA0000001aaaaIAA", 10, 100, "2025-01-01", "2025-12-31"), // matches
+		oppLine("00kA0000001bbbbIAA", 10, 100, "2025-01-01", "2025-12-31"), // qty and start differ
+		oppLine("00kA0000001cccc", 1, 50, "2025-01-01", "2025-12-31"),      // 15-char, only in SFDC
+	}
+	sos := []db.NsSoDetail{
+		soLine("00kA0000001aaaa", 10, 100.004, "2025-01-01", "2025-12-31"), // 15-char form of the first line
+		soLine("00kA0000001bbbbIAA", 12, 100, "2025-01-15", "2025-12-31"),
+		soLine("00kA0000001ddddIAA", 1, 1, "2025-01-01", "2025-12-31"), // only in NS
+		soLine("", 1, 1, "", ""), // no SFDC line ID
+	}
+
+	got := ReconcileOppSo(opps, sos, DefaultTolerance)
+
+	if got.OppLines != 3 || got.SoLines != 4 || got.Matched != 1 {
+		t.Errorf("counts = %d/%d/%d, expected 3 SFDC, 4 SO, 1 matched", got.OppLines, got.SoLines, got.Matched)
+	}
+
+	statuses := make(map[string][]string)
+	for _, f := range got.Findings {
+		statuses[f.Status] = append(statuses[f.Status], f.LineID)
+	}
+	if ids := statuses[StatusMismatch]; len(ids) != 1 || ids[0] != "00kA0000001bbbbIAA" {
+		t.Errorf("mismatches = %v", ids)
+	}
+	if ids := statuses[StatusMissingNS]; len(ids) != 1 || ids[0] != "00kA0000001cccc" {
+		t.Errorf("missing in NetSuite = %v", ids)
+	}
+	if ids := statuses[StatusMissingSFDC]; len(ids) != 2 {
+		t.Errorf("missing in Salesforce = %v, expected the unmatched and the blank SO line", ids)
+	}
+
+	for _, f := range got.Findings {
+		if f.Status == StatusMismatch {
+			diffs := strings.Join(f.Diffs, "; ")
+			if diffs != "quantity 10 vs 12; start 2025-01-01 vs 2025-01-15" {
+				t.Errorf("Diffs = %q", diffs)
+			}
+		}
+	}
+}
+
+func TestReconcileOppSo_Tolerance(t *testing.T) {
+	opps := []db.SfdcOppDetail{oppLine("00kA0000001aaaaIAA", 10, 100, "2025-01-01", "2025-12-31")}
+	sos := []db.NsSoDetail{soLine("00kA0000001aaaaIAA", 10, 100.5, "2025-01-02", "2025-12-31")}
+
+	if got := ReconcileOppSo(opps, sos, DefaultTolerance); got.Matched != 0 {
+		t.Error("price and start differences should be reported with the default tolerance")
+	}
+
+	loose := Tolerance{Quantity: 0.0001, Price: 1, Days: 1}
+	if got := ReconcileOppSo(opps, sos, loose); got.Matched != 1 {
+		t.Errorf("Findings = %+v, expected a match within the loose tolerance", got.Findings)
+	}
+}
+
+func TestReconcileOppSo_MultipleSoLines(t *testing.T) {
+	opps := []db.SfdcOppDetail{oppLine("00kA0000001aaaaIAA", 10, 100, "", "")}
+	sos := []db.NsSoDetail{
+		soLine("00kA0000001aaaaIAA", 5, 100, "", ""),
+		soLine("00kA0000001aaaaIAA", 5, 100, "", ""),
+	}
+
+	got := ReconcileOppSo(opps, sos, DefaultTolerance)
+
+	if len(got.Findings) != 1 || got.Findings[0].Status != StatusMultipleNS {
+		t.Errorf("Findings = %+v, expected one multiple-lines finding", got.Findings)
+	}
+}
+
+func TestOppSoReport(t *testing.T) {
+	r := OppSoReport(ReconcileOppSo(
+		[]db.SfdcOppDetail{oppLine("00kA0000001cccc", 1, 50, "", "")},
+		nil, DefaultTolerance))
+
+	if len(r.Rows) != 1 || len(r.Rows[0].Cells) != len(r.Columns) {
+		t.Fatalf("Rows = %+v, expected one row with every column", r.Rows)
+	}
+	if !strings.Contains(r.Summary, "1 missing in NetSuite") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
@@ -0,0 +1,231 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ----------------------------------------
+	PRICE BOOK COMPLIANCE AND DISCOUNTS
+---------------------------------------- */
+
+// DefaultDiscountThreshold is the discount above which lines are flagged.
+const DefaultDiscountThreshold = 0.20
+
+// Discount rollup dimensions.
+const (
+	ByProduct = "Product"
+	ByAccount = "Account"
+	ByPeriod  = "Fiscal Period"
+)
+
+// PriceLine is one opportunity line compared against the price book.
+type PriceLine struct {
+	Line         db.SfdcOppDetail
+	BookPrice    float64 // Price book list price, when the product has an entry
+	HasBook      bool
+	ListMismatch bool    // The line's list_price matches no price book entry
+	Discount     float64 // 1 - sales_price / list price, 0 when there is no list price
+	ListAmount   float64 // quantity x list price
+	SalesAmount  float64 // quantity x sales_price
+}
+
+// AnalyzePriceBook compares each opportunity line with the price book entries
+// for its product_code. The discount is measured against the price book price
+// when there is one, otherwise against the line's own list_price.
+func AnalyzePriceBook(lines []db.SfdcOppDetail, book []db.SfdcPriceBook, tol Tolerance) []PriceLine {
+	prices := make(map[string][]float64)
+	for _, entry := range book {
+		code := strings.ToUpper(text(entry.ProductCode))
+		if code != "" && entry.ListPrice.Valid {
+			prices[code] = append(prices[code], num(entry.ListPrice))
+		}
+	}
+
+	result := make([]PriceLine, len(lines))
+	for i, line := range lines {
+		p := PriceLine{Line: line}
+		list := num(line.ListPrice)
+		base := list
+
+		if entries := prices[strings.ToUpper(text(line.ProductCode))]; len(entries) > 0 {
+			p.HasBook = true
+			p.BookPrice = entries[0]
+			p.ListMismatch = true
+			for _, price := range entries {
+				if math.Abs(price-list) <= tol.Price {
+					p.BookPrice = price
+					p.ListMismatch = false
+					break
+				}
+			}
+			base = p.BookPrice
+		}
+
+		qty := num(line.Quantity)
+		sales := num(line.SalesPrice)
+		if base > 0 {
+			p.Discount = 1 - sales/base
+		}
+		p.ListAmount = qty * base
+		p.SalesAmount = qty * sales
+		result[i] = p
+	}
+	return result
+}
+
+// PriceLinesReport lists every line with its discount. Lines over threshold,
+// with a list price that disagrees with the price book, or with no price book
+// entry are flagged.
+func PriceLinesReport(lines []PriceLine, threshold float64) Report {
+	rows := make([]Row, len(lines))
+	over, mismatched, missing := 0, 0, 0
+	for i, p := range lines {
+		var issues []string
+		if p.Discount > threshold {
+			over++
+			issues = append(issues, "discount over "+percent(threshold))
+		}
+		if !p.HasBook {
+			missing++
+			issues = append(issues, "not in price book")
+		} else if p.ListMismatch {
+			mismatched++
+			issues = append(issues, "list price "+money(num(p.Line.ListPrice))+" vs price book "+money(p.BookPrice))
+		}
+
+		book := ""
+		if p.HasBook {
+			book = money(p.BookPrice)
+		}
+		rows[i] = Row{
+			Cells: []string{
+				text(p.Line.OpportunityName), text(p.Line.AccountName), text(p.Line.ProductCode), fiscalPeriod(p.Line),
+				quantity(num(p.Line.Quantity)), money(num(p.Line.ListPrice)), book, money(num(p.Line.SalesPrice)),
+				percent(p.Discount), strings.Join(issues, "; "),
+			},
+			Flagged: len(issues) > 0,
+		}
+	}
+
+	return Report{
+		Title: "Price Book Compliance",
+		Columns: []Column{
+			{Title: "Opportunity", Width: 30},
+			{Title: "Account", Width: 24},
+			{Title: "Product", Width: 14},
+			{Title: "Period", Width: 10},
+			{Title: "Qty", Width: 8},
+			{Title: "List", Width: 12},
+			{Title: "Price Book", Width: 12},
+			{Title: "Sales Price", Width: 12},
+			{Title: "Discount", Width: 8},
+			{Title: "Issues", Width: 50},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d lines: %d over the %s discount threshold, %d with a list price that disagrees with the price book, %d with no price book entry",
+			len(lines), over, percent(threshold), mismatched, missing),
+	}
+}
+
+// DiscountRollupReport sums list and sales amounts per product, account or
+// fiscal period. The discount is weighted by amount; groups over threshold are flagged.
+func DiscountRollupReport(lines []PriceLine, by string, threshold float64) Report {
+	type group struct {
+		lines, over int
+		list, sales float64
+	}
+	groups := make(map[string]*group)
+	for _, p := range lines {
+		key := rollupKey(p.Line, by)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+		}
+		g.lines++
+		g.list += p.ListAmount
+		g.sales += p.SalesAmount
+		if p.Discount > threshold {
+			g.over++
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([]Row, len(keys))
+	flagged := 0
+	for i, k := range keys {
+		g := groups[k]
+		discount := 0.0
+		if g.list > 0 {
+			discount = 1 - g.sales/g.list
+		}
+		if discount > threshold {
+			flagged++
+		}
+		rows[i] = Row{
+			Cells:   []string{orNone(k), fmt.Sprint(g.lines), money(g.list), money(g.sales), money(g.list - g.sales), percent(discount), fmt.Sprint(g.over)},
+			Flagged: discount > threshold,
+		}
+	}
+
+	return Report{
+		Title: "Discount by " + by,
+		Columns: []Column{
+			{Title: by, Width: 30},
+			{Title: "Lines", Width: 6},
+			{Title: "List Amount", Width: 16},
+			{Title: "Sales Amount", Width: 16},
+			{Title: "Discount Amount", Width: 16},
+			{Title: "Discount", Width: 8},
+			{Title: "Lines Over", Width: 10},
+		},
+		Rows:    rows,
+		Summary: fmt.Sprintf("%d groups, %d with a weighted discount over %s", len(keys), flagged, percent(threshold)),
+	}
+}
+
+func rollupKey(line db.SfdcOppDetail, by string) string {
+	switch by {
+	case ByProduct:
+		return text(line.ProductCode)
+	case ByAccount:
+		return text(line.AccountName)
+	default:
+		return fiscalPeriod(line)
+	}
+}
+
+// fiscalPeriod returns the line's fiscal_period, or its close month when blank.
+func fiscalPeriod(line db.SfdcOppDetail) string {
+	if p := text(line.FiscalPeriod); p != "" {
+		return p
+	}
+	if d := date(line.CloseDate); !d.IsZero() {
+		return d.Format("2006-01")
+	}
+	return ""
+}
+
+// LoadPriceLines loads opportunity lines and the price book and compares them.
+func LoadPriceLines(ctx context.Context, q *db.Queries) ([]PriceLine, error) {
+	lines, err := q.ListSfdcOppDetail(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading sfdc_opp_detail: %w", err)
+	}
+	book, err := q.ListSfdcPriceBook(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading sfdc_price_book: %w", err)
+	}
+	return AnalyzePriceBook(lines, book, DefaultTolerance), nil
+}
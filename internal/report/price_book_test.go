@@ -0,0 +1,143 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+)
+
+/* ========================================
+	AnalyzePriceBook Tests
+======================================== */
+
+func pricedLine(account, product, period string, qty, list, sales float64) db.SfdcOppDetail {
+	return db.SfdcOppDetail{
+		OpportunityName: pgText("Opp"),
+		AccountName:     pgText(account),
+		ProductCode:     pgText(product),
+		FiscalPeriod:    pgText(period),
+		Quantity:        pgNum(qty),
+		ListPrice:       pgNum(list),
+		SalesPrice:      pgNum(sales),
+	}
+}
+
+func bookEntry(product string, price float64) db.SfdcPriceBook {
+	return db.SfdcPriceBook{ProductCode: pgText(product), ListPrice: pgNum(price)}
+}
+
+func TestAnalyzePriceBook(t *testing.T) {
+	book := []db.SfdcPriceBook{
+		bookEntry("PLAT", 100),
+		bookEntry("PLAT", 90), // second price book
+		bookEntry("SUP", 50),
+	}
+	lines := []db.SfdcOppDetail{
+		pricedLine("Acme", "PLAT", "Q1-2025", 10, 90, 72), // matches the second entry, 20% off
+		pricedLine("Acme", "sup", "Q1-2025", 2, 40, 40),   // list disagrees; 20% off book
+		pricedLine("Globex", "NEW", "Q2-2025", 1, 10, 5),  // no price book entry
+	}
+
+	got := AnalyzePriceBook(lines, book, DefaultTolerance)
+
+	tests := []struct {
+		name        string
+		line        PriceLine
+		hasBook     bool
+		mismatch    bool
+		bookPrice   float64
+		discount    float64
+		listAmount  float64
+		salesAmount float64
+	}{
+		{"second price book", got[0], true, false, 90, 0.2, 900, 720},
+		{"list disagrees", got[1], true, true, 50, 0.2, 100, 80},
+		{"not in book", got[2], false, false, 0, 0.5, 10, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.line
+			if p.HasBook != tt.hasBook || p.ListMismatch != tt.mismatch || p.BookPrice != tt.bookPrice {
+				t.Errorf("book = %v/%v/%v, expected %v/%v/%v", p.HasBook, p.ListMismatch, p.BookPrice, tt.hasBook, tt.mismatch, tt.bookPrice)
+			}
+			if !near(p.Discount, tt.discount) || !near(p.ListAmount, tt.listAmount) || !near(p.SalesAmount, tt.salesAmount) {
+				t.Errorf("discount/list/sales = %v/%v/%v, expected %v/%v/%v",
+					p.Discount, p.ListAmount, p.SalesAmount, tt.discount, tt.listAmount, tt.salesAmount)
+			}
+		})
+	}
+}
+
+func near(a, b float64) bool {
+	d := a - b
+	return d < 1e-9 && d > -1e-9
+}
+
+/* ========================================
+	Price Book Report Tests
+======================================== */
+
+func TestPriceLinesReport_Flags(t *testing.T) {
+	lines := AnalyzePriceBook([]db.SfdcOppDetail{
+		pricedLine("Acme", "PLAT", "Q1", 1, 100, 100), // compliant
+		pricedLine("Acme", "PLAT", "Q1", 1, 100, 70),  // 30% off
+		pricedLine("Acme", "PLAT", "Q1", 1, 110, 100), // list disagrees
+	}, []db.SfdcPriceBook{bookEntry("PLAT", 100)}, DefaultTolerance)
+
+	r := PriceLinesReport(lines, 0.25)
+
+	flags := [3]bool{r.Rows[0].Flagged, r.Rows[1].Flagged, r.Rows[2].Flagged}
+	if flags != [3]bool{false, true, true} {
+		t.Errorf("Flagged = %v, expected [false true true]", flags)
+	}
+	if issues := r.Rows[2].Cells[len(r.Columns)-1]; issues != "list price 110.00 vs price book 100.00" {
+		t.Errorf("Issues = %q", issues)
+	}
+	if !strings.Contains(r.Summary, "1 over the 25.0% discount threshold") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
+
+func TestDiscountRollupReport(t *testing.T) {
+	lines := AnalyzePriceBook([]db.SfdcOppDetail{
+		pricedLine("Acme", "PLAT", "Q1", 1, 100, 50),
+		pricedLine("Acme", "SUP", "Q1", 3, 100, 100),
+		pricedLine("Globex", "PLAT", "Q2", 1, 100, 90),
+	}, nil, DefaultTolerance)
+
+	tests := []struct {
+		by       string
+		expected []string
+	}{
+		{ByAccount, []string{"Acme|2|400.00|350.00|50.00|12.5%|1", "Globex|1|100.00|90.00|10.00|10.0%|0"}},
+		{ByProduct, []string{"PLAT|2|200.00|140.00|60.00|30.0%|1", "SUP|1|300.00|300.00|0.00|0.0%|0"}},
+		{ByPeriod, []string{"Q1|2|400.00|350.00|50.00|12.5%|1", "Q2|1|100.00|90.00|10.00|10.0%|0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			r := DiscountRollupReport(lines, tt.by, 0.25)
+			if len(r.Rows) != len(tt.expected) {
+				t.Fatalf("rows = %d, expected %d", len(r.Rows), len(tt.expected))
+			}
+			for i, want := range tt.expected {
+				if got := strings.Join(r.Rows[i].Cells, "|"); got != want {
+					t.Errorf("row %d = %q, expected %q", i, got, want)
+				}
+			}
+		})
+	}
+
+	if r := DiscountRollupReport(lines, ByProduct, 0.25); !r.Rows[0].Flagged || r.Rows[1].Flagged {
+		t.Error("only PLAT (30% weighted discount) should be flagged")
+	}
+}
+
+func TestFiscalPeriod_FallsBackToCloseMonth(t *testing.T) {
+	line := db.SfdcOppDetail{CloseDate: pgDate("2025-03-15")}
+	if got := fiscalPeriod(line); got != "2025-03" {
+		t.Errorf("fiscalPeriod() = %q, expected %q", got, "2025-03")
+	}
+}
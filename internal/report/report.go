@@ -0,0 +1,109 @@
+// Package report builds the reconciliation and analysis reports shown in the
+// Reports menu. Each report loads its rows with sqlc queries, does the
+// matching and arithmetic in Go, and returns a Report that the TUI renders as
+// a table and can export to CSV.
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Timeout is the maximum duration for loading and building one report.
+const Timeout = 60 * time.Second
+
+// Dir is where exported reports are written.
+const Dir = "accounting/reports"
+
+// Report is a titled table of results.
+type Report struct {
+	Title   string
+	Columns []Column
+	Rows    []Row
+	Summary string // Shown above the table
+}
+
+// Column is one report column. Width is the preferred display width.
+type Column struct {
+	Title string
+	Width int
+}
+
+// Row is one report line. Flagged rows need attention (over a threshold,
+// mismatched, missing) and are marked in the TUI and the export.
+type Row struct {
+	Cells   []string
+	Flagged bool
+}
+
+// Flagged returns the number of flagged rows.
+func (r Report) Flagged() int {
+	n := 0
+	for _, row := range r.Rows {
+		if row.Flagged {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteCSV writes the report as CSV with a header row. A leading "flag"
+// column holds "!" for flagged rows.
+func (r Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"flag"}
+	for _, c := range r.Columns {
+		header = append(header, c.Title)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, row := range r.Rows {
+		flag := ""
+		if row.Flagged {
+			flag = "!"
+		}
+		if err := cw.Write(append([]string{flag}, row.Cells...)); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Export writes the report to Dir as <title>-<timestamp>.csv and returns the path.
+func Export(r Report, now time.Time) (string, error) {
+	if err := os.MkdirAll(Dir, 0o755); err != nil {
+		return "", fmt.Errorf("creating %s: %w", Dir, err)
+	}
+
+	path := filepath.Join(Dir, fmt.Sprintf("%s-%s.csv", slug(r.Title), now.Format("20060102-150405")))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("creating %s: %w", path, err)
+	}
+
+	if err := r.WriteCSV(f); err != nil {
+		f.Close()
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("writing %s: %w", path, err)
+	}
+	return path, nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+func slug(s string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	Test Helpers
+======================================== */
+
+func pgText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+func pgNum(f float64) pgtype.Numeric {
+	var n pgtype.Numeric
+	if err := n.Scan(fmt.Sprintf("%v", f)); err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func pgDate(s string) pgtype.Date {
+	if s == "" {
+		return pgtype.Date{}
+	}
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		panic(err)
+	}
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+/* ========================================
+	Report Tests
+======================================== */
+
+func TestReport_WriteCSV(t *testing.T) {
+	r := Report{
+		Title:   "Test",
+		Columns: []Column{{Title: "Name"}, {Title: "Amount"}},
+		Rows: []Row{
+			{Cells: []string{"Acme, Inc.", "1,200.00"}, Flagged: true},
+			{Cells: []string{"Globex", "5.00"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	want := "flag,Name,Amount\n!,\"Acme, Inc.\",\"1,200.00\"\n,Globex,5.00\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() =\n%s\nexpected\n%s", buf.String(), want)
+	}
+	if r.Flagged() != 1 {
+		t.Errorf("Flagged() = %d, expected 1", r.Flagged())
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"SFDC vs NetSuite SO Lines", "sfdc-vs-netsuite-so-lines"},
+		{"AR Aging (as of 2025-03-31)", "ar-aging-as-of-2025-03-31"},
+		{"  --  ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := slug(tt.input); got != tt.expected {
+			t.Errorf("slug(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+/* ========================================
+	Value Formatting Tests
+======================================== */
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0.00"},
+		{5, "5.00"},
+		{1234.5, "1,234.50"},
+		{-1234567.891, "-1,234,567.89"},
+		{999.999, "1,000.00"},
+	}
+
+	for _, tt := range tests {
+		if got := money(tt.input); got != tt.expected {
+			t.Errorf("money(%v) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{10, "10"},
+		{2.5, "2.5"},
+		{0.12345, "0.1235"},
+	}
+
+	for _, tt := range tests {
+		if got := quantity(tt.input); got != tt.expected {
+			t.Errorf("quantity(%v) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNum_Null(t *testing.T) {
+	if got := num(pgtype.Numeric{}); got != 0 {
+		t.Errorf("num(NULL) = %v, expected 0", got)
+	}
+	if got := num(pgNum(12.34)); got != 12.34 {
+		t.Errorf("num(12.34) = %v, expected 12.34", got)
+	}
+}
@@ -0,0 +1,165 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/revrec"
+)
+
+/* ----------------------------------------
+	REVENUE SCHEDULE
+---------------------------------------- */
+
+// ByCustomer groups the revenue schedule by customer.
+const ByCustomer = "Customer"
+
+// revenueTotals sums one group of schedule rows by source.
+type revenueTotals struct {
+	sfdc, ns  float64
+	sfdcLines map[string]bool
+	nsLines   map[string]bool
+}
+
+func (t *revenueTotals) add(r db.RevenueSchedule) {
+	line := fmt.Sprintf("%x", r.LineID.Bytes)
+	switch r.Source {
+	case revrec.SourceSFDC:
+		t.sfdc += num(r.Amount)
+		t.sfdcLines[line] = true
+	case revrec.SourceNS:
+		t.ns += num(r.Amount)
+		t.nsLines[line] = true
+	}
+}
+
+func newRevenueTotals() *revenueTotals {
+	return &revenueTotals{sfdcLines: make(map[string]bool), nsLines: make(map[string]bool)}
+}
+
+// RevenueByPeriodReport totals the schedule per month for each source.
+// Months where the SFDC and NetSuite schedules differ by more than
+// tol.Price are flagged.
+func RevenueByPeriodReport(rows []db.RevenueSchedule, tol Tolerance) Report {
+	periods := make(map[string]*revenueTotals)
+	for _, r := range rows {
+		key := date(r.Period).Format("2006-01")
+		t, ok := periods[key]
+		if !ok {
+			t = newRevenueTotals()
+			periods[key] = t
+		}
+		t.add(r)
+	}
+
+	keys := sortedKeys(periods)
+	out := make([]Row, len(keys))
+	flagged := 0
+	var sfdc, ns float64
+	for i, k := range keys {
+		t := periods[k]
+		diff := t.sfdc - t.ns
+		off := math.Abs(diff) > tol.Price
+		if off {
+			flagged++
+		}
+		sfdc += t.sfdc
+		ns += t.ns
+		out[i] = Row{
+			Cells:   []string{k, fmt.Sprint(len(t.sfdcLines)), money(t.sfdc), fmt.Sprint(len(t.nsLines)), money(t.ns), money(diff)},
+			Flagged: off,
+		}
+	}
+
+	return Report{
+		Title: "Revenue by Period",
+		Columns: []Column{
+			{Title: "Period", Width: 8},
+			{Title: "SFDC Lines", Width: 10},
+			{Title: "SFDC Revenue", Width: 16},
+			{Title: "NS Lines", Width: 10},
+			{Title: "NS Revenue", Width: 16},
+			{Title: "Difference", Width: 16},
+		},
+		Rows: out,
+		Summary: fmt.Sprintf("%d periods: SFDC %s, NetSuite %s; %d periods where the schedules differ",
+			len(keys), money(sfdc), money(ns), flagged),
+	}
+}
+
+// RevenueRollupReport totals the schedule per customer or product and month.
+func RevenueRollupReport(rows []db.RevenueSchedule, by string) Report {
+	type groupKey struct{ name, period string }
+	groups := make(map[groupKey]*revenueTotals)
+	names := make(map[string]bool)
+	for _, r := range rows {
+		name := text(r.Customer)
+		if by == ByProduct {
+			name = text(r.Product)
+		}
+		key := groupKey{name, date(r.Period).Format("2006-01")}
+		t, ok := groups[key]
+		if !ok {
+			t = newRevenueTotals()
+			groups[key] = t
+		}
+		t.add(r)
+		names[name] = true
+	}
+
+	keys := make([]groupKey, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].period < keys[j].period
+	})
+
+	out := make([]Row, len(keys))
+	var sfdc, ns float64
+	for i, k := range keys {
+		t := groups[k]
+		sfdc += t.sfdc
+		ns += t.ns
+		out[i] = Row{Cells: []string{orNone(k.name), k.period, money(t.sfdc), money(t.ns)}}
+	}
+
+	return Report{
+		Title: "Revenue by " + by,
+		Columns: []Column{
+			{Title: by, Width: 30},
+			{Title: "Period", Width: 8},
+			{Title: "SFDC Revenue", Width: 16},
+			{Title: "NS Revenue", Width: 16},
+		},
+		Rows: out,
+		Summary: fmt.Sprintf("%d %s values over %d rows: SFDC %s, NetSuite %s",
+			len(names), strings.ToLower(by), len(keys), money(sfdc), money(ns)),
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadRevenueSchedule loads schedule rows whose customer and product contain
+// the given text; blank matches everything.
+func LoadRevenueSchedule(ctx context.Context, q *db.Queries, customer, product string) ([]db.RevenueSchedule, error) {
+	rows, err := q.ListRevenueSchedule(ctx, db.ListRevenueScheduleParams{Customer: customer, Product: product})
+	if err != nil {
+		return nil, fmt.Errorf("loading revenue_schedule: %w", err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	Revenue Schedule Tests
+======================================== */
+
+func scheduled(source string, line byte, customer, product, period string, amount float64) db.RevenueSchedule {
+	return db.RevenueSchedule{
+		Source:   source,
+		LineID:   pgtype.UUID{Bytes: [16]byte{line}, Valid: true},
+		Customer: pgText(customer),
+		Product:  pgText(product),
+		Period:   pgDate(period),
+		Amount:   pgNum(amount),
+	}
+}
+
+func revenueRows() []db.RevenueSchedule {
+	return []db.RevenueSchedule{
+		scheduled("sfdc", 1, "Acme", "PLAT", "2025-01-01", 100),
+		scheduled("sfdc", 1, "Acme", "PLAT", "2025-02-01", 100),
+		scheduled("sfdc", 2, "Globex", "SUP", "2025-01-01", 50),
+		scheduled("ns", 3, "Acme Corp", "PLAT", "2025-01-01", 150),
+		scheduled("ns", 3, "Acme Corp", "PLAT", "2025-02-01", 90),
+	}
+}
+
+func TestRevenueByPeriodReport(t *testing.T) {
+	r := RevenueByPeriodReport(revenueRows(), DefaultTolerance)
+
+	if len(r.Rows) != 2 {
+		t.Fatalf("len(Rows) = %d, expected 2", len(r.Rows))
+	}
+
+	jan := strings.Join(r.Rows[0].Cells, "|")
+	if jan != "2025-01|2|150.00|1|150.00|0.00" || r.Rows[0].Flagged {
+		t.Errorf("January = %q (flagged %v), expected matching schedules", jan, r.Rows[0].Flagged)
+	}
+	feb := strings.Join(r.Rows[1].Cells, "|")
+	if feb != "2025-02|1|100.00|1|90.00|10.00" || !r.Rows[1].Flagged {
+		t.Errorf("February = %q (flagged %v), expected a flagged 10.00 difference", feb, r.Rows[1].Flagged)
+	}
+	if !strings.Contains(r.Summary, "1 periods where the schedules differ") {
+		t.Errorf("Summary = %q", r.Summary)
+	}
+}
+
+func TestRevenueRollupReport(t *testing.T) {
+	tests := []struct {
+		by       string
+		expected []string
+	}{
+		{ByCustomer, []string{
+			"Acme|2025-01|100.00|0.00",
+			"Acme|2025-02|100.00|0.00",
+			"Acme Corp|2025-01|0.00|150.00",
+			"Acme Corp|2025-02|0.00|90.00",
+			"Globex|2025-01|50.00|0.00",
+		}},
+		{ByProduct, []string{
+			"PLAT|2025-01|100.00|150.00",
+			"PLAT|2025-02|100.00|90.00",
+			"SUP|2025-01|50.00|0.00",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			r := RevenueRollupReport(revenueRows(), tt.by)
+			if len(r.Rows) != len(tt.expected) {
+				t.Fatalf("len(Rows) = %d, expected %d", len(r.Rows), len(tt.expected))
+			}
+			for i, want := range tt.expected {
+				if got := strings.Join(r.Rows[i].Cells, "|"); got != want {
+					t.Errorf("Rows[%d] = %q, expected %q", i, got, want)
+				}
+			}
+		})
+	}
+}
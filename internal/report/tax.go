@@ -0,0 +1,308 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/JonMunkholm/TUI/internal/schema"
+)
+
+/* ----------------------------------------
+	SALES TAX EXPOSURE
+---------------------------------------- */
+
+// Nexus statuses.
+const (
+	NexusBelow       = "Below"
+	NexusApproaching = "Approaching"
+	NexusOver        = "Over"
+)
+
+// taxPeriod returns the transaction's tax date, or its invoice date when blank.
+func taxPeriod(t db.AnrokTransaction) time.Time {
+	if d := date(t.TaxDate); !d.IsZero() {
+		return d
+	}
+	return date(t.InvoiceDate)
+}
+
+// isExempt reports whether the transaction's sales are exempt from tax.
+func isExempt(t db.AnrokTransaction) bool {
+	return text(t.ExemptReason) != ""
+}
+
+// isUS reports whether the customer address is in the United States. A
+// blank country is assumed domestic.
+func isUS(t db.AnrokTransaction) bool {
+	for _, c := range []string{text(t.CustomerCountryCode), text(t.CustomerAddressCountry)} {
+		switch strings.ToUpper(c) {
+		case "":
+			continue
+		case "US", "USA", "UNITED STATES", "UNITED STATES OF AMERICA":
+			return true
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// jurisdictions splits the transaction's jurisdiction list, e.g.
+// "California, Los Angeles County", into distinct names. A blank list yields
+// one blank name so the transaction is still counted.
+func jurisdictions(t db.AnrokTransaction) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.FieldsFunc(text(t.Jurisdictions), func(r rune) bool { return r == ',' || r == ';' || r == '|' }) {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToUpper(name)] {
+			continue
+		}
+		seen[strings.ToUpper(name)] = true
+		names = append(names, name)
+	}
+	if len(names) == 0 {
+		return []string{""}
+	}
+	return names
+}
+
+// usState returns the customer address region as a two-letter state code
+// where it names a US state, so "California" and "CA" group together.
+func usState(t db.AnrokTransaction) string {
+	return strings.ToUpper(schema.NormalizeUsState(text(t.CustomerAddressRegion)))
+}
+
+// taxTotals sums one group of transactions.
+type taxTotals struct {
+	transactions         int
+	taxable, exempt, tax float64
+}
+
+func (t *taxTotals) add(txn db.AnrokTransaction) {
+	t.transactions++
+	if isExempt(txn) {
+		t.exempt += num(txn.SalesAmount)
+	} else {
+		t.taxable += num(txn.SalesAmount)
+	}
+	t.tax += num(txn.TaxAmount)
+}
+
+// TaxByJurisdictionReport sums taxable and exempt sales, tax and transaction
+// counts per jurisdiction and month of the tax date. A transaction taxed by
+// several jurisdictions counts in full toward each of them, since Anrok does
+// not split the amounts; the summary counts it once. Voided transactions are
+// left out.
+func TaxByJurisdictionReport(txns []db.AnrokTransaction) Report {
+	type groupKey struct{ jurisdiction, period string }
+	groups := make(map[groupKey]*taxTotals)
+	var total taxTotals
+	for _, txn := range txns {
+		if txn.Void.Valid && txn.Void.Bool {
+			continue
+		}
+		period := taxPeriod(txn).Format("2006-01")
+		if taxPeriod(txn).IsZero() {
+			period = ""
+		}
+		for _, jurisdiction := range jurisdictions(txn) {
+			key := groupKey{jurisdiction, period}
+			g, ok := groups[key]
+			if !ok {
+				g = &taxTotals{}
+				groups[key] = g
+			}
+			g.add(txn)
+		}
+		total.add(txn)
+	}
+
+	keys := make([]groupKey, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].jurisdiction != keys[j].jurisdiction {
+			return keys[i].jurisdiction < keys[j].jurisdiction
+		}
+		return keys[i].period < keys[j].period
+	})
+
+	rows := make([]Row, len(keys))
+	for i, k := range keys {
+		g := groups[k]
+		rows[i] = Row{Cells: []string{
+			orNone(k.jurisdiction), orNone(k.period), fmt.Sprint(g.transactions),
+			money(g.taxable), money(g.exempt), money(g.tax),
+		}}
+	}
+
+	return Report{
+		Title: "Sales Tax by Jurisdiction",
+		Columns: []Column{
+			{Title: "Jurisdiction", Width: 40},
+			{Title: "Period", Width: 8},
+			{Title: "Transactions", Width: 12},
+			{Title: "Taxable Sales", Width: 16},
+			{Title: "Exempt Sales", Width: 16},
+			{Title: "Tax Collected", Width: 14},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d transactions: taxable %s, exempt %s, tax collected %s (a transaction appears under each of its jurisdictions)",
+			total.transactions, money(total.taxable), money(total.exempt), money(total.tax)),
+	}
+}
+
+// NexusState is one state's sales against its economic-nexus threshold in a
+// calendar year.
+type NexusState struct {
+	State        string
+	Year         int
+	Transactions int
+	Sales        float64 // Taxable and exempt
+	Tax          float64
+	Threshold    config.NexusThreshold
+	Share        float64 // Highest share of a threshold reached
+	Status       string
+}
+
+// AnalyzeNexus sums US sales and transactions per state (customer address
+// region, as a two-letter code) and calendar year of the tax date, and compares them with each
+// state's threshold. Voided transactions, and those with neither a tax date
+// nor an invoice date, are left out.
+func AnalyzeNexus(txns []db.AnrokTransaction, nexus config.Nexus) []NexusState {
+	type groupKey struct {
+		state string
+		year  int
+	}
+	groups := make(map[groupKey]*NexusState)
+	for _, txn := range txns {
+		if (txn.Void.Valid && txn.Void.Bool) || !isUS(txn) {
+			continue
+		}
+		period := taxPeriod(txn)
+		if period.IsZero() {
+			continue
+		}
+		state := usState(txn)
+		key := groupKey{state, period.Year()}
+		s, ok := groups[key]
+		if !ok {
+			s = &NexusState{State: state, Year: key.year, Threshold: nexus.For(state)}
+			groups[key] = s
+		}
+		s.Transactions++
+		s.Sales += num(txn.SalesAmount)
+		s.Tax += num(txn.TaxAmount)
+	}
+
+	states := make([]NexusState, 0, len(groups))
+	for _, s := range groups {
+		if s.Threshold.Sales > 0 {
+			s.Share = math.Max(s.Share, s.Sales/s.Threshold.Sales)
+		}
+		if s.Threshold.Transactions > 0 {
+			s.Share = math.Max(s.Share, float64(s.Transactions)/float64(s.Threshold.Transactions))
+		}
+		switch {
+		case s.Share >= 1:
+			s.Status = NexusOver
+		case s.Share >= nexus.Warn:
+			s.Status = NexusApproaching
+		default:
+			s.Status = NexusBelow
+		}
+		states = append(states, *s)
+	}
+
+	sort.Slice(states, func(i, j int) bool {
+		if states[i].Year != states[j].Year {
+			return states[i].Year > states[j].Year
+		}
+		if states[i].Share != states[j].Share {
+			return states[i].Share > states[j].Share
+		}
+		return states[i].State < states[j].State
+	})
+	return states
+}
+
+// NexusReport lists each state and year with its threshold. States
+// approaching or over a threshold are flagged; over a threshold with no tax
+// collected is called out as exposure.
+func NexusReport(states []NexusState, nexus config.Nexus) Report {
+	rows := make([]Row, len(states))
+	counts := make(map[string]int)
+	exposed := 0
+	for i, s := range states {
+		counts[s.Status]++
+
+		var threshold []string
+		if s.Threshold.Sales > 0 {
+			threshold = append(threshold, money(s.Threshold.Sales))
+		}
+		if s.Threshold.Transactions > 0 {
+			threshold = append(threshold, fmt.Sprintf("%d txns", s.Threshold.Transactions))
+		}
+		if len(threshold) == 0 {
+			threshold = []string{"none"}
+		}
+
+		note := ""
+		if s.Status == NexusOver && s.Tax == 0 {
+			exposed++
+			note = "over threshold with no tax collected"
+		}
+
+		rows[i] = Row{
+			Cells: []string{
+				orNone(s.State), fmt.Sprint(s.Year), fmt.Sprint(s.Transactions), money(s.Sales), money(s.Tax),
+				strings.Join(threshold, " or "), percent(s.Share), s.Status, note,
+			},
+			Flagged: s.Status != NexusBelow,
+		}
+	}
+
+	return Report{
+		Title: "Economic Nexus Exposure",
+		Columns: []Column{
+			{Title: "State", Width: 14},
+			{Title: "Year", Width: 6},
+			{Title: "Transactions", Width: 12},
+			{Title: "Sales", Width: 16},
+			{Title: "Tax Collected", Width: 14},
+			{Title: "Threshold", Width: 26},
+			{Title: "Reached", Width: 8},
+			{Title: "Status", Width: 12},
+			{Title: "Note", Width: 36},
+		},
+		Rows: rows,
+		Summary: fmt.Sprintf("%d state-years: %d over, %d approaching (%s of a threshold), %d over with no tax collected",
+			len(states), counts[NexusOver], counts[NexusApproaching], percent(nexus.Warn), exposed),
+	}
+}
+
+// LoadTaxByJurisdiction loads Anrok transactions and sums them by jurisdiction.
+func LoadTaxByJurisdiction(ctx context.Context, q *db.Queries) (Report, error) {
+	txns, err := q.ListAnrokTransactions(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading anrok_transactions: %w", err)
+	}
+	return TaxByJurisdictionReport(txns), nil
+}
+
+// LoadNexus loads Anrok transactions and compares state sales with nexus.
+func LoadNexus(ctx context.Context, q *db.Queries, nexus config.Nexus) (Report, error) {
+	txns, err := q.ListAnrokTransactions(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("loading anrok_transactions: %w", err)
+	}
+	return NexusReport(AnalyzeNexus(txns, nexus), nexus), nil
+}
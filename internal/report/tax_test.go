@@ -0,0 +1,128 @@
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/config"
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+/* ========================================
+	Sales Tax Tests
+======================================== */
+
+func taxTxn(jurisdiction, region, country, taxDate string, sales, tax float64, exempt string) db.AnrokTransaction {
+	return db.AnrokTransaction{
+		Jurisdictions:          pgText(jurisdiction),
+		CustomerAddressRegion:  pgText(region),
+		CustomerAddressCountry: pgText(country),
+		TaxDate:                pgDate(taxDate),
+		SalesAmount:            pgNum(sales),
+		TaxAmount:              pgNum(tax),
+		ExemptReason:           pgText(exempt),
+	}
+}
+
+func TestTaxByJurisdictionReport(t *testing.T) {
+	voided := taxTxn("California", "CA", "US", "2025-01-10", 1000, 80, "")
+	voided.Void = pgtype.Bool{Bool: true, Valid: true}
+
+	txns := []db.AnrokTransaction{
+		taxTxn("California", "CA", "US", "2025-01-05", 100, 8, ""),
+		taxTxn("California", "CA", "US", "2025-01-20", 50, 0, "resale"),
+		taxTxn("California", "CA", "US", "2025-02-01", 10, 1, ""),
+		taxTxn("California; Los Angeles County", "CA", "US", "2025-02-03", 20, 2, ""),
+		taxTxn("Washington", "WA", "US", "2025-01-05", 200, 20, ""),
+		voided,
+	}
+
+	r := TaxByJurisdictionReport(txns)
+
+	expected := []string{
+		"California|2025-01|2|100.00|50.00|8.00",
+		"California|2025-02|2|30.00|0.00|3.00",
+		"Los Angeles County|2025-02|1|20.00|0.00|2.00",
+		"Washington|2025-01|1|200.00|0.00|20.00",
+	}
+	if len(r.Rows) != len(expected) {
+		t.Fatalf("len(Rows) = %d, expected %d", len(r.Rows), len(expected))
+	}
+	for i, want := range expected {
+		if got := strings.Join(r.Rows[i].Cells, "|"); got != want {
+			t.Errorf("Rows[%d] = %q, expected %q", i, got, want)
+		}
+	}
+	if !strings.HasPrefix(r.Summary, "5 transactions: taxable 330.00") {
+		t.Errorf("Summary = %q, expected each transaction counted once", r.Summary)
+	}
+}
+
+func TestJurisdictions(t *testing.T) {
+	tests := []struct {
+		list     string
+		expected string
+	}{
+		{"California", "California"},
+		{"California, Los Angeles County ,Los Angeles", "California|Los Angeles County|Los Angeles"},
+		{"Texas; Austin; texas", "Texas|Austin"},
+		{"", ""},
+		{" , ", ""},
+	}
+	for _, tt := range tests {
+		got := strings.Join(jurisdictions(db.AnrokTransaction{Jurisdictions: pgText(tt.list)}), "|")
+		if got != tt.expected {
+			t.Errorf("jurisdictions(%q) = %q, expected %q", tt.list, got, tt.expected)
+		}
+	}
+}
+
+func TestAnalyzeNexus(t *testing.T) {
+	nexus := config.Nexus{
+		Warn:       0.8,
+		Default:    config.NexusThreshold{Sales: 1000, Transactions: 3},
+		Thresholds: []config.NexusThreshold{{State: "CA", Sales: 5000}},
+	}
+	txns := []db.AnrokTransaction{
+		taxTxn("", "ca", "US", "2025-01-05", 4000, 300, ""), // CA: 84% of 5000
+		taxTxn("", "California", "US", "2025-02-05", 200, 0, ""),
+		taxTxn("", "WA", "US", "2025-01-05", 100, 0, ""), // WA: 3 transactions
+		taxTxn("", "WA", "", "2025-03-05", 100, 0, ""),   // blank country counts as US
+		taxTxn("", "WA", "US", "2025-06-05", 100, 0, ""),
+		taxTxn("", "OR", "US", "2025-01-05", 100, 0, ""),      // OR: 10%
+		taxTxn("", "ON", "Canada", "2025-01-05", 9999, 0, ""), // not US
+		taxTxn("", "WA", "US", "2024-06-05", 100, 0, ""),      // other year
+		taxTxn("", "OR", "US", "", 9999, 0, ""),               // undated
+	}
+
+	states := AnalyzeNexus(txns, nexus)
+
+	tests := []struct {
+		state  string
+		year   int
+		status string
+	}{
+		{"WA", 2025, NexusOver},
+		{"CA", 2025, NexusApproaching},
+		{"OR", 2025, NexusBelow},
+		{"WA", 2024, NexusBelow},
+	}
+	if len(states) != len(tests) {
+		t.Fatalf("len(states) = %d, expected %d: %+v", len(states), len(tests), states)
+	}
+	for i, tt := range tests {
+		s := states[i]
+		if s.State != tt.state || s.Year != tt.year || s.Status != tt.status {
+			t.Errorf("states[%d] = %s %d %s, expected %s %d %s", i, s.State, s.Year, s.Status, tt.state, tt.year, tt.status)
+		}
+	}
+
+	r := NexusReport(states, nexus)
+	if !r.Rows[0].Flagged || !r.Rows[1].Flagged || r.Rows[2].Flagged {
+		t.Error("only states over or approaching a threshold should be flagged")
+	}
+	if note := r.Rows[0].Cells[8]; note != "over threshold with no tax collected" {
+		t.Errorf("WA note = %q, expected the no-tax exposure note", note)
+	}
+}
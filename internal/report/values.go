@@ -0,0 +1,82 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// DateLayout is how dates are shown in reports.
+const DateLayout = "2006-01-02"
+
+// num returns n as a float64, or 0 when NULL.
+func num(n pgtype.Numeric) float64 {
+	f, err := n.Float64Value()
+	if err != nil || !f.Valid {
+		return 0
+	}
+	return f.Float64
+}
+
+// text returns t trimmed, or "" when NULL.
+func text(t pgtype.Text) string {
+	if !t.Valid {
+		return ""
+	}
+	return strings.TrimSpace(t.String)
+}
+
+// date returns d as a time, or the zero time when NULL.
+func date(d pgtype.Date) time.Time {
+	if !d.Valid {
+		return time.Time{}
+	}
+	return d.Time
+}
+
+// formatDate renders t, or "" for the zero time.
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(DateLayout)
+}
+
+// money renders an amount with two decimals and thousands separators.
+func money(f float64) string {
+	neg := f < 0
+	cents := int64(math.Round(math.Abs(f) * 100))
+	whole := fmt.Sprintf("%d", cents/100)
+
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	s := fmt.Sprintf("%s.%02d", b.String(), cents%100)
+	if neg {
+		return "-" + s
+	}
+	return s
+}
+
+// quantity renders a quantity without trailing zeros.
+func quantity(f float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.4f", f), "0"), ".")
+}
+
+// percent renders a ratio as a percentage with one decimal.
+func percent(f float64) string {
+	return fmt.Sprintf("%.1f%%", f*100)
+}
+
+// daysApart returns the absolute number of days between a and b.
+func daysApart(a, b time.Time) int {
+	d := a.Sub(b).Hours() / 24
+	return int(math.Abs(math.Round(d)))
+}
@@ -0,0 +1,264 @@
+// Package revrec builds the ratable revenue schedule in revenue_schedule.
+//
+// Each contract line is recognized evenly per day over its service period,
+// inclusive of both dates. A month gets the line amount times its share of
+// the service days, truncated to the cent; the last month takes whatever is
+// left so the schedule always sums to the line amount.
+package revrec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Schedule sources stored in revenue_schedule.source.
+const (
+	SourceSFDC = "sfdc"
+	SourceNS   = "ns"
+)
+
+var (
+	// ErrNoDates is returned for a line without a service start or end date.
+	ErrNoDates = errors.New("service dates are missing")
+	// ErrEndBeforeStart is returned for a line that ends before it starts.
+	ErrEndBeforeStart = errors.New("service end date is before the start date")
+)
+
+// Line is one contract line to recognize. Amount is in cents; Start and End
+// are inclusive service dates.
+type Line struct {
+	ID       pgtype.UUID
+	Ref      string
+	Customer string
+	Product  string
+	Amount   int64
+	Start    time.Time
+	End      time.Time
+}
+
+// Entry is the revenue recognized for a line in one month.
+type Entry struct {
+	Period time.Time // first day of the month
+	Days   int
+	Amount int64 // cents
+}
+
+// Schedule spreads the line amount over its service months by day.
+func Schedule(l Line) ([]Entry, error) {
+	if l.Start.IsZero() || l.End.IsZero() {
+		return nil, ErrNoDates
+	}
+	start, end := day(l.Start), day(l.End)
+	if end.Before(start) {
+		return nil, ErrEndBeforeStart
+	}
+	total := days(start, end)
+
+	var entries []Entry
+	var recognized int64
+	for from := start; !from.After(end); {
+		period := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+		next := period.AddDate(0, 1, 0)
+		to := next.AddDate(0, 0, -1)
+		if to.After(end) {
+			to = end
+		}
+
+		n := days(from, to)
+		amount := l.Amount * int64(n) / int64(total)
+		entries = append(entries, Entry{Period: period, Days: n, Amount: amount})
+		recognized += amount
+		from = next
+	}
+	entries[len(entries)-1].Amount += l.Amount - recognized
+
+	return entries, nil
+}
+
+// day drops the time of day so date arithmetic is whole days.
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// days counts the days from a to b inclusive.
+func days(a, b time.Time) int {
+	return int(b.Sub(a).Hours()/24) + 1
+}
+
+// Cents converts an amount to whole cents, rounding half away from zero.
+// NULL is reported as not valid.
+func Cents(n pgtype.Numeric) (int64, bool) {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite || n.Int == nil {
+		return 0, false
+	}
+
+	v := new(big.Int).Set(n.Int)
+	exp := int64(n.Exp) + 2
+	if exp >= 0 {
+		v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil))
+	} else {
+		div := new(big.Int).Exp(big.NewInt(10), big.NewInt(-exp), nil)
+		q, r := new(big.Int).QuoRem(v, div, new(big.Int))
+		if new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(div) >= 0 {
+			q.Add(q, big.NewInt(int64(v.Sign())))
+		}
+		v = q
+	}
+
+	if !v.IsInt64() {
+		return 0, false
+	}
+	return v.Int64(), true
+}
+
+// Amount turns cents back into a NUMERIC(18, 2) value.
+func Amount(cents int64) pgtype.Numeric {
+	return pgtype.Numeric{Int: big.NewInt(cents), Exp: -2, Valid: true}
+}
+
+// SfdcLines maps opportunity lines to schedule lines: total price over the
+// line dates, falling back to the amount and the contract dates. Lines with
+// no amount are skipped.
+func SfdcLines(rows []db.SfdcOppDetail) []Line {
+	var lines []Line
+	for _, r := range rows {
+		cents, ok := Cents(r.TotalPrice)
+		if !ok {
+			cents, ok = Cents(r.Amount)
+		}
+		if !ok || cents == 0 {
+			continue
+		}
+
+		lines = append(lines, Line{
+			ID:       r.ID,
+			Ref:      firstText(r.OpportunityProductCasesafeID, r.OpportunityID),
+			Customer: firstText(r.AccountName),
+			Product:  firstText(r.ProductCode, r.ProductName),
+			Amount:   cents,
+			Start:    FirstDate(r.StartDate, r.ContractStartDate),
+			End:      FirstDate(r.EndDate, r.ContractEndDate),
+		})
+	}
+	return lines
+}
+
+// NsLines maps sales order lines to schedule lines: gross amount over the
+// line dates, falling back to the order dates. Lines with no amount are skipped.
+func NsLines(rows []db.NsSoDetail) []Line {
+	var lines []Line
+	for _, r := range rows {
+		cents, ok := Cents(r.AmountGross)
+		if !ok || cents == 0 {
+			continue
+		}
+
+		lines = append(lines, Line{
+			ID:       r.ID,
+			Ref:      firstText(r.SoNumber),
+			Customer: strings.TrimSpace(r.CustomerProject),
+			Product:  firstText(r.ItemName, r.ItemDisplayName),
+			Amount:   cents,
+			Start:    FirstDate(r.LineStartDate, r.StartDate),
+			End:      FirstDate(r.LineEndDate, r.EndDate),
+		})
+	}
+	return lines
+}
+
+func firstText(values ...pgtype.Text) string {
+	for _, v := range values {
+		if s := strings.TrimSpace(v.String); v.Valid && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// FirstDate returns the first valid date, without a time of day, or the
+// zero time when none is valid.
+func FirstDate(values ...pgtype.Date) time.Time {
+	for _, v := range values {
+		if v.Valid {
+			return day(v.Time)
+		}
+	}
+	return time.Time{}
+}
+
+// Result summarizes a regeneration.
+type Result struct {
+	Lines   int // lines scheduled
+	Entries int // revenue_schedule rows written
+	Skipped int // lines without usable service dates
+}
+
+// Regenerate replaces the schedule rows of one source from its live and
+// archived lines, so it can run after every import without dropping the
+// revenue of archived periods.
+func Regenerate(ctx context.Context, queries *db.Queries, source string) (Result, error) {
+	var lines []Line
+	switch source {
+	case SourceSFDC:
+		rows, err := queries.ListSfdcOppDetailWithArchive(ctx)
+		if err != nil {
+			return Result{}, fmt.Errorf("loading sfdc_opp_detail: %w", err)
+		}
+		lines = SfdcLines(rows)
+	case SourceNS:
+		rows, err := queries.ListNsSoDetailWithArchive(ctx)
+		if err != nil {
+			return Result{}, fmt.Errorf("loading ns_so_detail: %w", err)
+		}
+		lines = NsLines(rows)
+	default:
+		return Result{}, fmt.Errorf("unknown schedule source %q", source)
+	}
+
+	if err := queries.DeleteRevenueSchedule(ctx, source); err != nil {
+		return Result{}, fmt.Errorf("clearing %s revenue schedule: %w", source, err)
+	}
+
+	var res Result
+	var params []db.InsertRevenueScheduleParams
+	for _, l := range lines {
+		entries, err := Schedule(l)
+		if err != nil {
+			res.Skipped++
+			continue
+		}
+		res.Lines++
+		for _, e := range entries {
+			params = append(params, db.InsertRevenueScheduleParams{
+				Source:   source,
+				LineID:   l.ID,
+				LineRef:  optional(l.Ref),
+				Customer: optional(l.Customer),
+				Product:  optional(l.Product),
+				Period:   pgtype.Date{Time: e.Period, Valid: true},
+				Days:     int32(e.Days),
+				Amount:   Amount(e.Amount),
+			})
+		}
+	}
+
+	if len(params) > 0 {
+		n, err := queries.InsertRevenueSchedule(ctx, params)
+		if err != nil {
+			return Result{}, fmt.Errorf("writing %s revenue schedule: %w", source, err)
+		}
+		res.Entries = int(n)
+	}
+	return res, nil
+}
+
+func optional(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
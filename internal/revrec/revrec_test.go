@@ -0,0 +1,171 @@
+package revrec
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	db "github.com/JonMunkholm/TUI/internal/database"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func ymd(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+/* ========================================
+	Schedule Tests
+======================================== */
+
+func TestSchedule_FullYear(t *testing.T) {
+	entries, err := Schedule(Line{Amount: 1200000, Start: ymd(2025, 1, 1), End: ymd(2025, 12, 31)})
+	if err != nil {
+		t.Fatalf("Schedule() error: %v", err)
+	}
+	if len(entries) != 12 {
+		t.Fatalf("len(entries) = %d, expected 12", len(entries))
+	}
+
+	// 31-day January gets 31/365 of the line
+	if entries[0].Days != 31 || entries[0].Amount != 101917 {
+		t.Errorf("January = %+v, expected 31 days and 101917 cents", entries[0])
+	}
+	if entries[1].Days != 28 {
+		t.Errorf("February days = %d, expected 28", entries[1].Days)
+	}
+	assertTotal(t, entries, 1200000)
+}
+
+func TestSchedule_PartialMonths(t *testing.T) {
+	// Jan 15 - Mar 14: 17 + 28 + 14 = 59 days
+	entries, err := Schedule(Line{Amount: 10000, Start: ymd(2025, 1, 15), End: ymd(2025, 3, 14)})
+	if err != nil {
+		t.Fatalf("Schedule() error: %v", err)
+	}
+
+	expected := []Entry{
+		{Period: ymd(2025, 1, 1), Days: 17, Amount: 2881},
+		{Period: ymd(2025, 2, 1), Days: 28, Amount: 4745},
+		{Period: ymd(2025, 3, 1), Days: 14, Amount: 2374}, // 2372 + residue
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("len(entries) = %d, expected %d", len(entries), len(expected))
+	}
+	for i, e := range expected {
+		if !entries[i].Period.Equal(e.Period) || entries[i].Days != e.Days || entries[i].Amount != e.Amount {
+			t.Errorf("entries[%d] = %+v, expected %+v", i, entries[i], e)
+		}
+	}
+	assertTotal(t, entries, 10000)
+}
+
+func TestSchedule_SingleDayAndCredit(t *testing.T) {
+	entries, err := Schedule(Line{Amount: 999, Start: ymd(2024, 2, 29), End: ymd(2024, 2, 29)})
+	if err != nil || len(entries) != 1 || entries[0].Amount != 999 || entries[0].Days != 1 {
+		t.Errorf("single day = %+v, %v; expected one 999-cent entry", entries, err)
+	}
+
+	entries, err = Schedule(Line{Amount: -10000, Start: ymd(2025, 1, 15), End: ymd(2025, 3, 14)})
+	if err != nil {
+		t.Fatalf("Schedule() error: %v", err)
+	}
+	if entries[0].Amount != -2881 {
+		t.Errorf("credit January = %d, expected -2881", entries[0].Amount)
+	}
+	assertTotal(t, entries, -10000)
+}
+
+func TestSchedule_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     Line
+		expected error
+	}{
+		{"no start", Line{Amount: 1, End: ymd(2025, 1, 1)}, ErrNoDates},
+		{"no end", Line{Amount: 1, Start: ymd(2025, 1, 1)}, ErrNoDates},
+		{"reversed", Line{Amount: 1, Start: ymd(2025, 2, 1), End: ymd(2025, 1, 1)}, ErrEndBeforeStart},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Schedule(tt.line); err != tt.expected {
+				t.Errorf("Schedule() error = %v, expected %v", err, tt.expected)
+			}
+		})
+	}
+}
+
+func assertTotal(t *testing.T, entries []Entry, expected int64) {
+	t.Helper()
+	var total int64
+	for _, e := range entries {
+		total += e.Amount
+	}
+	if total != expected {
+		t.Errorf("schedule total = %d, expected %d", total, expected)
+	}
+}
+
+/* ========================================
+	Cents Tests
+======================================== */
+
+func TestCents(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     pgtype.Numeric
+		expected  int64
+		wantValid bool
+	}{
+		{"two decimals", pgtype.Numeric{Int: big.NewInt(123456), Exp: -2, Valid: true}, 123456, true},
+		{"whole", pgtype.Numeric{Int: big.NewInt(12), Exp: 0, Valid: true}, 1200, true},
+		{"round up", pgtype.Numeric{Int: big.NewInt(12345), Exp: -3, Valid: true}, 1235, true},
+		{"round down", pgtype.Numeric{Int: big.NewInt(12344), Exp: -3, Valid: true}, 1234, true},
+		{"negative", pgtype.Numeric{Int: big.NewInt(-12345), Exp: -3, Valid: true}, -1235, true},
+		{"null", pgtype.Numeric{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Cents(tt.input)
+			if got != tt.expected || ok != tt.wantValid {
+				t.Errorf("Cents() = %d, %v, expected %d, %v", got, ok, tt.expected, tt.wantValid)
+			}
+		})
+	}
+}
+
+/* ========================================
+	SfdcLines Tests
+======================================== */
+
+func TestSfdcLines_Fallbacks(t *testing.T) {
+	date := func(t time.Time) pgtype.Date { return pgtype.Date{Time: t, Valid: true} }
+	text := func(s string) pgtype.Text { return pgtype.Text{String: s, Valid: true} }
+
+	rows := []db.SfdcOppDetail{
+		{ // amount and contract dates when the line has none
+			OpportunityID:     text("006A"),
+			AccountName:       text(" Acme "),
+			ProductName:       text("Platform"),
+			Amount:            pgtype.Numeric{Int: big.NewInt(500), Exp: 0, Valid: true},
+			ContractStartDate: date(ymd(2025, 1, 1)),
+			ContractEndDate:   date(ymd(2025, 6, 30)),
+		},
+		{ // zero lines are skipped
+			TotalPrice: pgtype.Numeric{Int: big.NewInt(0), Exp: 0, Valid: true},
+		},
+	}
+
+	lines := SfdcLines(rows)
+	if len(lines) != 1 {
+		t.Fatalf("len(lines) = %d, expected 1", len(lines))
+	}
+	l := lines[0]
+	if l.Ref != "006A" || l.Customer != "Acme" || l.Product != "Platform" || l.Amount != 50000 {
+		t.Errorf("line = %+v, expected ref 006A, customer Acme, product Platform, 50000 cents", l)
+	}
+	if !l.Start.Equal(ymd(2025, 1, 1)) || !l.End.Equal(ymd(2025, 6, 30)) {
+		t.Errorf("line dates = %v - %v, expected the contract dates", l.Start, l.End)
+	}
+}
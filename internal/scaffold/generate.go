@@ -0,0 +1,166 @@
+package scaffold
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/csv"
+)
+
+// Main runs the scaffold command:
+//
+//	csv-importer scaffold -name "NS credit memos" path/to/sample.csv
+//
+// It writes the migration, queries, FieldSpecs and handler file under -root,
+// creates the upload directory, and prints the registration snippet.
+func Main(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("scaffold", flag.ContinueOnError)
+	fs.SetOutput(out)
+	name := fs.String("name", "", `target name: source followed by report name, e.g. "NS credit memos"`)
+	root := fs.String("root", ".", "repository root")
+	dryRun := fs.Bool("dry-run", false, "print the generated files instead of writing them")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("scaffold requires -name and exactly one sample CSV")
+	}
+
+	header, rows, err := readSample(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	version, err := nextMigrationVersion(filepath.Join(*root, "sql", "schema"))
+	if err != nil {
+		return err
+	}
+
+	plan, err := NewPlan(*name, version, InferColumns(header, rows))
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		path    string
+		content string
+		append  bool
+	}{
+		{filepath.Join(*root, "sql", "schema", plan.MigrationFile()), plan.Migration(), false},
+		{filepath.Join(*root, "sql", "queries", plan.Table+".sql"), plan.Queries(), false},
+		{filepath.Join(*root, "internal", "schema", plan.Schema), plan.Specs(), true},
+		{filepath.Join(*root, "internal", "handler", lowerFirst(plan.Plural)+".go"), plan.Handler(), false},
+	}
+
+	if *dryRun {
+		for _, f := range files {
+			fmt.Fprintf(out, "==> %s\n%s\n", f.path, f.content)
+		}
+		fmt.Fprintf(out, "==> registration\n%s", plan.Registration())
+		return nil
+	}
+
+	// Refuse to overwrite anything before writing the first file
+	for _, f := range files {
+		if _, err := os.Stat(f.path); err == nil && !f.append {
+			return fmt.Errorf("%s already exists", f.path)
+		}
+	}
+
+	for _, f := range files {
+		if err := writeFile(f.path, f.content, f.append); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "wrote %s\n", f.path)
+	}
+
+	uploadDir := filepath.Join(*root, "accounting", "uploads", plan.Source, plan.Dir)
+	if err := os.MkdirAll(uploadDir, 0755); err != nil {
+		return fmt.Errorf("creating upload directory: %w", err)
+	}
+	fmt.Fprintf(out, "created %s\n\n", uploadDir)
+
+	fmt.Fprintf(out, "Inferred %d columns for %s. Next steps:\n", len(plan.Columns), plan.Table)
+	fmt.Fprintln(out, "  1. Review the inferred types, then run: sqlc generate")
+	fmt.Fprintln(out, "  2. Register the handler and menu item:")
+	fmt.Fprintln(out)
+	fmt.Fprint(out, plan.Registration())
+	return nil
+}
+
+// readSample returns the header (first row with at least two non-empty cells)
+// and the data rows that follow it.
+func readSample(path string) ([]string, [][]string, error) {
+	records, err := csv.Read(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	limit := min(len(records), csv.MaxHeaderSearchRows)
+	for i := 0; i < limit; i++ {
+		filled := 0
+		for _, cell := range records[i] {
+			if csv.CleanCell(cell) != "" {
+				filled++
+			}
+		}
+		if filled >= 2 {
+			return records[i], records[i+1:], nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no header row found within first %d rows of %s", csv.MaxHeaderSearchRows, filepath.Base(path))
+}
+
+// nextMigrationVersion returns one more than the highest NNN_ prefix in dir.
+func nextMigrationVersion(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("reading migrations: %w", err)
+	}
+
+	highest := 0
+	for _, e := range entries {
+		prefix, _, ok := strings.Cut(e.Name(), "_")
+		if !ok {
+			continue
+		}
+		if v, err := strconv.Atoi(prefix); err == nil && v > highest {
+			highest = v
+		}
+	}
+	return highest + 1, nil
+}
+
+func writeFile(path, content string, appendTo bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if appendTo {
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
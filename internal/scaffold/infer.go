@@ -0,0 +1,143 @@
+// Package scaffold generates the boilerplate for a new upload type from a sample CSV:
+// the goose migration, sqlc queries, FieldSpec slice and handler wiring.
+package scaffold
+
+import (
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/csv"
+	"github.com/JonMunkholm/TUI/internal/handler"
+	"github.com/JonMunkholm/TUI/internal/schema"
+)
+
+// Column is one inferred CSV column.
+type Column struct {
+	Header string           // Header as it appears in the CSV
+	Name   string           // snake_case database column name
+	Type   schema.FieldType // Inferred from the sample values
+}
+
+// InferColumns infers a column name and field type for each header from the sample rows.
+func InferColumns(header []string, rows [][]string) []Column {
+	cols := make([]Column, len(header))
+	for i, h := range header {
+		h = csv.CleanCell(h)
+
+		var values []string
+		for _, row := range rows {
+			if i < len(row) {
+				values = append(values, row[i])
+			}
+		}
+
+		name := ColumnName(h)
+		cols[i] = Column{Header: h, Name: name, Type: InferType(name, values)}
+	}
+	return cols
+}
+
+// InferType picks the narrowest field type that every non-empty value parses as,
+// using the same conversions the upload pipeline applies. Identifier-like columns
+// (names ending in "id" or values with leading zeros) stay FieldText.
+func InferType(column string, values []string) schema.FieldType {
+	var cleaned []string
+	for _, v := range values {
+		if v = csv.CleanCell(v); v != "" {
+			cleaned = append(cleaned, v)
+		}
+	}
+	if len(cleaned) == 0 || column == "id" || strings.HasSuffix(column, "_id") {
+		return schema.FieldText
+	}
+
+	allBool, anyBoolWord := true, false
+	allNumeric, leadingZero := true, false
+	allDate := true
+
+	for _, v := range cleaned {
+		if !handler.ToPgBool(v).Valid {
+			allBool = false
+		} else if v != "0" && v != "1" {
+			anyBoolWord = true
+		}
+		if !handler.ToPgNumeric(v).Valid {
+			allNumeric = false
+		} else if len(v) > 1 && v[0] == '0' && v[1] != '.' {
+			leadingZero = true
+		}
+		if !handler.ToPgDate(v).Valid {
+			allDate = false
+		}
+	}
+
+	switch {
+	case allBool && anyBoolWord:
+		return schema.FieldBool
+	case allNumeric && !leadingZero:
+		return schema.FieldNumeric
+	case allNumeric:
+		return schema.FieldText
+	case allDate:
+		return schema.FieldDate
+	default:
+		return schema.FieldText
+	}
+}
+
+// ColumnName converts a CSV header to a snake_case column name.
+// "Customer address line 1" -> "customer_address_line_1".
+func ColumnName(header string) string {
+	var sb strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(strings.TrimSpace(header)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			underscore = false
+		} else if !underscore && sb.Len() > 0 {
+			sb.WriteByte('_')
+			underscore = true
+		}
+	}
+
+	name := strings.TrimSuffix(sb.String(), "_")
+	if name == "" {
+		return "column"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "c_" + name
+	}
+	return name
+}
+
+// GoName converts a snake_case name to the Go identifier sqlc would generate.
+// "overall_vat_id_status" -> "OverallVatIDStatus".
+func GoName(snake string) string {
+	var sb strings.Builder
+	for _, part := range strings.Split(snake, "_") {
+		if part == "" {
+			continue
+		}
+		if part == "id" {
+			sb.WriteString("ID")
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
+
+// singular strips a simple English plural, mirroring how sqlc names model structs.
+func singular(s string) string {
+	switch {
+	case strings.HasSuffix(s, "ies"):
+		return strings.TrimSuffix(s, "ies") + "y"
+	case strings.HasSuffix(s, "ses"), strings.HasSuffix(s, "xes"):
+		return strings.TrimSuffix(s, "es")
+	case strings.HasSuffix(s, "ss"):
+		return s
+	case strings.HasSuffix(s, "s"):
+		return strings.TrimSuffix(s, "s")
+	default:
+		return s
+	}
+}
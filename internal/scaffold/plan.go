@@ -0,0 +1,245 @@
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/schema"
+)
+
+// sources describes the existing uploaders a new upload type can be attached to.
+var sources = map[string]struct {
+	Dir      string // Top-level upload directory
+	Uploader string // Uploader type in internal/handler
+	Receiver string // Receiver name used by that uploader's methods
+	Schema   string // File in internal/schema holding the source's FieldSpecs
+}{
+	"ns":    {"NS", "NsUpload", "n", "ns.go"},
+	"sfdc":  {"SFDC", "SfdcUpload", "s", "sfdc.go"},
+	"anrok": {"Anrok", "AnrokUpload", "a", "anrok.go"},
+}
+
+// Plan holds everything needed to render the files for a new upload type.
+type Plan struct {
+	Source   string // Top-level upload directory (NS, SFDC, Anrok)
+	Uploader string // Uploader type the handler methods attach to
+	Receiver string
+	Schema   string // Schema file the FieldSpecs are appended to
+	Table    string // e.g. ns_credit_memos
+	Dir      string // Upload subdirectory, e.g. CreditMemos
+	Plural   string // Go name for the table, e.g. NsCreditMemos
+	Singular string // Go name for one row, e.g. NsCreditMemo
+	Version  int    // Migration version number
+	Columns  []Column
+}
+
+// NewPlan builds a plan from a target name such as "NS credit memos", whose
+// first word selects the source.
+func NewPlan(target string, version int, columns []Column) (Plan, error) {
+	words := strings.Fields(target)
+	if len(words) < 2 {
+		return Plan{}, fmt.Errorf("target %q must be a source followed by a name, e.g. \"NS credit memos\"", target)
+	}
+
+	src, ok := sources[strings.ToLower(words[0])]
+	if !ok {
+		return Plan{}, fmt.Errorf("unknown source %q: expected NS, SFDC or Anrok", words[0])
+	}
+
+	if len(columns) == 0 {
+		return Plan{}, fmt.Errorf("sample has no columns")
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for _, c := range columns {
+		if seen[c.Name] {
+			return Plan{}, fmt.Errorf("headers produce duplicate column name %q", c.Name)
+		}
+		seen[c.Name] = true
+	}
+
+	table := ColumnName(strings.Join(words, " "))
+	name := ColumnName(strings.Join(words[1:], " "))
+	plural := GoName(table)
+
+	return Plan{
+		Source:   src.Dir,
+		Uploader: src.Uploader,
+		Receiver: src.Receiver,
+		Schema:   src.Schema,
+		Table:    table,
+		Dir:      GoName(name),
+		Plural:   plural,
+		Singular: singular(plural),
+		Version:  version,
+		Columns:  columns,
+	}, nil
+}
+
+// SpecsVar is the name of the generated FieldSpec slice.
+func (p Plan) SpecsVar() string {
+	return p.Singular + "FieldSpecs"
+}
+
+// MigrationFile is the migration's file name within sql/schema.
+func (p Plan) MigrationFile() string {
+	return fmt.Sprintf("%03d_%s.sql", p.Version, p.Table)
+}
+
+/* ----------------------------------------
+	Renderers
+---------------------------------------- */
+
+// Migration renders the goose migration creating the table.
+func (p Plan) Migration() string {
+	var sb strings.Builder
+	sb.WriteString("-- +goose Up\n")
+	fmt.Fprintf(&sb, "CREATE TABLE %s (\n", p.Table)
+	sb.WriteString("    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),\n\n")
+	for _, c := range p.Columns {
+		fmt.Fprintf(&sb, "    %s %s,\n", c.Name, sqlType(c.Type))
+	}
+	sb.WriteString("\n    source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '')\n")
+	sb.WriteString(");\n\n")
+	sb.WriteString("-- +goose Down\n")
+	fmt.Fprintf(&sb, "DROP TABLE IF EXISTS %s;\n", p.Table)
+	return sb.String()
+}
+
+// Queries renders the sqlc insert and reset queries.
+func (p Plan) Queries() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "-- name: Insert%s :exec\n", p.Singular)
+	fmt.Fprintf(&sb, "INSERT INTO %s (\n", p.Table)
+	placeholders := make([]string, len(p.Columns))
+	for i, c := range p.Columns {
+		sep := ","
+		if i == len(p.Columns)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&sb, "    %s%s\n", c.Name, sep)
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	fmt.Fprintf(&sb, ")\nVALUES (%s);\n\n", strings.Join(placeholders, ", "))
+	fmt.Fprintf(&sb, "-- name: Reset%s :exec\n", p.Plural)
+	fmt.Fprintf(&sb, "DELETE FROM %s;\n", p.Table)
+	return sb.String()
+}
+
+// Specs renders the FieldSpec slice, to be appended to the source's schema file.
+func (p Plan) Specs() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\n// %s defines the expected CSV columns for %s data.\n", p.SpecsVar(), strings.ReplaceAll(p.Table, "_", " "))
+	fmt.Fprintf(&sb, "var %s = []FieldSpec{\n", p.SpecsVar())
+	for _, c := range p.Columns {
+		column := ""
+		if c.Name != strings.ToLower(c.Header) {
+			column = fmt.Sprintf(" Column: %q,", c.Name)
+		}
+		fmt.Fprintf(&sb, "\t{Name: %q,%s Type: %s, Required: false, AllowEmpty: true},\n", c.Header, column, fieldTypeName(c.Type))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Handler renders the insert action, BuildParams function and insert wrapper.
+func (p Plan) Handler() string {
+	r := p.Receiver
+	params := "db.Insert" + p.Singular + "Params"
+
+	width := 0
+	for _, c := range p.Columns {
+		if n := len(GoName(c.Name)); n > width {
+			width = n
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("package handler\n\n")
+	sb.WriteString("import (\n\t\"context\"\n\n")
+	sb.WriteString("\tdb \"github.com/JonMunkholm/TUI/internal/database\"\n")
+	sb.WriteString("\ttea \"github.com/charmbracelet/bubbletea\"\n)\n\n")
+
+	fmt.Fprintf(&sb, "func (%s *%s) Insert%s() tea.Cmd {\n", r, p.Uploader, p.Plural)
+	fmt.Fprintf(&sb, "\treturn %s.RunUpload(%q)\n}\n\n", r, p.Dir)
+
+	fmt.Fprintf(&sb, "func (%s *%s) Build%sParams(vrow ValidatedRow) (%s, error) {\n", r, p.Uploader, p.Singular, params)
+	fmt.Fprintf(&sb, "\treturn %s{\n", params)
+	for _, c := range p.Columns {
+		field := GoName(c.Name) + ":"
+		fmt.Fprintf(&sb, "\t\t%-*s %s(vrow[%q]),\n", width+1, field, converter(c.Type), c.Header)
+	}
+	sb.WriteString("\t}, nil\n}\n\n")
+
+	fmt.Fprintf(&sb, "func (%s *%s) insert%s() InsertFn[%s] {\n", r, p.Uploader, p.Singular, params)
+	fmt.Fprintf(&sb, "\treturn func(ctx context.Context, queries *db.Queries, arg %s) (bool, error) {\n", params)
+	fmt.Fprintf(&sb, "\t\terr := queries.Insert%s(ctx, arg)\n", p.Singular)
+	sb.WriteString("\t\treturn err == nil, err\n\t}\n}\n")
+	return sb.String()
+}
+
+// Registration renders the makeDirMap entry and menu item to paste in by hand.
+func (p Plan) Registration() string {
+	r := p.Receiver
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "// internal/handler: add to (%s *%s) makeDirMap\n", r, p.Uploader)
+	fmt.Fprintf(&sb, "%q: CsvHandler[db.Insert%sParams]{\n", p.Dir, p.Singular)
+	fmt.Fprintf(&sb, "\ttable:  %q,\n", p.Table)
+	fmt.Fprintf(&sb, "\tspecs:  schema.Resolve(schema.%s),\n", p.SpecsVar())
+	fmt.Fprintf(&sb, "\tbuild:  %s.Build%sParams,\n", r, p.Singular)
+	fmt.Fprintf(&sb, "\tinsert: %s.insert%s(),\n},\n\n", r, p.Singular)
+	sb.WriteString("// internal/application/menu.go: add to the source's upload menu\n")
+	fmt.Fprintf(&sb, "{Label: \"Upload %s\", Action: h.Insert%s},\n", spaced(p.Dir), p.Plural)
+	return sb.String()
+}
+
+// spaced splits a Go name into words: "CreditMemos" -> "Credit Memos".
+func spaced(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			sb.WriteByte(' ')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func sqlType(t schema.FieldType) string {
+	switch t {
+	case schema.FieldDate:
+		return "DATE"
+	case schema.FieldNumeric:
+		return "NUMERIC"
+	case schema.FieldBool:
+		return "BOOLEAN"
+	default:
+		return "TEXT"
+	}
+}
+
+func converter(t schema.FieldType) string {
+	switch t {
+	case schema.FieldDate:
+		return "ToPgDate"
+	case schema.FieldNumeric:
+		return "ToPgNumeric"
+	case schema.FieldBool:
+		return "ToPgBool"
+	default:
+		return "ToPgText"
+	}
+}
+
+func fieldTypeName(t schema.FieldType) string {
+	switch t {
+	case schema.FieldDate:
+		return "FieldDate"
+	case schema.FieldNumeric:
+		return "FieldNumeric"
+	case schema.FieldBool:
+		return "FieldBool"
+	default:
+		return "FieldText"
+	}
+}
@@ -0,0 +1,185 @@
+package scaffold
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JonMunkholm/TUI/internal/schema"
+)
+
+/* ========================================
+	Inference Tests
+======================================== */
+
+func TestInferType(t *testing.T) {
+	tests := []struct {
+		name     string
+		column   string
+		values   []string
+		expected schema.FieldType
+	}{
+		{"dates", "invoice_date", []string{"1/15/2025", "2025-02-01", ""}, schema.FieldDate},
+		{"numbers", "amount", []string{"$1,200.00", "(50.25)", "3"}, schema.FieldNumeric},
+		{"bool words", "void", []string{"Yes", "no", "TRUE"}, schema.FieldBool},
+		{"zero one only", "qty", []string{"0", "1", "1"}, schema.FieldNumeric},
+		{"leading zeros", "zip", []string{"02134", "10001"}, schema.FieldText},
+		{"id column", "customer_id", []string{"123", "456"}, schema.FieldText},
+		{"mixed", "memo", []string{"12", "hello"}, schema.FieldText},
+		{"all empty", "notes", []string{"", "  "}, schema.FieldText},
+		{"excel formula numbers", "total", []string{`="100.00"`, `="5"`}, schema.FieldNumeric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InferType(tt.column, tt.values); got != tt.expected {
+				t.Errorf("InferType(%q, %v) = %v, expected %v", tt.column, tt.values, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Transaction ID", "transaction_id"},
+		{"Customer address line 1", "customer_address_line_1"},
+		{"  Amount ($) ", "amount"},
+		{"1st Payment", "c_1st_payment"},
+		{"already_snake", "already_snake"},
+		{"%%%", "column"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := ColumnName(tt.input); got != tt.expected {
+				t.Errorf("ColumnName(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"overall_vat_id_status", "OverallVatIDStatus"},
+		{"salesforce_id_io", "SalesforceIDIo"},
+		{"valid_vat_ids", "ValidVatIds"},
+		{"ns_credit_memos", "NsCreditMemos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := GoName(tt.input); got != tt.expected {
+				t.Errorf("GoName(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+/* ========================================
+	Plan Tests
+======================================== */
+
+func TestNewPlan(t *testing.T) {
+	cols := []Column{{Header: "Amount", Name: "amount", Type: schema.FieldNumeric}}
+
+	plan, err := NewPlan("NS credit memos", 12, cols)
+	if err != nil {
+		t.Fatalf("NewPlan() error = %v", err)
+	}
+
+	checks := map[string]string{
+		"Source":        plan.Source,
+		"Table":         plan.Table,
+		"Dir":           plan.Dir,
+		"Singular":      plan.Singular,
+		"MigrationFile": plan.MigrationFile(),
+	}
+	expected := map[string]string{
+		"Source":        "NS",
+		"Table":         "ns_credit_memos",
+		"Dir":           "CreditMemos",
+		"Singular":      "NsCreditMemo",
+		"MigrationFile": "012_ns_credit_memos.sql",
+	}
+	for k, want := range expected {
+		if checks[k] != want {
+			t.Errorf("%s = %q, expected %q", k, checks[k], want)
+		}
+	}
+}
+
+func TestNewPlan_Negative(t *testing.T) {
+	cols := []Column{{Header: "A", Name: "a"}}
+
+	tests := []struct {
+		name   string
+		target string
+		cols   []Column
+	}{
+		{"no name", "NS", cols},
+		{"unknown source", "Stripe charges", cols},
+		{"no columns", "NS credit memos", nil},
+		{"duplicate columns", "NS credit memos", []Column{{Header: "A b", Name: "a_b"}, {Header: "A-b", Name: "a_b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewPlan(tt.target, 1, tt.cols); err == nil {
+				t.Errorf("NewPlan(%q) expected error, got nil", tt.target)
+			}
+		})
+	}
+}
+
+func TestMain_WritesFiles(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"sql/schema", "sql/queries", "internal/schema", "internal/handler"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "sql/schema/007_existing.sql"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "internal/schema/anrok.go"), []byte("package schema\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sample := filepath.Join(root, "sample.csv")
+	if err := os.WriteFile(sample, []byte("Report title\nRefund ID,Refund date,Amount\nR-1,1/2/2025,10.00\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := Main([]string{"-root", root, "-name", "Anrok refunds", sample}, &out); err != nil {
+		t.Fatalf("Main() error = %v\n%s", err, out.String())
+	}
+
+	for _, path := range []string{
+		"sql/schema/008_anrok_refunds.sql",
+		"sql/queries/anrok_refunds.sql",
+		"internal/handler/anrokRefunds.go",
+		"accounting/uploads/Anrok/Refunds",
+	} {
+		if _, err := os.Stat(filepath.Join(root, path)); err != nil {
+			t.Errorf("expected %s to exist: %v", path, err)
+		}
+	}
+
+	specs, _ := os.ReadFile(filepath.Join(root, "internal/schema/anrok.go"))
+	if !strings.Contains(string(specs), `{Name: "Refund date", Column: "refund_date", Type: FieldDate`) {
+		t.Errorf("schema file missing inferred spec:\n%s", specs)
+	}
+
+	// Running again must not overwrite the generated files
+	if err := Main([]string{"-root", root, "-name", "Anrok refunds", sample}, &out); err == nil {
+		t.Error("Main() expected error on second run, got nil")
+	}
+}
@@ -2,27 +2,27 @@ package schema
 
 // AnrokFieldSpecs defines the expected CSV columns for Anrok tax transaction reports.
 var AnrokFieldSpecs = []FieldSpec{
-	{Name: "Transaction ID", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer ID", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer name", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Overall VAT ID validation status", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Valid VAT IDs", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Other VAT IDs", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Invoice date", Type: FieldDate, Required: false, AllowEmpty: true},
-	{Name: "Tax date", Type: FieldDate, Required: false, AllowEmpty: true},
-	{Name: "Transaction currency", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Sales amount", Type: FieldNumeric, Required: false, AllowEmpty: true},
-	{Name: "Exempt reasons", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Tax amount", Type: FieldNumeric, Required: false, AllowEmpty: true},
-	{Name: "Invoice amount", Type: FieldNumeric, Required: false, AllowEmpty: true},
-	{Name: "Void", Type: FieldBool, Required: false, AllowEmpty: true},
-	{Name: "Customer address line 1", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer address city", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer address region", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer address postal code", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer address country", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Customer country code", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Jurisdictions", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Jurisdictions IDs", Type: FieldText, Required: false, AllowEmpty: true},
-	{Name: "Return IDs", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Transaction ID", Column: "transaction_id", Type: FieldText, Required: false, AllowEmpty: true, Normalizers: []string{NormTrim, NormUpper}},
+	{Name: "Customer ID", Column: "customer_id", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Customer name", Column: "customer_name", Type: FieldText, Required: false, AllowEmpty: true, Normalizers: []string{NormCollapseSpace}},
+	{Name: "Overall VAT ID validation status", Column: "overall_vat_id_status", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Valid VAT IDs", Column: "valid_vat_ids", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Other VAT IDs", Column: "other_vat_ids", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Invoice date", Column: "invoice_date", Type: FieldDate, Required: false, AllowEmpty: true, Checks: []Check{WarnIfFutureDate(365)}},
+	{Name: "Tax date", Column: "tax_date", Type: FieldDate, Required: false, AllowEmpty: true, Checks: []Check{WarnIfFutureDate(365)}},
+	{Name: "Transaction currency", Column: "transaction_currency", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Sales amount", Column: "sales_amount", Type: FieldNumeric, Required: false, AllowEmpty: true},
+	{Name: "Exempt reasons", Column: "exempt_reason", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Tax amount", Column: "tax_amount", Type: FieldNumeric, Required: false, AllowEmpty: true},
+	{Name: "Invoice amount", Column: "invoice_amount", Type: FieldNumeric, Required: false, AllowEmpty: true},
+	{Name: "Void", Column: "void", Type: FieldBool, Required: false, AllowEmpty: true},
+	{Name: "Customer address line 1", Column: "customer_address_line_1", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Customer address city", Column: "customer_address_city", Type: FieldText, Required: false, AllowEmpty: true, Normalizers: []string{NormCollapseSpace}},
+	{Name: "Customer address region", Column: "customer_address_region", Type: FieldText, Required: false, AllowEmpty: true, Normalizers: []string{NormUsState}},
+	{Name: "Customer address postal code", Column: "customer_address_postal_code", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Customer address country", Column: "customer_address_country", Type: FieldText, Required: false, AllowEmpty: true, Normalizers: []string{NormCountry}},
+	{Name: "Customer country code", Column: "customer_country_code", Type: FieldText, Required: false, AllowEmpty: true, Normalizers: []string{NormTrim, NormUpper}},
+	{Name: "Jurisdictions", Column: "jurisdictions", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Jurisdictions IDs", Column: "jurisdiction_ids", Type: FieldText, Required: false, AllowEmpty: true},
+	{Name: "Return IDs", Column: "return_ids", Type: FieldText, Required: false, AllowEmpty: true},
 }
@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/JonMunkholm/TUI/internal/sfid"
+)
+
+// NormalizerFunc transforms a cleaned CSV cell before type validation.
+type NormalizerFunc func(string) string
+
+// Names of the built-in normalizers. Use these in FieldSpec.Normalizers.
+const (
+	NormTrim          = "trim"
+	NormUpper         = "upper"
+	NormUsState       = "us_state"
+	NormCountry       = "country"
+	NormCollapseSpace = "collapse_space"
+	NormDigits        = "digits"
+	NormSfdcID        = "sfdc_id"
+)
+
+// normalizers holds every named normalizer available to FieldSpecs.
+var normalizers = map[string]NormalizerFunc{
+	NormTrim:          strings.TrimSpace,
+	NormUpper:         strings.ToUpper,
+	NormUsState:       NormalizeUsState,
+	NormCountry:       NormalizeCountry,
+	NormCollapseSpace: CollapseWhitespace,
+	NormDigits:        StripNonDigits,
+	NormSfdcID:        sfid.To18,
+}
+
+// RegisterNormalizer adds (or replaces) a named normalizer.
+// Register custom normalizers from an init function before any upload runs.
+func RegisterNormalizer(name string, fn NormalizerFunc) {
+	normalizers[name] = fn
+}
+
+// LookupNormalizer returns the normalizer registered under name.
+func LookupNormalizer(name string) (NormalizerFunc, bool) {
+	fn, ok := normalizers[name]
+	return fn, ok
+}
+
+// NormalizerNames returns the registered normalizer names in sorted order.
+func NormalizerNames() []string {
+	names := make([]string, 0, len(normalizers))
+	for name := range normalizers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Chain composes the named normalizers into a single function applied left to right.
+// Returns an error if any name is not registered.
+func Chain(names ...string) (NormalizerFunc, error) {
+	fns := make([]NormalizerFunc, len(names))
+	for i, name := range names {
+		fn, ok := normalizers[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown normalizer %q", name)
+		}
+		fns[i] = fn
+	}
+
+	return func(s string) string {
+		for _, fn := range fns {
+			s = fn(s)
+		}
+		return s
+	}, nil
+}
+
+// Resolve returns a copy of specs with each spec's named normalizers looked
+// up once, so Normalize does not rebuild the chain for every cell. Call it
+// when building a handler's specs, after custom normalizers are registered.
+// A spec naming an unknown normalizer keeps the error and returns it from
+// Normalize.
+func Resolve(specs []FieldSpec) []FieldSpec {
+	out := make([]FieldSpec, len(specs))
+	for i, f := range specs {
+		f.chain, f.chainErr = Chain(f.Normalizers...)
+		f.resolved = true
+		out[i] = f
+	}
+	return out
+}
+
+// Normalize applies the spec's Normalizer function followed by its named
+// Normalizers chain. Returns an error if the chain references an unknown name.
+// Specs that were not resolved look the chain up on every call.
+func (f FieldSpec) Normalize(s string) (string, error) {
+	if f.Normalizer != nil {
+		s = f.Normalizer(s)
+	}
+
+	if len(f.Normalizers) == 0 {
+		return s, nil
+	}
+
+	chain, err := f.chain, f.chainErr
+	if !f.resolved {
+		chain, err = Chain(f.Normalizers...)
+	}
+	if err != nil {
+		return "", fmt.Errorf("field %q: %w", f.Name, err)
+	}
+	return chain(s), nil
+}
+
+/* ----------------------------------------
+	Built-in Normalizers
+---------------------------------------- */
+
+// CollapseWhitespace trims the value and collapses internal runs of whitespace to a single space.
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// StripNonDigits removes every character that is not an ASCII digit.
+func StripNonDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
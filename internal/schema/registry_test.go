@@ -0,0 +1,164 @@
+package schema
+
+import "testing"
+
+/* ========================================
+	Normalizer Registry Tests
+======================================== */
+
+func TestChain_AppliesInOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		chain    []string
+		input    string
+		expected string
+	}{
+		{"trim then upper", []string{NormTrim, NormUpper}, "  so-123 ", "SO-123"},
+		{"collapse whitespace", []string{NormCollapseSpace}, " Acme \t  Corp\n", "Acme Corp"},
+		{"us state", []string{NormUsState}, "California", "CA"},
+		{"country", []string{NormCountry}, "United States of America", "US"},
+		{"digits", []string{NormDigits}, "ID-00 42", "0042"},
+		{"sfdc id", []string{NormSfdcID}, "0015000000Gv7qJ", "0015000000Gv7qJAAR"},
+		{"empty chain", []string{}, " unchanged ", " unchanged "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, err := Chain(tt.chain...)
+			if err != nil {
+				t.Fatalf("Chain(%v) error = %v", tt.chain, err)
+			}
+			if got := fn(tt.input); got != tt.expected {
+				t.Errorf("Chain(%v)(%q) = %q, expected %q", tt.chain, tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChain_UnknownName(t *testing.T) {
+	if _, err := Chain(NormTrim, "no_such_normalizer"); err == nil {
+		t.Error("Chain() expected error for unknown normalizer, got nil")
+	}
+}
+
+func TestFieldSpec_Normalize(t *testing.T) {
+	spec := FieldSpec{
+		Name:        "state",
+		Normalizer:  func(s string) string { return s + " " },
+		Normalizers: []string{NormTrim, NormUsState},
+	}
+
+	got, err := spec.Normalize("new york")
+	if err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if got != "NY" {
+		t.Errorf("Normalize() = %q, expected %q", got, "NY")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	specs := Resolve([]FieldSpec{
+		{Name: "id", Normalizers: []string{NormDigits}},
+		{Name: "bad", Normalizers: []string{"no_such_normalizer"}},
+	})
+
+	got, err := specs[0].Normalize("ID-00 42")
+	if err != nil || got != "0042" {
+		t.Errorf("Normalize() = %q, %v, expected %q", got, err, "0042")
+	}
+	if _, err := specs[1].Normalize("x"); err == nil {
+		t.Error("Normalize() expected error for unknown normalizer, got nil")
+	}
+}
+
+func TestRegisterNormalizer(t *testing.T) {
+	RegisterNormalizer("test_reverse", func(s string) string {
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r)
+	})
+	defer delete(normalizers, "test_reverse")
+
+	fn, ok := LookupNormalizer("test_reverse")
+	if !ok {
+		t.Fatal("LookupNormalizer() did not find registered normalizer")
+	}
+	if got := fn("abc"); got != "cba" {
+		t.Errorf("test_reverse(%q) = %q, expected %q", "abc", got, "cba")
+	}
+}
+
+// Every normalizer referenced by the built-in specs must be registered,
+// otherwise every row of that upload type would fail validation.
+func TestBuiltinSpecs_NormalizersRegistered(t *testing.T) {
+	specSets := map[string][]FieldSpec{
+		"NsCustomerFieldSpecs":      NsCustomerFieldSpecs,
+		"NsSoDetailFieldSpecs":      NsSoDetailFieldSpecs,
+		"NsInvoiceDetailFieldSpecs": NsInvoiceDetailFieldSpecs,
+		"SfdcCustomerFieldSpecs":    SfdcCustomerFieldSpecs,
+		"SfdcPriceBookFieldSpecs":   SfdcPriceBookFieldSpecs,
+		"SfdcOppDetailFieldSpecs":   SfdcOppDetailFieldSpecs,
+		"AnrokFieldSpecs":           AnrokFieldSpecs,
+	}
+
+	for setName, specs := range specSets {
+		for _, spec := range specs {
+			if _, err := Chain(spec.Normalizers...); err != nil {
+				t.Errorf("%s[%q]: %v", setName, spec.Name, err)
+			}
+		}
+	}
+}
+
+/* ========================================
+	Built-in Normalizer Tests
+======================================== */
+
+func TestNormalizeCountry(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"full name", "Germany", "DE"},
+		{"mixed case", "UNITED KINGDOM", "GB"},
+		{"alias", "USA", "US"},
+		{"extra whitespace", "  New   Zealand ", "NZ"},
+		{"alpha-2 lowercase", "ca", "CA"},
+		{"alpha-2 uppercase", "FR", "FR"},
+		{"unknown", "Atlantis", "Atlantis"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeCountry(tt.input); got != tt.expected {
+				t.Errorf("NormalizeCountry(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStripNonDigits(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"12345", "12345"},
+		{"ID-123", "123"},
+		{"1,234.00", "123400"},
+		{"abc", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := StripNonDigits(tt.input); got != tt.expected {
+				t.Errorf("StripNonDigits(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@
 // the expected CSV structure and validation rules.
 package schema
 
+import "strings"
+
 // FieldType represents the expected data type for a CSV field.
 type FieldType int
 
@@ -16,10 +18,66 @@ const (
 
 // FieldSpec defines validation rules for a single CSV column.
 type FieldSpec struct {
-	Name       string            // Column header name (must match CSV exactly)
-	Type       FieldType         // Expected data type
-	Required   bool              // Column must exist in CSV header
-	AllowEmpty bool              // If true, empty values are allowed even when Required
-	EnumValues []string          // Valid values for FieldEnum type
-	Normalizer func(string) string // Optional transformation function
+	Name        string              // Column header name (must match CSV exactly)
+	Column      string              // Database column name; defaults to Name lower-cased
+	Type        FieldType           // Expected data type
+	Required    bool                // Column must exist in CSV header
+	AllowEmpty  bool                // If true, empty values are allowed even when Required
+	EnumValues  []string            // Valid values for FieldEnum type
+	Normalizer  func(string) string // Optional transformation function
+	Normalizers []string            // Named normalizers (see registry.go), applied in order after Normalizer
+	Checks      []Check             // Additional rules evaluated after type validation
+
+	chain    NormalizerFunc // Normalizers, looked up by Resolve
+	chainErr error
+	resolved bool
+}
+
+// ColumnName returns the database column this field is stored in.
+func (f FieldSpec) ColumnName() string {
+	if f.Column != "" {
+		return f.Column
+	}
+	return strings.ToLower(f.Name)
+}
+
+// Severity determines whether a failed check rejects the row or only flags it.
+type Severity int
+
+const (
+	SeverityError   Severity = iota // Row is rejected and written to the failed-records file
+	SeverityWarning                 // Row is imported and the issue is recorded as a warning
+)
+
+// CheckKind identifies an additional validation rule.
+type CheckKind int
+
+const (
+	CheckNormalized CheckKind = iota // Value was changed by a normalizer
+	CheckFutureDate                  // Date is more than MaxDays after today
+	CheckEmptied                     // A non-empty value was normalized to empty
+)
+
+// Check is an additional rule evaluated against a field after type validation.
+// Unknown values on non-required FieldEnum fields are always reported as warnings.
+type Check struct {
+	Kind     CheckKind
+	Severity Severity
+	MaxDays  int // CheckFutureDate: how many days past today a date may be
+}
+
+// WarnIfNormalized flags values that a normalizer changed.
+func WarnIfNormalized() Check {
+	return Check{Kind: CheckNormalized, Severity: SeverityWarning}
+}
+
+// RejectIfEmptied fails rows whose value a normalizer reduced to nothing,
+// such as "N/A" in a digits-only ID column.
+func RejectIfEmptied() Check {
+	return Check{Kind: CheckEmptied, Severity: SeverityError}
+}
+
+// WarnIfFutureDate flags dates more than maxDays after today.
+func WarnIfFutureDate(maxDays int) Check {
+	return Check{Kind: CheckFutureDate, Severity: SeverityWarning, MaxDays: maxDays}
 }
@@ -0,0 +1,64 @@
+// Package sfid normalizes Salesforce record IDs.
+//
+// Salesforce IDs come in a case-sensitive 15-character form and a
+// case-insensitive 18-character form, which appends a 3-character checksum
+// of the casing. Exports mix both, so IDs are compared in 18-character form.
+package sfid
+
+import "strings"
+
+const suffixChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ012345"
+
+// To18 returns the 18-character form of id. 18-character IDs are returned
+// trimmed and unchanged; anything that is not a 15-character alphanumeric ID
+// is returned trimmed so it still compares equal to itself.
+func To18(id string) string {
+	id = strings.TrimSpace(id)
+	if len(id) != 15 || !alphanumeric(id) {
+		return id
+	}
+
+	suffix := make([]byte, 3)
+	for chunk := 0; chunk < 3; chunk++ {
+		n := 0
+		for i := 0; i < 5; i++ {
+			c := id[chunk*5+i]
+			if c >= 'A' && c <= 'Z' {
+				n |= 1 << i
+			}
+		}
+		suffix[chunk] = suffixChars[n]
+	}
+	return id + string(suffix)
+}
+
+// Key returns the form used to match IDs: 18 characters, with the checksum
+// upper-cased so an 18-character ID re-cased by a spreadsheet still matches.
+func Key(id string) string {
+	id = To18(id)
+	if len(id) != 18 {
+		return id
+	}
+	return id[:15] + strings.ToUpper(id[15:])
+}
+
+// Is15 reports whether id is in the 15-character form.
+func Is15(id string) bool {
+	return len(strings.TrimSpace(id)) == 15
+}
+
+// Valid reports whether id looks like a Salesforce ID: 15 or 18 letters and digits.
+func Valid(id string) bool {
+	id = strings.TrimSpace(id)
+	return (len(id) == 15 || len(id) == 18) && alphanumeric(id)
+}
+
+// alphanumeric reports whether s holds only ASCII letters and digits.
+func alphanumeric(s string) bool {
+	for _, c := range s {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,66 @@
+package sfid
+
+import "testing"
+
+/* ========================================
+	To18 Tests
+======================================== */
+
+func TestTo18(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		// Known pairs from Salesforce
+		{"001A0000006Vm9r", "001A0000006Vm9rIAC"},
+		{"003A0000005QB3A", "003A0000005QB3AIAW"},
+		{"001000000000000", "001000000000000AAA"},
+		{"001A0000006Vm9rIAC", "001A0000006Vm9rIAC"},
+		{" 001A0000006Vm9r ", "001A0000006Vm9rIAC"},
+		{"0015000000Gv7qJ", "0015000000Gv7qJAAR"},
+		{"001a0000006vm9r", "001a0000006vm9rAAA"},
+		{"", ""},
+		{"short", "short"},
+		{"0015000000Gv7-J", "0015000000Gv7-J"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := To18(tt.input); got != tt.expected {
+				t.Errorf("To18(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	if Key("001A0000006Vm9r") != Key("001A0000006Vm9rIAC") {
+		t.Error("15- and 18-character forms should share a key")
+	}
+	if Key("001A0000006Vm9rIAC") != Key("001A0000006Vm9riac") {
+		t.Error("the checksum should compare case-insensitively")
+	}
+	if Key("001A0000006Vm9r") == Key("001a0000006Vm9r") {
+		t.Error("15-character IDs differing in case are different records")
+	}
+}
+
+func TestValid(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"001A0000006Vm9r", true},
+		{"001A0000006Vm9rIAC", true},
+		{" 001A0000006Vm9rIAC ", true},
+		{"001A0000006Vm9", false},
+		{"001A0000006Vm9r-AC", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := Valid(tt.input); got != tt.expected {
+			t.Errorf("Valid(%q) = %v, expected %v", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -1,16 +1,30 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
 
 	"github.com/JonMunkholm/TUI/internal/application"
+	"github.com/JonMunkholm/TUI/internal/scaffold"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/joho/godotenv"
 )
 
 
 func main() {
+	// Developer subcommand: generate a new upload type from a sample CSV
+	if len(os.Args) > 1 && os.Args[1] == "scaffold" {
+		if err := scaffold.Main(os.Args[2:], os.Stdout); err != nil {
+			fmt.Printf("scaffold: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	readOnly := flag.Bool("read-only", false, "connect read-only and hide upload, reset and other write actions")
+	flag.Parse()
+
 	if err := godotenv.Load(); err != nil {
 		fmt.Println("No .env file found, continuing...")
 	}
@@ -21,7 +35,7 @@ func main() {
 		}
 	}()
 
-	m, err := application.InitialModel()
+	m, err := application.InitialModel(*readOnly)
 	defer m.Close() // Always call Close, even on error (handles partial init)
 
 	if err != nil {
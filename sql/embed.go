@@ -0,0 +1,13 @@
+// Package sql embeds the goose migrations so the binary can apply them
+// without the repository on disk.
+package sql
+
+import "embed"
+
+// Migrations holds every schema/NNN_name.sql file.
+//
+//go:embed schema/*.sql
+var Migrations embed.FS
+
+// MigrationsDir is the directory inside Migrations that holds the files.
+const MigrationsDir = "schema"
@@ -1,3 +1,8 @@
+-- name: GetAnrokTransactionByKey :one
+SELECT *
+FROM anrok_transactions
+WHERE transaction_id = $1;
+
 -- name: InsertAnrokTransaction :exec
 INSERT INTO anrok_transactions (
     transaction_id,
@@ -26,5 +31,10 @@ INSERT INTO anrok_transactions (
 )
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23);
 
+-- name: ListAnrokTransactions :many
+SELECT *
+FROM anrok_transactions
+ORDER BY transaction_id, id;
+
 -- name: ResetAnrokTransactions :exec
 DELETE FROM anrok_transactions;
@@ -0,0 +1,29 @@
+-- name: DeleteImportedCrosswalk :exec
+DELETE FROM customer_crosswalk
+WHERE source = 'ns';
+
+-- name: InsertImportedCrosswalk :exec
+INSERT INTO customer_crosswalk (ns_internal_id, sfdc_account_id, source)
+VALUES ($1, $2, 'ns')
+ON CONFLICT (ns_internal_id) DO NOTHING;
+
+-- name: ListCustomerCrosswalk :many
+SELECT *
+FROM customer_crosswalk
+ORDER BY ns_internal_id;
+
+-- name: SetCrosswalkOverride :exec
+INSERT INTO customer_crosswalk (ns_internal_id, sfdc_account_id, source, note)
+VALUES ($1, $2, 'manual', $3)
+ON CONFLICT (ns_internal_id) DO UPDATE
+SET sfdc_account_id = EXCLUDED.sfdc_account_id,
+    source = 'manual',
+    note = EXCLUDED.note,
+    updated_at = NOW();
+
+-- name: DeleteCrosswalkOverride :execrows
+DELETE FROM customer_crosswalk
+WHERE ns_internal_id = $1 AND source = 'manual';
+
+-- name: ResetCustomerCrosswalk :exec
+DELETE FROM customer_crosswalk;
@@ -0,0 +1,102 @@
+-- Slowly-changing-dimension history for customer snapshots. After each import,
+-- Close* ends the open version of every customer whose values changed and
+-- Open* starts a version for every customer without one. Snapshots must be
+-- newer than Latest*Snapshot, so every change gets its own version.
+
+-- name: LatestNsCustomerSnapshot :one
+SELECT MAX(valid_from)::timestamp AS latest
+FROM ns_customers_history;
+
+-- name: CloseNsCustomerHistory :exec
+UPDATE ns_customers_history h
+SET valid_to = @valid_to::timestamp
+FROM ns_customers c
+WHERE h.internal_id = c.internal_id
+  AND h.valid_to IS NULL
+  AND (h.salesforce_id_io, h.name, h.duplicate, h.company_name, h.balance, h.unbilled_orders, h.overdue_balance, h.days_overdue)
+      IS DISTINCT FROM
+      (c.salesforce_id_io, c.name, c.duplicate, c.company_name, c.balance, c.unbilled_orders, c.overdue_balance, c.days_overdue);
+
+-- name: OpenNsCustomerHistory :exec
+INSERT INTO ns_customers_history (
+    internal_id, salesforce_id_io, name, duplicate, company_name,
+    balance, unbilled_orders, overdue_balance, days_overdue, valid_from
+)
+SELECT c.internal_id, c.salesforce_id_io, c.name, c.duplicate, c.company_name,
+       c.balance, c.unbilled_orders, c.overdue_balance, c.days_overdue, @valid_from::timestamp
+FROM ns_customers c
+WHERE c.internal_id IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM ns_customers_history h
+      WHERE h.internal_id = c.internal_id AND h.valid_to IS NULL
+  );
+
+-- name: GetNsCustomerAsOf :one
+SELECT *
+FROM ns_customers_history
+WHERE internal_id = @internal_id
+  AND valid_from < @as_of::date + 1
+  AND (valid_to IS NULL OR valid_to >= @as_of::date + 1);
+
+-- name: ListNsCustomersAsOf :many
+SELECT *
+FROM ns_customers_history
+WHERE valid_from < @as_of::date + 1
+  AND (valid_to IS NULL OR valid_to >= @as_of::date + 1)
+ORDER BY internal_id;
+
+-- name: GetNsCustomerHistory :many
+SELECT *
+FROM ns_customers_history
+WHERE internal_id = $1
+ORDER BY valid_from;
+
+-- name: ResetNsCustomersHistory :exec
+DELETE FROM ns_customers_history;
+
+-- name: LatestSfdcCustomerSnapshot :one
+SELECT MAX(valid_from)::timestamp AS latest
+FROM sfdc_customers_history;
+
+-- name: CloseSfdcCustomerHistory :exec
+UPDATE sfdc_customers_history h
+SET valid_to = @valid_to::timestamp
+FROM sfdc_customers c
+WHERE h.account_id_casesafe = c.account_id_casesafe
+  AND h.valid_to IS NULL
+  AND (h.account_name, h.last_activity, h.type)
+      IS DISTINCT FROM
+      (c.account_name, c.last_activity, c.type);
+
+-- name: OpenSfdcCustomerHistory :exec
+INSERT INTO sfdc_customers_history (account_id_casesafe, account_name, last_activity, type, valid_from)
+SELECT c.account_id_casesafe, c.account_name, c.last_activity, c.type, @valid_from::timestamp
+FROM sfdc_customers c
+WHERE c.account_id_casesafe IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM sfdc_customers_history h
+      WHERE h.account_id_casesafe = c.account_id_casesafe AND h.valid_to IS NULL
+  );
+
+-- name: GetSfdcCustomerAsOf :one
+SELECT *
+FROM sfdc_customers_history
+WHERE account_id_casesafe = @account_id_casesafe
+  AND valid_from < @as_of::date + 1
+  AND (valid_to IS NULL OR valid_to >= @as_of::date + 1);
+
+-- name: ListSfdcCustomersAsOf :many
+SELECT *
+FROM sfdc_customers_history
+WHERE valid_from < @as_of::date + 1
+  AND (valid_to IS NULL OR valid_to >= @as_of::date + 1)
+ORDER BY account_id_casesafe;
+
+-- name: GetSfdcCustomerHistory :many
+SELECT *
+FROM sfdc_customers_history
+WHERE account_id_casesafe = $1
+ORDER BY valid_from;
+
+-- name: ResetSfdcCustomersHistory :exec
+DELETE FROM sfdc_customers_history;
@@ -0,0 +1,12 @@
+-- name: InsertImportWarning :exec
+INSERT INTO import_warnings (batch, line, field, value, message)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetImportWarnings :many
+SELECT *
+FROM import_warnings
+WHERE batch = $1
+ORDER BY line, field;
+
+-- name: ResetImportWarnings :exec
+DELETE FROM import_warnings;
@@ -0,0 +1,21 @@
+-- name: DeleteMonthlyMetrics :exec
+DELETE FROM metrics_monthly;
+
+-- name: InsertMonthlyMetric :exec
+INSERT INTO metrics_monthly (
+    period,
+    mrr,
+    arr,
+    new_mrr,
+    expansion_mrr,
+    contraction_mrr,
+    churned_mrr,
+    bookings,
+    customers
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: ListMonthlyMetrics :many
+SELECT *
+FROM metrics_monthly
+ORDER BY period;
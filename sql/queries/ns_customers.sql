@@ -1,3 +1,8 @@
+-- name: GetNsCustomerByKey :one
+SELECT *
+FROM ns_customers
+WHERE internal_id = $1;
+
 -- name: InsertNsCustomer :exec
 INSERT INTO ns_customers (
     salesforce_id_io,
@@ -10,7 +15,21 @@ INSERT INTO ns_customers (
     overdue_balance,
     days_overdue
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (internal_id) DO UPDATE SET
+    salesforce_id_io = EXCLUDED.salesforce_id_io,
+    name = EXCLUDED.name,
+    duplicate = EXCLUDED.duplicate,
+    company_name = EXCLUDED.company_name,
+    balance = EXCLUDED.balance,
+    unbilled_orders = EXCLUDED.unbilled_orders,
+    overdue_balance = EXCLUDED.overdue_balance,
+    days_overdue = EXCLUDED.days_overdue;
+
+-- name: ListNsCustomers :many
+SELECT *
+FROM ns_customers
+ORDER BY internal_id, id;
 
 -- name: ResetNsCustomers :exec
 DELETE FROM ns_customers;
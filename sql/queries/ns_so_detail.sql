@@ -20,5 +20,18 @@ INSERT INTO ns_so_detail (
 )
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17);
 
+-- name: ListNsSoDetail :many
+SELECT *
+FROM ns_so_detail
+ORDER BY sfdc_opp_line_id, id;
+
+-- name: ListNsSoDetailWithArchive :many
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, customer_internal_id, product_internal_id, customer_project, so_number, document_date, start_date, end_date, item_name, item_display_name, line_start_date, line_end_date, quantity, unit_price, amount_gross, terms_days_till_net_due, source_file
+FROM ns_so_detail
+UNION ALL
+SELECT id, sfdc_opp_id, sfdc_opp_line_id, customer_internal_id, product_internal_id, customer_project, so_number, document_date, start_date, end_date, item_name, item_display_name, line_start_date, line_end_date, quantity, unit_price, amount_gross, terms_days_till_net_due, source_file
+FROM ns_so_detail_archive
+ORDER BY sfdc_opp_line_id, id;
+
 -- name: ResetNsSoDetail :exec
 DELETE FROM ns_so_detail;
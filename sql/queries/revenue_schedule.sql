@@ -0,0 +1,23 @@
+-- name: DeleteRevenueSchedule :exec
+DELETE FROM revenue_schedule
+WHERE source = $1;
+
+-- name: InsertRevenueSchedule :copyfrom
+INSERT INTO revenue_schedule (
+    source,
+    line_id,
+    line_ref,
+    customer,
+    product,
+    period,
+    days,
+    amount
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListRevenueSchedule :many
+SELECT *
+FROM revenue_schedule
+WHERE COALESCE(customer, '') ILIKE '%' || @customer::text || '%'
+  AND COALESCE(product, '') ILIKE '%' || @product::text || '%'
+ORDER BY period, customer, product, line_ref;
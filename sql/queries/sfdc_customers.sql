@@ -5,7 +5,16 @@ INSERT INTO sfdc_customers (
     last_activity,
     type
 )
-VALUES ($1, $2, $3, $4);
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (account_id_casesafe) DO UPDATE SET
+    account_name = EXCLUDED.account_name,
+    last_activity = EXCLUDED.last_activity,
+    type = EXCLUDED.type;
+
+-- name: ListSfdcCustomers :many
+SELECT *
+FROM sfdc_customers
+ORDER BY account_id_casesafe, id;
 
 -- name: ResetSfdcCustomers :exec
 DELETE FROM sfdc_customers;
@@ -8,5 +8,10 @@ INSERT INTO sfdc_price_book (
 )
 VALUES ($1, $2, $3, $4, $5);
 
+-- name: ListSfdcPriceBook :many
+SELECT *
+FROM sfdc_price_book
+ORDER BY product_code, price_book_name, id;
+
 -- name: ResetSfdcPriceBook :exec
 DELETE FROM sfdc_price_book;
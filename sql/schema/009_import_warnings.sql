@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE import_warnings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    batch TEXT NOT NULL,
+    line INTEGER NOT NULL,
+    field TEXT NOT NULL,
+    value TEXT,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX import_warnings_batch_idx ON import_warnings (batch);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_warnings;
@@ -0,0 +1,71 @@
+-- +goose Up
+-- Business keys for each data table. Rows repeating a key that is already
+-- taken by a row with a lower id are moved to <table>_removed_010 first, so
+-- the constraints can be added; nothing is deleted outright. Rolling back
+-- moves them back.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    k RECORD;
+    cond TEXT;
+    removed TEXT;
+    moved BIGINT;
+BEGIN
+    FOR k IN SELECT * FROM (VALUES
+        ('anrok_transactions', ARRAY['transaction_id']),
+        ('sfdc_opp_detail', ARRAY['opportunity_product_casesafe_id']),
+        ('ns_invoice_detail', ARRAY['document_number', 'sfdc_opp_line_id']),
+        ('ns_customers', ARRAY['internal_id'])
+    ) AS v(tbl, cols)
+    LOOP
+        -- Equality never matches NULL, as in the UNIQUE constraint.
+        SELECT string_agg(format('d.%1$I = t.%1$I', c), ' AND ') INTO cond FROM unnest(k.cols) AS c;
+        removed := k.tbl || '_removed_010';
+
+        EXECUTE format('SELECT COUNT(*) FROM %1$I t WHERE EXISTS (SELECT 1 FROM %1$I d WHERE %2$s AND d.id < t.id)', k.tbl, cond)
+            INTO moved;
+        IF moved > 0 THEN
+            EXECUTE format('CREATE TABLE %I (LIKE %I)', removed, k.tbl);
+            EXECUTE format('INSERT INTO %2$I SELECT t.* FROM %1$I t WHERE EXISTS (SELECT 1 FROM %1$I d WHERE %3$s AND d.id < t.id)', k.tbl, removed, cond);
+            EXECUTE format('DELETE FROM %I t USING %I r WHERE t.id = r.id', k.tbl, removed);
+            RAISE NOTICE '%: moved % rows repeating (%) to %', k.tbl, moved, array_to_string(k.cols, ', '), removed;
+        END IF;
+    END LOOP;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE anrok_transactions
+    ADD CONSTRAINT anrok_transactions_transaction_id_key UNIQUE (transaction_id);
+
+ALTER TABLE sfdc_opp_detail
+    ADD CONSTRAINT sfdc_opp_detail_opportunity_product_casesafe_id_key UNIQUE (opportunity_product_casesafe_id);
+
+-- Lines without an SFDC opportunity line (e.g. sales tax) have a NULL key and never conflict
+ALTER TABLE ns_invoice_detail
+    ADD CONSTRAINT ns_invoice_detail_document_number_sfdc_opp_line_id_key UNIQUE (document_number, sfdc_opp_line_id);
+
+ALTER TABLE ns_customers
+    ADD CONSTRAINT ns_customers_internal_id_key UNIQUE (internal_id);
+
+-- +goose Down
+ALTER TABLE ns_customers DROP CONSTRAINT IF EXISTS ns_customers_internal_id_key;
+ALTER TABLE ns_invoice_detail DROP CONSTRAINT IF EXISTS ns_invoice_detail_document_number_sfdc_opp_line_id_key;
+ALTER TABLE sfdc_opp_detail DROP CONSTRAINT IF EXISTS sfdc_opp_detail_opportunity_product_casesafe_id_key;
+ALTER TABLE anrok_transactions DROP CONSTRAINT IF EXISTS anrok_transactions_transaction_id_key;
+
+-- +goose StatementBegin
+DO $$
+DECLARE
+    tbl TEXT;
+BEGIN
+    FOREACH tbl IN ARRAY ARRAY['anrok_transactions', 'sfdc_opp_detail', 'ns_invoice_detail', 'ns_customers']
+    LOOP
+        IF to_regclass(tbl || '_removed_010') IS NOT NULL THEN
+            EXECUTE format('INSERT INTO %I SELECT * FROM %I', tbl, tbl || '_removed_010');
+            EXECUTE format('DROP TABLE %I', tbl || '_removed_010');
+        END IF;
+    END LOOP;
+END
+$$;
+-- +goose StatementEnd
@@ -0,0 +1,100 @@
+-- +goose Up
+-- Customer snapshots are upserted on their business key; history keeps every version.
+-- Repeated SFDC imports may have left duplicate accounts behind. As in 010,
+-- the row with the lowest id is kept and the others are moved to
+-- sfdc_customers_removed_011; rolling back moves them back.
+-- +goose StatementBegin
+DO $$
+DECLARE
+    moved BIGINT;
+BEGIN
+    SELECT COUNT(*) INTO moved
+    FROM sfdc_customers t
+    WHERE EXISTS (
+        SELECT 1 FROM sfdc_customers d
+        WHERE d.account_id_casesafe = t.account_id_casesafe AND d.id < t.id
+    );
+
+    IF moved > 0 THEN
+        CREATE TABLE sfdc_customers_removed_011 (LIKE sfdc_customers);
+        INSERT INTO sfdc_customers_removed_011
+        SELECT t.* FROM sfdc_customers t
+        WHERE EXISTS (
+            SELECT 1 FROM sfdc_customers d
+            WHERE d.account_id_casesafe = t.account_id_casesafe AND d.id < t.id
+        );
+        DELETE FROM sfdc_customers t USING sfdc_customers_removed_011 r WHERE t.id = r.id;
+        RAISE NOTICE 'sfdc_customers: moved % rows repeating account_id_casesafe to sfdc_customers_removed_011', moved;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
+
+ALTER TABLE sfdc_customers
+    ADD CONSTRAINT sfdc_customers_account_id_casesafe_key UNIQUE (account_id_casesafe);
+
+CREATE TABLE ns_customers_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    internal_id         TEXT NOT NULL,
+    salesforce_id_io    TEXT,
+    name                TEXT,
+    duplicate           TEXT,
+    company_name        TEXT,
+    balance             NUMERIC,
+    unbilled_orders     NUMERIC,
+    overdue_balance     NUMERIC,
+    days_overdue        NUMERIC,
+
+    valid_from          TIMESTAMP NOT NULL,
+    valid_to            TIMESTAMP
+);
+
+CREATE INDEX ns_customers_history_key_idx ON ns_customers_history (internal_id, valid_from);
+CREATE UNIQUE INDEX ns_customers_history_current_idx ON ns_customers_history (internal_id) WHERE valid_to IS NULL;
+
+CREATE TABLE sfdc_customers_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    account_id_casesafe TEXT NOT NULL,
+    account_name        TEXT,
+    last_activity       DATE,
+    type                TEXT,
+
+    valid_from          TIMESTAMP NOT NULL,
+    valid_to            TIMESTAMP
+);
+
+CREATE INDEX sfdc_customers_history_key_idx ON sfdc_customers_history (account_id_casesafe, valid_from);
+CREATE UNIQUE INDEX sfdc_customers_history_current_idx ON sfdc_customers_history (account_id_casesafe) WHERE valid_to IS NULL;
+
+-- Seed history with the customers already loaded
+INSERT INTO ns_customers_history (
+    internal_id, salesforce_id_io, name, duplicate, company_name,
+    balance, unbilled_orders, overdue_balance, days_overdue, valid_from
+)
+SELECT internal_id, salesforce_id_io, name, duplicate, company_name,
+       balance, unbilled_orders, overdue_balance, days_overdue, NOW()
+FROM ns_customers
+WHERE internal_id IS NOT NULL;
+
+INSERT INTO sfdc_customers_history (account_id_casesafe, account_name, last_activity, type, valid_from)
+SELECT account_id_casesafe, account_name, last_activity, type, NOW()
+FROM sfdc_customers
+WHERE account_id_casesafe IS NOT NULL;
+
+-- +goose Down
+DROP TABLE IF EXISTS sfdc_customers_history;
+DROP TABLE IF EXISTS ns_customers_history;
+ALTER TABLE sfdc_customers DROP CONSTRAINT IF EXISTS sfdc_customers_account_id_casesafe_key;
+
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF to_regclass('sfdc_customers_removed_011') IS NOT NULL THEN
+        INSERT INTO sfdc_customers SELECT * FROM sfdc_customers_removed_011;
+        DROP TABLE sfdc_customers_removed_011;
+    END IF;
+END
+$$;
+-- +goose StatementEnd
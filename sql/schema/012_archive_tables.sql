@@ -0,0 +1,20 @@
+-- +goose Up
+-- Closed periods are moved out of the live tables into same-shaped archive
+-- tables. Columns added to a live table later must be added here too.
+CREATE TABLE ns_so_detail_archive (LIKE ns_so_detail INCLUDING DEFAULTS, archived_at TIMESTAMP NOT NULL DEFAULT NOW());
+CREATE INDEX ns_so_detail_archive_period_idx ON ns_so_detail_archive (document_date);
+
+CREATE TABLE ns_invoice_detail_archive (LIKE ns_invoice_detail INCLUDING DEFAULTS, archived_at TIMESTAMP NOT NULL DEFAULT NOW());
+CREATE INDEX ns_invoice_detail_archive_period_idx ON ns_invoice_detail_archive (date);
+
+CREATE TABLE sfdc_opp_detail_archive (LIKE sfdc_opp_detail INCLUDING DEFAULTS, archived_at TIMESTAMP NOT NULL DEFAULT NOW());
+CREATE INDEX sfdc_opp_detail_archive_period_idx ON sfdc_opp_detail_archive (close_date);
+
+CREATE TABLE anrok_transactions_archive (LIKE anrok_transactions INCLUDING DEFAULTS, archived_at TIMESTAMP NOT NULL DEFAULT NOW());
+CREATE INDEX anrok_transactions_archive_period_idx ON anrok_transactions_archive (invoice_date);
+
+-- +goose Down
+DROP TABLE IF EXISTS anrok_transactions_archive;
+DROP TABLE IF EXISTS sfdc_opp_detail_archive;
+DROP TABLE IF EXISTS ns_invoice_detail_archive;
+DROP TABLE IF EXISTS ns_so_detail_archive;
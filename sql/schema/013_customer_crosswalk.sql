@@ -0,0 +1,16 @@
+-- +goose Up
+-- Links each NetSuite customer to its Salesforce account by 18-character ID.
+-- Rows with source 'ns' are rebuilt from ns_customers.salesforce_id_io after
+-- every customer import; 'manual' rows are overrides and are never rebuilt.
+CREATE TABLE customer_crosswalk (
+    ns_internal_id      TEXT PRIMARY KEY,
+    sfdc_account_id     TEXT,
+    source              TEXT NOT NULL DEFAULT 'ns' CHECK (source IN ('ns', 'manual')),
+    note                TEXT,
+    updated_at          TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX customer_crosswalk_sfdc_account_idx ON customer_crosswalk (sfdc_account_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS customer_crosswalk;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Ratable revenue schedule: one row per contract line per month, regenerated
+-- from sfdc_opp_detail or ns_so_detail after each import of that table.
+CREATE TABLE revenue_schedule (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+
+    source              TEXT NOT NULL CHECK (source IN ('sfdc', 'ns')),
+    line_id             UUID NOT NULL,   -- id of the source row
+    line_ref            TEXT,            -- SFDC line ID or SO number
+    customer            TEXT,
+    product             TEXT,
+    period              DATE NOT NULL,   -- first day of the month
+    days                INTEGER NOT NULL,
+    amount              NUMERIC(18, 2) NOT NULL,
+
+    generated_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    UNIQUE (source, line_id, period)
+);
+
+CREATE INDEX revenue_schedule_period_idx ON revenue_schedule (period);
+CREATE INDEX revenue_schedule_customer_idx ON revenue_schedule (customer, period);
+CREATE INDEX revenue_schedule_product_idx ON revenue_schedule (product, period);
+
+-- +goose Down
+DROP TABLE IF EXISTS revenue_schedule;
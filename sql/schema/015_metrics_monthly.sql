@@ -0,0 +1,20 @@
+-- +goose Up
+-- Monthly subscription metrics derived from sfdc_opp_detail, recomputed
+-- after each opportunity import.
+CREATE TABLE metrics_monthly (
+    period              DATE PRIMARY KEY,   -- first day of the month
+
+    mrr                 NUMERIC(18, 2) NOT NULL,
+    arr                 NUMERIC(18, 2) NOT NULL,
+    new_mrr             NUMERIC(18, 2) NOT NULL,
+    expansion_mrr       NUMERIC(18, 2) NOT NULL,
+    contraction_mrr     NUMERIC(18, 2) NOT NULL,
+    churned_mrr         NUMERIC(18, 2) NOT NULL,
+    bookings            NUMERIC(18, 2) NOT NULL,
+    customers           INTEGER NOT NULL,
+
+    computed_at         TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS metrics_monthly;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Record the CSV each row was imported from. The import sets tui.source_file
+-- for its transaction; rows inserted any other way get NULL.
+ALTER TABLE anrok_transactions ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+ALTER TABLE ns_customers ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+ALTER TABLE ns_invoice_detail ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+ALTER TABLE ns_so_detail ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+ALTER TABLE sfdc_customers ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+ALTER TABLE sfdc_opp_detail ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+ALTER TABLE sfdc_price_book ADD COLUMN source_file TEXT DEFAULT NULLIF(current_setting('tui.source_file', true), '');
+
+-- Archive tables keep the same columns as their live tables
+ALTER TABLE ns_so_detail_archive ADD COLUMN source_file TEXT;
+ALTER TABLE ns_invoice_detail_archive ADD COLUMN source_file TEXT;
+ALTER TABLE sfdc_opp_detail_archive ADD COLUMN source_file TEXT;
+ALTER TABLE anrok_transactions_archive ADD COLUMN source_file TEXT;
+
+-- +goose Down
+ALTER TABLE anrok_transactions_archive DROP COLUMN IF EXISTS source_file;
+ALTER TABLE sfdc_opp_detail_archive DROP COLUMN IF EXISTS source_file;
+ALTER TABLE ns_invoice_detail_archive DROP COLUMN IF EXISTS source_file;
+ALTER TABLE ns_so_detail_archive DROP COLUMN IF EXISTS source_file;
+ALTER TABLE sfdc_price_book DROP COLUMN IF EXISTS source_file;
+ALTER TABLE sfdc_opp_detail DROP COLUMN IF EXISTS source_file;
+ALTER TABLE sfdc_customers DROP COLUMN IF EXISTS source_file;
+ALTER TABLE ns_so_detail DROP COLUMN IF EXISTS source_file;
+ALTER TABLE ns_invoice_detail DROP COLUMN IF EXISTS source_file;
+ALTER TABLE ns_customers DROP COLUMN IF EXISTS source_file;
+ALTER TABLE anrok_transactions DROP COLUMN IF EXISTS source_file;